@@ -21,7 +21,7 @@ func (r *runners) InitAppCreate(parent *cobra.Command) *cobra.Command {
 	return cmd
 }
 
-func (r *runners) createApp(_ *cobra.Command, args []string) error {
+func (r *runners) createApp(cmd *cobra.Command, args []string) error {
 	if len(args) != 1 {
 		return errors.New("missing app name")
 	}
@@ -29,7 +29,7 @@ func (r *runners) createApp(_ *cobra.Command, args []string) error {
 
 	kotsRestClient := kotsclient.VendorV3Client{HTTPClient: *r.platformAPI}
 
-	app, err := kotsRestClient.CreateKOTSApp(appName)
+	app, err := kotsRestClient.CreateKOTSAppContext(cmd.Context(), appName)
 
 	if err != nil {
 		return errors.Wrap(err, "list apps")
@@ -46,5 +46,5 @@ func (r *runners) createApp(_ *cobra.Command, args []string) error {
 		},
 	}
 
-	return print.Apps(r.w, apps)
+	return print.Apps(outputFormat, r.w, apps)
 }
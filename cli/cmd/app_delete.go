@@ -22,7 +22,7 @@ func (r *runners) InitAppDelete(parent *cobra.Command) *cobra.Command {
 	return cmd
 }
 
-func (r *runners) deleteApp(_ *cobra.Command, args []string) error {
+func (r *runners) deleteApp(cmd *cobra.Command, args []string) error {
 	log := print.NewLogger(r.w)
 	if len(args) != 1 {
 		return errors.New("missing app slug or id")
@@ -30,7 +30,7 @@ func (r *runners) deleteApp(_ *cobra.Command, args []string) error {
 	appName := args[0]
 
 	log.ActionWithSpinner("Fetching App")
-	app, err := r.kotsAPI.GetApp(appName)
+	app, err := r.kotsAPI.GetAppContext(cmd.Context(), appName)
 	if err != nil {
 		log.FinishSpinnerWithError()
 		return errors.Wrap(err, "list apps")
@@ -39,7 +39,7 @@ func (r *runners) deleteApp(_ *cobra.Command, args []string) error {
 
 	apps := []types.AppAndChannels{{App: app}}
 
-	err = print.Apps(r.w, apps)
+	err = print.Apps(outputFormat, r.w, apps)
 	if err != nil {
 		return errors.Wrap(err, "print app")
 	}
@@ -56,13 +56,16 @@ func (r *runners) deleteApp(_ *cobra.Command, args []string) error {
 	}
 
 	log.ActionWithSpinner("Deleting App")
-	err = r.kotsAPI.DeleteKOTSApp(app.ID)
+	err = r.kotsAPI.DeleteKOTSAppContext(cmd.Context(), app.ID)
 	if err != nil {
 		log.FinishSpinnerWithError()
 		return errors.Wrap(err, "delete app")
 	}
 	log.FinishSpinner()
 
+	// a new app could be created with the same slug
+	forgetApp(*app)
+
 	return nil
 }
 
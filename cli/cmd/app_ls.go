@@ -22,15 +22,15 @@ func (r *runners) InitAppList(parent *cobra.Command) *cobra.Command {
 	return cmd
 }
 
-func (r *runners) listApps(_ *cobra.Command, args []string) error {
+func (r *runners) listApps(cmd *cobra.Command, args []string) error {
 
-	kotsApps, err := r.kotsAPI.ListApps()
+	kotsApps, err := r.kotsAPI.ListAppsContext(cmd.Context())
 	if err != nil {
 		return errors.Wrap(err, "list apps")
 	}
 
 	if len(args) == 0 {
-		return print.Apps(r.w, kotsApps)
+		return print.Apps(outputFormat, r.w, kotsApps)
 	}
 
 	appSearch := args[0]
@@ -40,5 +40,5 @@ func (r *runners) listApps(_ *cobra.Command, args []string) error {
 			resultApps = append(resultApps, app)
 		}
 	}
-	return print.Apps(r.w, resultApps)
+	return print.Apps(outputFormat, r.w, resultApps)
 }
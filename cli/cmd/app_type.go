@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/appcache"
+	"github.com/replicatedhq/replicated/pkg/platformclient"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var validAppTypeValues = map[string]interface{}{
+	"platform": nil,
+	"ship":     nil,
+	"kots":     nil,
+}
+
+// appCacheTTL is how long an app's type is cached. REPLICATED_APP_CACHE_TTL
+// changes it, a TTL of 0 disables the cache.
+func appCacheTTL() (time.Duration, error) {
+	value := os.Getenv("REPLICATED_APP_CACHE_TTL")
+	if value == "" {
+		return appcache.DefaultTTL, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.Wrap(err, "parse REPLICATED_APP_CACHE_TTL")
+	}
+	return ttl, nil
+}
+
+// appCacheOrigin identifies the APIs an app is looked up with. The platform
+// and kots apps are found through the platform origin and ship apps through
+// the graphql origin, so both are part of the cache key.
+func appCacheOrigin() string {
+	return platformOrigin + " " + graphqlOrigin
+}
+
+// resolveApp finds the app and its type. --app-type skips probing each API
+// for the app, and both are cached so later invocations skip the lookup
+// entirely. It also returns whether the app came from the cache.
+func (r *runners) resolveApp(ctx context.Context, appSlugOrID string) (*types.App, string, bool, error) {
+	if appTypeOverride != "" {
+		if _, ok := validAppTypeValues[appTypeOverride]; !ok {
+			return nil, "", false, errors.Errorf("app-type value %q not supported, supported values are [platform, ship, kots]", appTypeOverride)
+		}
+	}
+
+	ttl, err := appCacheTTL()
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	if ttl > 0 && appSlugOrID != "" {
+		cache := appcache.Load(appcache.DefaultPath(homeDir()), ttl)
+		app, appType, ok := cache.Get(appCacheOrigin(), appSlugOrID)
+		if ok && (appTypeOverride == "" || appTypeOverride == appType) {
+			return app, appType, true, nil
+		}
+	}
+
+	app, appType, err := r.lookupApp(ctx, appSlugOrID, ttl)
+	return app, appType, false, err
+}
+
+// lookupApp finds the app and its type with the API, and caches them for ttl
+func (r *runners) lookupApp(ctx context.Context, appSlugOrID string, ttl time.Duration) (*types.App, string, error) {
+	var app *types.App
+	var err error
+	appType := appTypeOverride
+	if appType != "" {
+		app, err = r.api.GetAppByTypeContext(ctx, appSlugOrID, appType)
+	} else {
+		app, appType, err = r.api.GetAppTypeContext(ctx, appSlugOrID)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if ttl > 0 && appSlugOrID != "" {
+		cache := appcache.Load(appcache.DefaultPath(homeDir()), ttl)
+		cache.Set(appCacheOrigin(), *app, appType)
+		// the cache is only an optimization, don't fail the command over it
+		_ = cache.Save()
+	}
+
+	return app, appType, nil
+}
+
+// forgetApp drops the app from the cache
+func forgetApp(app types.App) {
+	cache := appcache.Load(appcache.DefaultPath(homeDir()), appcache.DefaultTTL)
+	cache.Delete(appCacheOrigin(), app)
+	_ = cache.Save()
+}
+
+// retryWithFreshApp wraps the command so that when it fails with a 404 for an
+// app that came from the cache, the app is dropped from the cache and looked
+// up again. The app may have been deleted and created again with a new ID,
+// in which case the command is run again with the new ID.
+func (r *runners) retryWithFreshApp(cmd *cobra.Command, appSlugOrID string) {
+	runE := cmd.RunE
+	if runE == nil {
+		return
+	}
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		err := runE(cmd, args)
+		if !errors.Is(err, platformclient.ErrNotFound) {
+			return err
+		}
+
+		forgetApp(types.App{ID: r.appID, Slug: r.appSlug})
+		ttl, ttlErr := appCacheTTL()
+		if ttlErr != nil {
+			return err
+		}
+		app, appType, lookupErr := r.lookupApp(cmd.Context(), appSlugOrID, ttl)
+		if lookupErr != nil {
+			return errors.Wrapf(lookupErr, "look up app %s", appSlugOrID)
+		}
+		if app.ID == r.appID && appType == r.appType {
+			// the cached app was right, something else wasn't found
+			return err
+		}
+
+		r.appID, r.appSlug, r.appType = app.ID, app.Slug, appType
+		return runE(cmd, args)
+	}
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/appcache"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryWithFreshApp(t *testing.T) {
+	tests := []struct {
+		name      string
+		cachedID  string
+		channelID string
+		wantRuns  int
+		wantErr   string
+	}{
+		{
+			name:     "app created again with a new id",
+			cachedID: "old-id",
+			wantRuns: 2,
+		},
+		{
+			name:      "cached app is current",
+			cachedID:  testAppID,
+			channelID: "missing-id",
+			wantRuns:  1,
+			wantErr:   "Not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			t.Setenv("HOME", t.TempDir())
+			t.Setenv("REPLICATED_APP_CACHE_TTL", "")
+
+			previousOverride := appTypeOverride
+			appTypeOverride = "kots"
+			t.Cleanup(func() { appTypeOverride = previousOverride })
+
+			cache := appcache.Load(appcache.DefaultPath(homeDir()), appcache.DefaultTTL)
+			cache.Set(appCacheOrigin(), types.App{ID: tt.cachedID, Slug: "my-app"}, "kots")
+			req.NoError(cache.Save())
+
+			vendorAPI := newTestVendorAPI()
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v3/apps", func(w http.ResponseWriter, r *http.Request) {
+				apps := []types.KotsAppWithChannels{{Id: testAppID, Name: "My App", Slug: "my-app"}}
+				writeTestJSON(w, http.StatusOK, map[string]interface{}{"apps": apps})
+			})
+			mux.Handle("/", vendorAPI)
+			r := newTestRunners(t, mux)
+
+			app, appType, cached, err := r.resolveApp(context.Background(), "my-app")
+			req.NoError(err)
+			req.True(cached)
+			req.Equal(tt.cachedID, app.ID)
+			r.appID, r.appSlug, r.appType = app.ID, app.Slug, appType
+
+			runs := 0
+			_, err = executeTestCommand(r, func(parent *cobra.Command) {
+				cmd := &cobra.Command{
+					Use: "inspect",
+					RunE: func(cmd *cobra.Command, args []string) error {
+						runs++
+						if _, err := r.api.ListChannelsContext(cmd.Context(), r.appID, r.appType, r.appSlug, ""); err != nil {
+							return err
+						}
+						if tt.channelID == "" {
+							return nil
+						}
+						_, _, err := r.kotsAPI.GetChannelContext(cmd.Context(), r.appID, tt.channelID)
+						return errors.Cause(err)
+					},
+				}
+				parent.AddCommand(cmd)
+				r.retryWithFreshApp(cmd, "my-app")
+			}, "inspect")
+			if tt.wantErr != "" {
+				req.EqualError(err, tt.wantErr)
+			} else {
+				req.NoError(err)
+			}
+			req.Equal(tt.wantRuns, runs)
+			req.Equal(testAppID, r.appID)
+
+			app, _, ok := appcache.Load(appcache.DefaultPath(homeDir()), appcache.DefaultTTL).Get(appCacheOrigin(), "my-app")
+			req.True(ok)
+			req.Equal(testAppID, app.ID)
+		})
+	}
+}
@@ -25,12 +25,12 @@ func (r *runners) channelAdoption(cmd *cobra.Command, args []string) error {
 	chanID := args[0]
 
 	if r.appType == "platform" {
-		appChan, _, err := r.platformAPI.GetChannel(r.appID, chanID)
+		appChan, _, err := r.platformAPI.GetChannelContext(cmd.Context(), r.appID, chanID)
 		if err != nil {
 			return err
 		}
 
-		if err = print.ChannelAdoption(r.w, appChan.Adoption); err != nil {
+		if err = print.ChannelAdoption(outputFormat, r.w, appChan.Adoption); err != nil {
 			return err
 		}
 
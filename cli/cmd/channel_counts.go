@@ -25,12 +25,12 @@ func (r *runners) channelCounts(cmd *cobra.Command, args []string) error {
 	chanID := args[0]
 
 	if r.appType == "platform" {
-		appChan, _, err := r.api.GetChannel(r.appID, r.appType, chanID)
+		appChan, _, err := r.api.GetChannelContext(cmd.Context(), r.appID, r.appType, chanID)
 		if err != nil {
 			return err
 		}
 
-		if err = print.LicenseCounts(r.w, appChan.LicenseCounts); err != nil {
+		if err = print.LicenseCounts(outputFormat, r.w, appChan.LicenseCounts); err != nil {
 			return err
 		}
 	} else if r.appType == "ship" {
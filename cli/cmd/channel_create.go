@@ -24,10 +24,10 @@ func (r *runners) InitChannelCreate(parent *cobra.Command) {
 }
 
 func (r *runners) channelCreate(cmd *cobra.Command, args []string) error {
-	allChannels, err := r.api.CreateChannel(r.appID, r.appType, r.appSlug, r.args.channelCreateName, r.args.channelCreateDescription)
+	allChannels, err := r.api.CreateChannelContext(cmd.Context(), r.appID, r.appType, r.appSlug, r.args.channelCreateName, r.args.channelCreateDescription)
 	if err != nil {
 		return err
 	}
 
-	return print.Channels(r.w, allChannels)
+	return print.Channels(outputFormat, r.w, allChannels)
 }
@@ -25,7 +25,7 @@ func (r *runners) channelDisableSemanticVersioning(cmd *cobra.Command, args []st
 		return errors.New("channel ID is required")
 	}
 	chanID := args[0]
-	if err := r.api.UpdateSemanticVersioningForChannel(r.appType, r.appID, chanID, false); err != nil {
+	if err := r.api.UpdateSemanticVersioningForChannelContext(cmd.Context(), r.appType, r.appID, chanID, false); err != nil {
 		return err
 	}
 
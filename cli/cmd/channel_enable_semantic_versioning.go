@@ -25,7 +25,7 @@ func (r *runners) channelEnableSemanticVersioning(cmd *cobra.Command, args []str
 		return errors.New("channel ID is required")
 	}
 	chanID := args[0]
-	if err := r.api.UpdateSemanticVersioningForChannel(r.appType, r.appID, chanID, true); err != nil {
+	if err := r.api.UpdateSemanticVersioningForChannelContext(cmd.Context(), r.appType, r.appID, chanID, true); err != nil {
 		return err
 	}
 
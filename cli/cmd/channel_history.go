@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitChannelHistory(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:   "history CHANNEL",
+		Short: "List every release promoted to a channel",
+		Long: `List every release promoted to a channel, newest first, with the version
+label and release notes it was promoted with.
+
+  Example: replicated channel history Stable`,
+	}
+	parent.AddCommand(cmd)
+	cmd.RunE = r.channelHistory
+}
+
+func (r *runners) channelHistory(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("channel name or ID is required")
+	}
+
+	appChan, err := r.api.GetChannelByNameContext(cmd.Context(), r.appID, r.appType, r.appSlug, args[0])
+	if err != nil {
+		return err
+	}
+
+	releases, err := r.api.ListChannelReleasesContext(cmd.Context(), r.appID, r.appType, appChan.ID)
+	if err != nil {
+		return err
+	}
+
+	return print.ChannelReleases(outputFormat, r.w, releases)
+}
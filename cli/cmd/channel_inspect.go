@@ -19,18 +19,18 @@ func (r *runners) InitChannelInspect(parent *cobra.Command) {
 	cmd.RunE = r.channelInspect
 }
 
-func (r *runners) channelInspect(_ *cobra.Command, args []string) error {
+func (r *runners) channelInspect(cmd *cobra.Command, args []string) error {
 	if len(args) != 1 {
 		return errors.New("channel name or ID is required")
 	}
 
 	channelNameOrID := args[0]
-	appChan, err := r.api.GetChannelByName(r.appID, r.appType, r.appSlug, channelNameOrID)
+	appChan, err := r.api.GetChannelByNameContext(cmd.Context(), r.appID, r.appType, r.appSlug, channelNameOrID)
 	if err != nil {
 		return err
 	}
 
-	if err = print.ChannelAttrs(r.w, appChan); err != nil {
+	if err = print.ChannelAttrs(outputFormat, r.w, appChan); err != nil {
 		return err
 	}
 
@@ -17,10 +17,10 @@ func (r *runners) InitChannelList(parent *cobra.Command) {
 }
 
 func (r *runners) channelList(cmd *cobra.Command, args []string) error {
-	channels, err := r.api.ListChannels(r.appID, r.appType, r.appSlug, "")
+	channels, err := r.api.ListChannelsContext(cmd.Context(), r.appID, r.appType, r.appSlug, "")
 	if err != nil {
 		return err
 	}
 
-	return print.Channels(r.w, channels)
+	return print.Channels(outputFormat, r.w, channels)
 }
@@ -13,7 +13,7 @@ func (r *runners) InitChannelReleases(parent *cobra.Command) {
 		Short: "List all releases in a channel",
 		Long:  "List all releases in a channel",
 	}
-	cmd.Hidden = true // Not supported in KOTS
+	cmd.Hidden = true // replaced by channel history
 	parent.AddCommand(cmd)
 	cmd.RunE = r.channelReleases
 }
@@ -24,21 +24,10 @@ func (r *runners) channelReleases(cmd *cobra.Command, args []string) error {
 	}
 	chanID := args[0]
 
-	if r.appType == "platform" {
-
-		_, releases, err := r.api.GetChannel(r.appID, r.appType, chanID)
-		if err != nil {
-			return err
-		}
-
-		if err = print.ChannelReleases(r.w, releases); err != nil {
-			return err
-		}
-	} else if r.appType == "ship" {
-		return errors.New("This feature is not supported for Ship applications.")
-	} else if r.appType == "kots" {
-		return errors.New("This feature is not supported for Kots applications.")
+	releases, err := r.api.ListChannelReleasesContext(cmd.Context(), r.appID, r.appType, chanID)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return print.ChannelReleases(outputFormat, r.w, releases)
 }
@@ -24,7 +24,7 @@ func (r *runners) channelRemove(cmd *cobra.Command, args []string) error {
 	}
 	chanID := args[0]
 
-	if err := r.api.ArchiveChannel(r.appID, r.appType, chanID); err != nil {
+	if err := r.api.ArchiveChannelContext(cmd.Context(), r.appID, r.appType, chanID); err != nil {
 		return err
 	}
 
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/cli/print"
+	channels "github.com/replicatedhq/replicated/gen/go/v1"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitChannelRollback(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:   "rollback CHANNEL",
+		Short: "Set a channel back to a release it was promoted to before",
+		Long: `Set a channel back to a release it was promoted to before, with the version
+label and release notes it was originally promoted with. By default the channel
+is set to the release before the current one.
+
+  Example: replicated channel rollback Stable
+  Example: replicated channel rollback Stable --to 41`,
+	}
+	parent.AddCommand(cmd)
+
+	cmd.Flags().Int64Var(&r.args.channelRollbackTo, "to", 0, "The release sequence to roll back to. It must have been promoted to the channel before.")
+
+	cmd.RunE = r.channelRollback
+}
+
+func (r *runners) channelRollback(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("channel name or ID is required")
+	}
+
+	appChan, err := r.api.GetChannelByNameContext(cmd.Context(), r.appID, r.appType, r.appSlug, args[0])
+	if err != nil {
+		return err
+	}
+
+	releases, err := r.api.ListChannelReleasesContext(cmd.Context(), r.appID, r.appType, appChan.ID)
+	if err != nil {
+		return err
+	}
+
+	var target *channels.ChannelRelease
+	if cmd.Flags().Changed("to") {
+		target, err = findRollbackRelease(releases, r.args.channelRollbackTo)
+	} else {
+		target, err = previousRelease(releases)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "channel %s", appChan.Name)
+	}
+
+	if err := r.api.PromoteReleaseContext(cmd.Context(), r.appID, r.appType, target.ReleaseSequence, target.Version, target.ReleaseNotes, target.Required, appChan.ID); err != nil {
+		return err
+	}
+
+	return print.ChannelPromotions(outputFormat, r.w, []types.ChannelPromotion{
+		{
+			Channel:   args[0],
+			ChannelID: appChan.ID,
+			Sequence:  target.ReleaseSequence,
+			Promoted:  true,
+		},
+	})
+}
+
+// previousRelease returns the most recent promotion of a release other than
+// the current one. releases are sorted newest first.
+func previousRelease(releases []channels.ChannelRelease) (*channels.ChannelRelease, error) {
+	if len(releases) == 0 {
+		return nil, errors.New("no releases have been promoted")
+	}
+
+	current := releases[0].ReleaseSequence
+	for i := range releases[1:] {
+		if releases[i+1].ReleaseSequence != current {
+			return &releases[i+1], nil
+		}
+	}
+	return nil, errors.Errorf("no release before %d has been promoted", current)
+}
+
+// findRollbackRelease returns the most recent promotion of the release with
+// the given sequence. releases are sorted newest first.
+func findRollbackRelease(releases []channels.ChannelRelease, sequence int64) (*channels.ChannelRelease, error) {
+	if len(releases) > 0 && releases[0].ReleaseSequence == sequence {
+		return nil, errors.Errorf("release %d is already the current release", sequence)
+	}
+
+	for i := range releases {
+		if releases[i].ReleaseSequence == sequence {
+			return &releases[i], nil
+		}
+	}
+	return nil, errors.Errorf("release %d has not been promoted", sequence)
+}
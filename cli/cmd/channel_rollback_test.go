@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"testing"
+
+	channels "github.com/replicatedhq/replicated/gen/go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+// channelHistory is the history of a channel newest first, as the API returns it
+var channelHistory = []channels.ChannelRelease{
+	{ChannelSequence: 5, ReleaseSequence: 7, Version: "1.3.0-hotfix"},
+	{ChannelSequence: 4, ReleaseSequence: 7, Version: "1.3.0"},
+	{ChannelSequence: 3, ReleaseSequence: 6, Version: "1.2.1", ReleaseNotes: "fixes", Required: true},
+	{ChannelSequence: 2, ReleaseSequence: 4, Version: "1.2.0"},
+	{ChannelSequence: 1, ReleaseSequence: 4, Version: "1.2.0-rc"},
+}
+
+func TestPreviousRelease(t *testing.T) {
+	tests := []struct {
+		name         string
+		releases     []channels.ChannelRelease
+		wantSequence int64
+		wantVersion  string
+		wantErr      string
+	}{
+		{
+			name:         "previous release",
+			releases:     channelHistory[2:],
+			wantSequence: 4,
+			wantVersion:  "1.2.0",
+		},
+		{
+			name:         "current release promoted more than once",
+			releases:     channelHistory,
+			wantSequence: 6,
+			wantVersion:  "1.2.1",
+		},
+		{
+			name:     "only the current release",
+			releases: channelHistory[3:],
+			wantErr:  "no release before 4 has been promoted",
+		},
+		{
+			name:     "no releases",
+			releases: nil,
+			wantErr:  "no releases have been promoted",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			release, err := previousRelease(test.releases)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+				return
+			}
+			req.NoError(err)
+			req.Equal(test.wantSequence, release.ReleaseSequence)
+			req.Equal(test.wantVersion, release.Version)
+		})
+	}
+}
+
+func TestFindRollbackRelease(t *testing.T) {
+	tests := []struct {
+		name        string
+		releases    []channels.ChannelRelease
+		sequence    int64
+		wantRelease channels.ChannelRelease
+		wantErr     string
+	}{
+		{
+			name:        "keeps the label and notes it was promoted with",
+			releases:    channelHistory,
+			sequence:    6,
+			wantRelease: channelHistory[2],
+		},
+		{
+			name:        "latest promotion of a release promoted more than once",
+			releases:    channelHistory,
+			sequence:    4,
+			wantRelease: channelHistory[3],
+		},
+		{
+			name:     "current release",
+			releases: channelHistory,
+			sequence: 7,
+			wantErr:  "release 7 is already the current release",
+		},
+		{
+			name:     "sequence not in the channel",
+			releases: channelHistory,
+			sequence: 5,
+			wantErr:  "release 5 has not been promoted",
+		},
+		{
+			name:     "no releases",
+			releases: nil,
+			sequence: 5,
+			wantErr:  "release 5 has not been promoted",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			release, err := findRollbackRelease(test.releases, test.sequence)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+				return
+			}
+			req.NoError(err)
+			req.Equal(test.wantRelease, *release)
+		})
+	}
+}
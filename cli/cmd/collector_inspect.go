@@ -26,7 +26,7 @@ func (r *runners) collectorInspect(cmd *cobra.Command, args []string) error {
 	}
 	id := args[0]
 
-	collector, err := r.api.GetCollector(r.appID, id)
+	collector, err := r.api.GetCollectorContext(cmd.Context(), r.appID, id)
 	if err != nil {
 		if err == platformclient.ErrNotFound {
 			return fmt.Errorf("no such collector %s", id)
@@ -34,5 +34,5 @@ func (r *runners) collectorInspect(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	return print.Collector(r.w, collector)
+	return print.Collector(outputFormat, r.w, collector)
 }
@@ -17,10 +17,10 @@ func (r *runners) InitCollectorList(parent *cobra.Command) {
 }
 
 func (r *runners) collectorList(cmd *cobra.Command, args []string) error {
-	collectors, err := r.api.ListCollectors(r.appID, r.appType)
+	collectors, err := r.api.ListCollectorsContext(cmd.Context(), r.appID, r.appType)
 	if err != nil {
 		return err
 	}
 
-	return print.Collectors(r.w, collectors)
+	return print.Collectors(outputFormat, r.w, collectors)
 }
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/collectormigrate"
+	"github.com/replicatedhq/replicated/pkg/platformclient"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitCollectorMigrate(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:   "migrate SPEC_ID",
+		Short: "Convert a collector into a KOTS support bundle spec",
+		Long: `Convert the collector of a ship or platform application into a
+troubleshoot.sh/v1beta2 SupportBundle or Preflight spec for a KOTS release.
+
+Legacy "collect: v1" collectors without a troubleshoot.sh equivalent are left
+out and listed on stderr.
+
+  Example: replicated collector migrate 1uBzXBoBZu9Crp1uTlYaBPzUVFG --yaml-dir ./manifests`,
+	}
+	parent.AddCommand(cmd)
+
+	cmd.Flags().StringVar(&r.args.migrateCollectorYamlDir, "yaml-dir", "", "The directory of the KOTS release to write the spec to. The spec is printed to stdout if not set.")
+	cmd.Flags().BoolVar(&r.args.migrateCollectorForce, "force", false, "Overwrite the spec if the file already exists in --yaml-dir")
+
+	cmd.RunE = r.collectorMigrate
+}
+
+func (r *runners) collectorMigrate(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("collector ID is required")
+	}
+	id := args[0]
+
+	collector, err := r.api.GetCollectorContext(cmd.Context(), r.appID, id)
+	if err != nil {
+		if err == platformclient.ErrNotFound {
+			return fmt.Errorf("no such collector %s", id)
+		}
+		return err
+	}
+
+	result, err := collectormigrate.Migrate(collector.Name, collector.Config)
+	if err != nil {
+		return errors.Wrapf(err, "migrate collector %s", id)
+	}
+
+	for _, unconverted := range result.Unconverted {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Collector %q has no troubleshoot.sh equivalent and was left out\n", unconverted)
+	}
+
+	if r.args.migrateCollectorYamlDir == "" {
+		if _, err := r.w.Write(result.Content); err != nil {
+			return err
+		}
+		return r.w.Flush()
+	}
+
+	filename := filepath.Join(r.args.migrateCollectorYamlDir, result.Filename)
+	if _, err := os.Stat(filename); err == nil && !r.args.migrateCollectorForce {
+		return errors.Errorf("%s already exists, use --force to overwrite it", filename)
+	}
+	if err := ioutil.WriteFile(filename, result.Content, 0644); err != nil {
+		return errors.Wrapf(err, "write %s", filename)
+	}
+
+	fmt.Fprintf(r.w, "Collector %s written to %s\n", id, filename)
+	return r.w.Flush()
+}
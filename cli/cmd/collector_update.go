@@ -61,14 +61,14 @@ func (r *runners) collectorUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	if r.args.updateCollectorYaml != "" {
-		_, err := r.api.UpdateCollector(r.appID, specID, r.args.updateCollectorYaml)
+		_, err := r.api.UpdateCollectorContext(cmd.Context(), r.appID, specID, r.args.updateCollectorYaml)
 		if err != nil {
 			return errors.Wrap(err, "failure setting updates for collector")
 		}
 	}
 
 	if r.args.updateCollectorName != "" {
-		_, err := r.api.UpdateCollectorName(r.appID, specID, r.args.updateCollectorName)
+		_, err := r.api.UpdateCollectorNameContext(cmd.Context(), r.appID, specID, r.args.updateCollectorName)
 		if err != nil {
 			return errors.Wrap(err, "failure setting new yaml config for collector")
 		}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/manifoldco/promptui"
+	"github.com/mattn/go-isatty"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitCustomersArchiveCommand(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "archive",
+		Short: "archive a customer",
+		Long: `archive a customer, so that its license can no longer be used to install or update the application.
+
+Use "customer unarchive" to restore an archived customer.`,
+		RunE:         r.archiveCustomer,
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+	cmd.Flags().StringVar(&r.args.customerArchiveCustomer, "customer", "", "The Customer Name or ID")
+
+	return cmd
+}
+
+func (r *runners) InitCustomersUnarchiveCommand(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "unarchive",
+		Short:        "unarchive a customer",
+		Long:         `restore a customer that was archived`,
+		RunE:         r.unarchiveCustomer,
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+	cmd.Flags().StringVar(&r.args.customerArchiveCustomer, "customer", "", "The Customer Name or ID")
+
+	return cmd
+}
+
+func (r *runners) InitCustomersRmCommand(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rm",
+		Short: "remove a customer by archiving it",
+		Long: `remove a customer by archiving it, so that its license can no longer be used to install or update the application.
+
+The Vendor API can't delete customers, so rm archives the customer like "customer archive" does, after asking for confirmation.
+Use "customer unarchive" to restore it.`,
+		RunE:         r.removeCustomer,
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+	cmd.Flags().StringVar(&r.args.customerArchiveCustomer, "customer", "", "The Customer Name or ID")
+	cmd.Flags().BoolVarP(&r.args.customerRmForce, "force", "f", false, "Archive the customer without asking for confirmation")
+
+	return cmd
+}
+
+func (r *runners) archiveCustomer(cmd *cobra.Command, _ []string) error {
+	if r.args.customerArchiveCustomer == "" {
+		return errors.Errorf("missing or invalid parameters: customer")
+	}
+
+	customer, err := r.api.GetCustomerByNameContext(cmd.Context(), r.appType, r.appID, r.args.customerArchiveCustomer)
+	if err != nil {
+		return errors.Wrapf(err, "find customer %q", r.args.customerArchiveCustomer)
+	}
+
+	if err := r.api.ArchiveCustomerContext(cmd.Context(), r.appType, customer.ID); err != nil {
+		return errors.Wrapf(err, "archive customer %q", customer.Name)
+	}
+
+	fmt.Fprintf(r.w, "Customer %s successfully archived\n", customer.Name)
+	return r.w.Flush()
+}
+
+func (r *runners) unarchiveCustomer(cmd *cobra.Command, _ []string) error {
+	if r.args.customerArchiveCustomer == "" {
+		return errors.Errorf("missing or invalid parameters: customer")
+	}
+
+	customer, err := r.api.GetCustomerByNameContext(cmd.Context(), r.appType, r.appID, r.args.customerArchiveCustomer)
+	if err != nil {
+		return errors.Wrapf(err, "find customer %q", r.args.customerArchiveCustomer)
+	}
+
+	if err := r.api.UnarchiveCustomerContext(cmd.Context(), r.appType, customer.ID); err != nil {
+		return errors.Wrapf(err, "unarchive customer %q", customer.Name)
+	}
+
+	fmt.Fprintf(r.w, "Customer %s successfully unarchived\n", customer.Name)
+	return r.w.Flush()
+}
+
+func (r *runners) removeCustomer(cmd *cobra.Command, _ []string) error {
+	if r.args.customerArchiveCustomer == "" {
+		return errors.Errorf("missing or invalid parameters: customer")
+	}
+
+	customer, err := r.api.GetCustomerByNameContext(cmd.Context(), r.appType, r.appID, r.args.customerArchiveCustomer)
+	if err != nil {
+		return errors.Wrapf(err, "find customer %q", r.args.customerArchiveCustomer)
+	}
+
+	if !r.args.customerRmForce {
+		if !isatty.IsTerminal(os.Stdin.Fd()) {
+			return errors.New("stdin is not a terminal to confirm, use --force to archive the customer")
+		}
+		answer, err := promptConfirmRemoveCustomer(customer.Name)
+		if err != nil {
+			return errors.Wrap(err, "confirm removal")
+		}
+		if answer != "yes" {
+			return errors.New("prompt declined")
+		}
+	}
+
+	if err := r.api.ArchiveCustomerContext(cmd.Context(), r.appType, customer.ID); err != nil {
+		return errors.Wrapf(err, "archive customer %q", customer.Name)
+	}
+
+	fmt.Fprintf(r.w, "Customer %s successfully archived, customers can't be deleted\n", customer.Name)
+	return r.w.Flush()
+}
+
+func promptConfirmRemoveCustomer(name string) (string, error) {
+	prompt := promptui.Prompt{
+		Label:     fmt.Sprintf("Archive customer %s? Customers can't be deleted, it can be restored with customer unarchive:", name),
+		Templates: templates,
+		Default:   "",
+		Validate: func(input string) error {
+			if input == "no" || input == "yes" {
+				return nil
+			}
+			return errors.New(`only "yes" will be accepted`)
+		},
+	}
+
+	result, err := prompt.Run()
+	if err == promptui.ErrInterrupt {
+		return "", errors.New("interrupted")
+	}
+	return result, err
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mattn/go-isatty"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveCustomer(t *testing.T) {
+	tests := []struct {
+		name         string
+		archived     bool
+		args         []string
+		want         string
+		wantArchived bool
+		wantErr      string
+		// noTerminal is set for cases that expect stdin not to be a terminal
+		noTerminal bool
+	}{
+		{
+			name:         "archive",
+			args:         []string{"archive", "--customer", "acme"},
+			want:         "Customer acme successfully archived\n",
+			wantArchived: true,
+		},
+		{
+			name:         "archive by id",
+			args:         []string{"archive", "--customer", "customer-1"},
+			want:         "Customer acme successfully archived\n",
+			wantArchived: true,
+		},
+		{
+			name:         "rm with force",
+			args:         []string{"rm", "--customer", "acme", "--force"},
+			want:         "Customer acme successfully archived, customers can't be deleted\n",
+			wantArchived: true,
+		},
+		{
+			name:       "rm without a terminal to confirm",
+			args:       []string{"rm", "--customer", "acme"},
+			noTerminal: true,
+			wantErr:    "stdin is not a terminal to confirm, use --force to archive the customer",
+		},
+		{
+			name:         "unarchive",
+			archived:     true,
+			args:         []string{"unarchive", "--customer", "acme"},
+			want:         "Customer acme successfully unarchived\n",
+			wantArchived: false,
+		},
+		{
+			name:    "unknown customer",
+			args:    []string{"archive", "--customer", "globex"},
+			wantErr: `find customer "globex": customer "globex" not found`,
+		},
+		{
+			name:    "missing customer flag",
+			args:    []string{"unarchive"},
+			wantErr: "missing or invalid parameters: customer",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+			if test.noTerminal && isatty.IsTerminal(os.Stdin.Fd()) {
+				t.Skip("stdin is a terminal")
+			}
+
+			api := newTestVendorAPI()
+			r := newTestRunners(t, api)
+			api.addCustomer(types.CustomerOptions{Name: "acme", ChannelID: "stable-id", Type: "paid"})
+			init := func(parent *cobra.Command) {
+				r.InitCustomersArchiveCommand(parent)
+				r.InitCustomersUnarchiveCommand(parent)
+				r.InitCustomersRmCommand(parent)
+			}
+			if test.archived {
+				_, err := executeTestCommand(r, init, "archive", "--customer", "acme")
+				req.NoError(err)
+			}
+
+			out, err := executeTestCommand(r, init, test.args...)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+				req.Equal(test.archived, api.customer("acme").IsArchived)
+				return
+			}
+			req.NoError(err)
+			req.Equal(test.want, out)
+			req.Equal(test.wantArchived, api.customer("acme").IsArchived)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/replicatedhq/replicated/cli/print"
 	"github.com/replicatedhq/replicated/pkg/types"
@@ -20,13 +22,20 @@ func (r *runners) InitCustomersCreateCommand(parent *cobra.Command) *cobra.Comma
 	cmd.Flags().StringVar(&r.args.customerCreateChannel, "channel", "", "Release channel to which the customer should be assigned")
 	cmd.Flags().DurationVar(&r.args.customerCreateExpiryDuration, "expires-in", 0, "If set, an expiration date will be set on the license. Supports Go durations like '72h' or '3600m'")
 	cmd.Flags().BoolVar(&r.args.customerCreateEnsureChannel, "ensure-channel", false, "If set, channel will be created if it does not exist.")
+	cmd.Flags().StringVar(&r.args.customerCreateType, "type", "dev", "The license type, one of [dev, trial, paid, community]")
+	cmd.Flags().BoolVar(&r.args.customerCreateAirgap, "airgap", false, "If set, the license will allow airgap installs.")
+	cmd.Flags().BoolVar(&r.args.customerCreateGitops, "gitops", false, "If set, the license will allow the GitOps workflow.")
+	cmd.Flags().BoolVar(&r.args.customerCreateSnapshot, "snapshot", false, "If set, the license will allow snapshots.")
 
 	return cmd
 }
 
-func (r *runners) createCustomer(_ *cobra.Command, _ []string) error {
+func (r *runners) createCustomer(cmd *cobra.Command, _ []string) error {
+	if err := validateCustomerType(r.args.customerCreateType); err != nil {
+		return err
+	}
 
-	channel, err := r.api.GetOrCreateChannelByName(
+	channel, err := r.api.GetOrCreateChannelByNameContext(cmd.Context(),
 		r.appID,
 		r.appType,
 		r.appSlug,
@@ -38,10 +47,23 @@ func (r *runners) createCustomer(_ *cobra.Command, _ []string) error {
 		return errors.Wrap(err, "get channel")
 	}
 
-	customer, err := r.api.CreateCustomer(r.appID, r.appType, r.args.customerCreateName, channel.ID, r.args.customerCreateExpiryDuration)
+	opts := types.CustomerOptions{
+		Name:                r.args.customerCreateName,
+		ChannelID:           channel.ID,
+		Type:                r.args.customerCreateType,
+		IsAirgapEnabled:     r.args.customerCreateAirgap,
+		IsGitopsSupported:   r.args.customerCreateGitops,
+		IsSnapshotSupported: r.args.customerCreateSnapshot,
+	}
+	if r.args.customerCreateExpiryDuration > 0 {
+		expiresAt := time.Now().UTC().Add(r.args.customerCreateExpiryDuration)
+		opts.ExpiresAt = &expiresAt
+	}
+
+	customer, err := r.api.CreateCustomerWithOptionsContext(cmd.Context(), r.appID, r.appType, opts)
 	if err != nil {
 		return errors.Wrap(err, "create customer")
 	}
 
-	return print.Customers(r.w, []types.Customer{*customer})
+	return print.Customers(outputFormat, r.w, []types.Customer{*customer})
 }
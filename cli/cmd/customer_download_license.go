@@ -21,7 +21,7 @@ func (r *runners) InitCustomersDownloadLicenseCommand(parent *cobra.Command) *co
 	return cmd
 }
 
-func (r *runners) downloadCustomerLicense(_ *cobra.Command, _ []string) error {
+func (r *runners) downloadCustomerLicense(cmd *cobra.Command, _ []string) error {
 	if r.args.customerLicenseInspectCustomer == "" {
 		return errors.Errorf("missing or invalid parameters: customer")
 	}
@@ -29,12 +29,12 @@ func (r *runners) downloadCustomerLicense(_ *cobra.Command, _ []string) error {
 		return errors.Errorf("missing or invalid parameters: output")
 	}
 
-	customer, err := r.api.GetCustomerByName(r.appType, r.appID, r.args.customerLicenseInspectCustomer)
+	customer, err := r.api.GetCustomerByNameContext(cmd.Context(), r.appType, r.appID, r.args.customerLicenseInspectCustomer)
 	if err != nil {
 		return errors.Wrapf(err, "find customer %q", r.args.customerLicenseInspectCustomer)
 	}
 
-	license, err := r.api.DownloadLicense(r.appType, r.appID, customer.ID)
+	license, err := r.api.DownloadLicenseContext(cmd.Context(), r.appType, r.appID, customer.ID)
 	if err != nil {
 		return errors.Wrapf(err, "download license for customer %q", customer.Name)
 	}
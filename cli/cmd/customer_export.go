@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/customerimport"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitCustomersExportCommand(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export customers to a CSV or YAML file",
+		Long: `Export customers to a CSV or YAML file that can be read by "customer import".
+
+Entitlement values are not returned by the Vendor API with customers, so they are not exported.`,
+		RunE:         r.exportCustomers,
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+	cmd.Flags().StringVarP(&r.args.customerExportFile, "file", "f", "", "The file to write. The format is picked from the .csv, .yaml or .yml extension. Defaults to stdout.")
+	cmd.Flags().StringVar(&r.args.customerExportFormat, "format", customerimport.FormatCSV, "The format to write to stdout. Supported values are [csv, yaml].")
+
+	return cmd
+}
+
+func (r *runners) exportCustomers(cmd *cobra.Command, _ []string) error {
+	format := r.args.customerExportFormat
+	if r.args.customerExportFile != "" {
+		var err error
+		format, err = customerimport.FormatForPath(r.args.customerExportFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	customers, err := r.api.ListCustomersContext(cmd.Context(), r.appID, r.appType)
+	if err != nil {
+		return errors.Wrap(err, "list customers")
+	}
+
+	records := make([]customerimport.Record, 0, len(customers))
+	for _, customer := range customers {
+		records = append(records, customerimport.FromCustomer(customer))
+	}
+
+	if r.args.customerExportFile == "" {
+		if err := customerimport.Write(r.w, format, records); err != nil {
+			return err
+		}
+		return r.w.Flush()
+	}
+
+	f, err := os.Create(r.args.customerExportFile)
+	if err != nil {
+		return errors.Wrap(err, "create file")
+	}
+	if err := customerimport.Write(f, format, records); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "write %s", r.args.customerExportFile)
+	}
+	return f.Close()
+}
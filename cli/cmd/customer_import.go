@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/replicatedhq/replicated/pkg/customerimport"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitCustomersImportCommand(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create or update customers from a CSV or YAML file",
+		Long: `Create or update customers from a CSV or YAML file.
+
+Customers are matched to existing customers by name, so each name can only
+appear once in the file. New customers are created, existing customers are updated. The result of every row is printed, and the
+command fails if any row failed.
+
+A CSV file has a header row with the name and channel columns, and optionally
+type (dev, trial, paid or community) and expires_at (a date or RFC 3339 timestamp).
+Any other column is the value of the entitlement field with that name.
+
+  name,channel,type,expires_at,seats
+  acme,Stable,paid,2030-01-01,10
+
+A YAML file lists the customers under a customers key.
+
+  customers:
+    - name: acme
+      channel: Stable
+      type: paid
+      expiresAt: 2030-01-01
+      entitlements:
+        seats: "10"`,
+		RunE:         r.importCustomers,
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+	cmd.Flags().StringVarP(&r.args.customerImportFile, "file", "f", "", "The CSV (.csv) or YAML (.yaml, .yml) file to import")
+	cmd.Flags().BoolVar(&r.args.customerImportDryRun, "dry-run", false, "Validate the file and print what would be created or updated, without changing anything")
+
+	return cmd
+}
+
+func (r *runners) importCustomers(cmd *cobra.Command, _ []string) error {
+	if r.args.customerImportFile == "" {
+		return errors.New("file is required")
+	}
+
+	format, err := customerimport.FormatForPath(r.args.customerImportFile)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(r.args.customerImportFile)
+	if err != nil {
+		return errors.Wrap(err, "open file")
+	}
+	defer f.Close()
+
+	records, err := customerimport.Read(f, format)
+	if err != nil {
+		return errors.Wrapf(err, "read %s", r.args.customerImportFile)
+	}
+
+	channels, err := r.api.ListChannelsContext(cmd.Context(), r.appID, r.appType, r.appSlug, "")
+	if err != nil {
+		return errors.Wrap(err, "list channels")
+	}
+	channelIDs := map[string]string{}
+	for _, channel := range channels {
+		channelIDs[channel.Name] = channel.ID
+	}
+
+	customers, err := r.api.ListCustomersContext(cmd.Context(), r.appID, r.appType)
+	if err != nil {
+		return errors.Wrap(err, "list customers")
+	}
+	existing := map[string]types.Customer{}
+	for _, customer := range customers {
+		existing[customer.Name] = customer
+	}
+
+	results := make([]customerimport.Result, 0, len(records))
+	failed := 0
+	// rows are matched to customers by name, so a name can only be imported once
+	rows := map[string]int{}
+	for _, record := range records {
+		var result customerimport.Result
+		if row, ok := rows[record.Name]; ok && record.Name != "" {
+			result = customerimport.Result{
+				Row:    record.Row,
+				Name:   record.Name,
+				DryRun: r.args.customerImportDryRun,
+				Error:  errors.Errorf("customer %q is already imported by row %d", record.Name, row).Error(),
+			}
+		} else {
+			rows[record.Name] = record.Row
+			result = r.importCustomer(cmd.Context(), record, channelIDs, existing)
+		}
+		if result.Error != "" {
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	if err := print.CustomerImportResults(outputFormat, r.w, results); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return errors.Errorf("%d of %d rows failed", failed, len(records))
+	}
+	return nil
+}
+
+func (r *runners) importCustomer(ctx context.Context, record customerimport.Record, channelIDs map[string]string, existing map[string]types.Customer) customerimport.Result {
+	result := customerimport.Result{
+		Row:    record.Row,
+		Name:   record.Name,
+		DryRun: r.args.customerImportDryRun,
+	}
+
+	expiresAt, err := record.Validate()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	channelID, ok := channelIDs[record.Channel]
+	if !ok {
+		result.Error = errors.Errorf("channel %q not found", record.Channel).Error()
+		return result
+	}
+
+	opts := types.CustomerOptions{
+		Name:              record.Name,
+		ChannelID:         channelID,
+		Type:              record.Type,
+		ExpiresAt:         expiresAt,
+		EntitlementValues: record.EntitlementValues(),
+	}
+
+	customer, exists := existing[record.Name]
+	if !exists {
+		result.Action = customerimport.ActionCreate
+		if !r.args.customerImportDryRun {
+			_, err = r.api.CreateCustomerWithOptionsContext(ctx, r.appID, r.appType, opts)
+		}
+	} else {
+		result.Action = customerimport.ActionUpdate
+		// an update replaces the whole customer, so like customer update it
+		// starts from all of the customer's details, which the list may not have
+		details, err := r.api.GetCustomerContext(ctx, r.appType, r.appID, customer.ID)
+		if err != nil {
+			result.Error = errors.Wrapf(err, "get customer %q", customer.Name).Error()
+			return result
+		}
+		customer = *details
+		// blank fields keep their current values
+		if opts.Type == "" {
+			opts.Type = customer.Type
+		}
+		if opts.ExpiresAt == nil && customer.Expires != nil && !customer.Expires.IsZero() {
+			opts.ExpiresAt = &customer.Expires.Time
+		}
+		// the import format has no features, keep them as they are
+		opts.IsAirgapEnabled = customer.IsAirgapEnabled
+		opts.IsGitopsSupported = customer.IsGitopsSupported
+		opts.IsSnapshotSupported = customer.IsSnapshotSupported
+		if !r.args.customerImportDryRun {
+			_, err = r.api.UpdateCustomerContext(ctx, r.appID, r.appType, customer.ID, opts)
+		}
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	return result
+}
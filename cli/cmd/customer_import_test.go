@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/replicatedhq/replicated/pkg/customerimport"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCustomers(t *testing.T) {
+	tests := []struct {
+		name          string
+		csv           string
+		dryRun        bool
+		wantResults   []customerimport.Result
+		wantCustomers []string
+		wantErr       string
+	}{
+		{
+			name: "create and update",
+			csv:  "name,channel,type\nacme,Stable,paid\nexisting,Beta,\n",
+			wantResults: []customerimport.Result{
+				{Row: 1, Name: "acme", Action: customerimport.ActionCreate},
+				{Row: 2, Name: "existing", Action: customerimport.ActionUpdate},
+			},
+			wantCustomers: []string{"acme", "existing"},
+		},
+		{
+			name: "duplicate name",
+			csv:  "name,channel,type\nacme,Stable,paid\nacme,Beta,trial\n",
+			wantResults: []customerimport.Result{
+				{Row: 1, Name: "acme", Action: customerimport.ActionCreate},
+				{Row: 2, Name: "acme", Error: `customer "acme" is already imported by row 1`},
+			},
+			wantCustomers: []string{"acme", "existing"},
+			wantErr:       "1 of 2 rows failed",
+		},
+		{
+			name:   "duplicate name in dry run",
+			csv:    "name,channel,type\nacme,Stable,paid\nacme,Beta,trial\n",
+			dryRun: true,
+			wantResults: []customerimport.Result{
+				{Row: 1, Name: "acme", Action: customerimport.ActionCreate, DryRun: true},
+				{Row: 2, Name: "acme", DryRun: true, Error: `customer "acme" is already imported by row 1`},
+			},
+			wantCustomers: []string{"existing"},
+			wantErr:       "1 of 2 rows failed",
+		},
+		{
+			name: "unknown channel",
+			csv:  "name,channel,type\nacme,Nightly,paid\n",
+			wantResults: []customerimport.Result{
+				{Row: 1, Name: "acme", Error: `channel "Nightly" not found`},
+			},
+			wantCustomers: []string{"existing"},
+			wantErr:       "1 of 1 rows failed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			api := newTestVendorAPI()
+			r := newTestRunners(t, api)
+			outputFormat = print.FormatJSON
+			api.addCustomer(types.CustomerOptions{Name: "existing", ChannelID: "stable-id", Type: "dev"})
+
+			file := filepath.Join(t.TempDir(), "customers.csv")
+			req.NoError(ioutil.WriteFile(file, []byte(test.csv), 0644))
+
+			args := []string{"import", "--file", file}
+			if test.dryRun {
+				args = append(args, "--dry-run")
+			}
+			out, err := executeTestCommand(r, func(parent *cobra.Command) { r.InitCustomersImportCommand(parent) }, args...)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+			} else {
+				req.NoError(err)
+			}
+
+			results := []customerimport.Result{}
+			req.NoError(json.Unmarshal([]byte(out), &results))
+			req.Equal(test.wantResults, results)
+
+			customers := []string{}
+			for _, customer := range api.customers {
+				customers = append(customers, customer.Name)
+			}
+			sort.Strings(customers)
+			req.Equal(test.wantCustomers, customers)
+		})
+	}
+}
+
+func TestImportCustomersKeepsDetails(t *testing.T) {
+	req := require.New(t)
+
+	api := newTestVendorAPI()
+	api.summaryList = true
+	r := newTestRunners(t, api)
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	api.addCustomer(types.CustomerOptions{
+		Name:                "existing",
+		ChannelID:           "stable-id",
+		Type:                "paid",
+		ExpiresAt:           &expiresAt,
+		IsAirgapEnabled:     true,
+		IsSnapshotSupported: true,
+	})
+
+	file := filepath.Join(t.TempDir(), "customers.csv")
+	req.NoError(ioutil.WriteFile(file, []byte("name,channel,type\nexisting,Beta,\n"), 0644))
+	_, err := executeTestCommand(r, func(parent *cobra.Command) { r.InitCustomersImportCommand(parent) }, "import", "--file", file)
+	req.NoError(err)
+
+	customer := api.customer("existing")
+	req.Equal("Beta", customer.Channels[0].Name)
+	req.Equal("paid", customer.Type)
+	req.NotNil(customer.Expires)
+	req.True(expiresAt.Equal(customer.Expires.Time))
+	req.True(customer.IsAirgapEnabled)
+	req.False(customer.IsGitopsSupported)
+	req.True(customer.IsSnapshotSupported)
+}
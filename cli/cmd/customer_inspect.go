@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitCustomersInspectCommand(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "inspect",
+		Short:        "show details of a customer",
+		Long:         `show the channel, type, expiry and features of a customer`,
+		RunE:         r.inspectCustomer,
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+	cmd.Flags().StringVar(&r.args.customerInspectCustomer, "customer", "", "The Customer Name or ID")
+
+	return cmd
+}
+
+func (r *runners) inspectCustomer(cmd *cobra.Command, _ []string) error {
+	if r.args.customerInspectCustomer == "" {
+		return errors.Errorf("missing or invalid parameters: customer")
+	}
+
+	customer, err := r.findCustomer(cmd, r.args.customerInspectCustomer)
+	if err != nil {
+		return err
+	}
+
+	return print.Customer(outputFormat, r.w, customer)
+}
+
+// findCustomer resolves a customer name or ID and fetches all of its details
+func (r *runners) findCustomer(cmd *cobra.Command, nameOrID string) (*types.Customer, error) {
+	found, err := r.api.GetCustomerByNameContext(cmd.Context(), r.appType, r.appID, nameOrID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "find customer %q", nameOrID)
+	}
+
+	customer, err := r.api.GetCustomerContext(cmd.Context(), r.appType, r.appID, found.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get customer %q", found.Name)
+	}
+	return customer, nil
+}
+
+func validateCustomerType(customerType string) error {
+	for _, t := range types.CustomerTypes {
+		if customerType == t {
+			return nil
+		}
+	}
+	return errors.Errorf("type %q not supported, supported values are [%s]", customerType, strings.Join(types.CustomerTypes, ", "))
+}
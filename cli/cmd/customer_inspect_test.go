@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectCustomer(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		existing types.CustomerOptions
+		args     []string
+		want     string
+		wantErr  string
+	}{
+		{
+			name: "by name",
+			existing: types.CustomerOptions{
+				Name:            "acme",
+				Type:            "paid",
+				ExpiresAt:       &expiresAt,
+				IsAirgapEnabled: true,
+			},
+			args: []string{"--customer", "acme"},
+			want: `ID:           customer-1
+NAME:         acme
+CHANNELS:     Stable
+TYPE:         paid
+EXPIRES:      2030-01-02 03:04:05 +0000 UTC
+AIRGAP:       true
+GITOPS:       false
+SNAPSHOTS:    false
+ARCHIVED:     false
+`,
+		},
+		{
+			name:     "by id, never expires",
+			existing: types.CustomerOptions{Name: "acme", Type: "trial"},
+			args:     []string{"--customer", "customer-1"},
+			want: `ID:           customer-1
+NAME:         acme
+CHANNELS:     Stable
+TYPE:         trial
+EXPIRES:      Never
+AIRGAP:       false
+GITOPS:       false
+SNAPSHOTS:    false
+ARCHIVED:     false
+`,
+		},
+		{
+			name:     "unknown customer",
+			existing: types.CustomerOptions{Name: "acme", Type: "trial"},
+			args:     []string{"--customer", "globex"},
+			wantErr:  `find customer "globex": customer "globex" not found`,
+		},
+		{
+			name:     "missing customer flag",
+			existing: types.CustomerOptions{Name: "acme", Type: "trial"},
+			wantErr:  "missing or invalid parameters: customer",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			api := newTestVendorAPI()
+			r := newTestRunners(t, api)
+			test.existing.ChannelID = "stable-id"
+			api.addCustomer(test.existing)
+
+			args := append([]string{"inspect"}, test.args...)
+			out, err := executeTestCommand(r, func(parent *cobra.Command) { r.InitCustomersInspectCommand(parent) }, args...)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+				return
+			}
+			req.NoError(err)
+			req.Equal(test.want, out)
+		})
+	}
+}
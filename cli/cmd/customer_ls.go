@@ -18,12 +18,12 @@ func (r *runners) InitCustomersLSCommand(parent *cobra.Command) *cobra.Command {
 	return customersCmd
 }
 
-func (r *runners) listCustomers(_ *cobra.Command, _ []string) error {
+func (r *runners) listCustomers(cmd *cobra.Command, _ []string) error {
 
-	customers, err := r.api.ListCustomers(r.appID, r.appType)
+	customers, err := r.api.ListCustomersContext(cmd.Context(), r.appID, r.appType)
 	if err != nil {
 		return errors.Wrap(err, "list customers")
 	}
 
-	return print.Customers(r.w, customers)
+	return print.Customers(outputFormat, r.w, customers)
 }
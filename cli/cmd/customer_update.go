@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitCustomersUpdateCommand(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "update a customer",
+		Long: `update a customer's name, channel, expiry, type or features.
+
+Only the flags that are passed are changed, everything else keeps its current value.`,
+		RunE:         r.updateCustomer,
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+	cmd.Flags().StringVar(&r.args.customerUpdateCustomer, "customer", "", "The Customer Name or ID")
+	cmd.Flags().StringVar(&r.args.customerUpdateName, "name", "", "The new name of the customer")
+	cmd.Flags().StringVar(&r.args.customerUpdateChannel, "channel", "", "Release channel to which the customer should be assigned")
+	cmd.Flags().BoolVar(&r.args.customerUpdateEnsureChannel, "ensure-channel", false, "If set, channel will be created if it does not exist.")
+	cmd.Flags().DurationVar(&r.args.customerUpdateExpiryDuration, "expires-in", 0, "Set the license to expire this long from now. Supports Go durations like '72h' or '3600m'. 0 removes the expiration date.")
+	cmd.Flags().StringVar(&r.args.customerUpdateType, "type", "", "The license type, one of [dev, trial, paid, community]")
+	cmd.Flags().BoolVar(&r.args.customerUpdateAirgap, "airgap", false, "Whether the license allows airgap installs.")
+	cmd.Flags().BoolVar(&r.args.customerUpdateGitops, "gitops", false, "Whether the license allows the GitOps workflow.")
+	cmd.Flags().BoolVar(&r.args.customerUpdateSnapshot, "snapshot", false, "Whether the license allows snapshots.")
+
+	return cmd
+}
+
+func (r *runners) updateCustomer(cmd *cobra.Command, _ []string) error {
+	if r.args.customerUpdateCustomer == "" {
+		return errors.Errorf("missing or invalid parameters: customer")
+	}
+	flags := cmd.Flags()
+	if flags.Changed("type") {
+		if err := validateCustomerType(r.args.customerUpdateType); err != nil {
+			return err
+		}
+	}
+
+	customer, err := r.findCustomer(cmd, r.args.customerUpdateCustomer)
+	if err != nil {
+		return err
+	}
+
+	// the API replaces the whole customer, so start from its current values
+	opts := types.CustomerOptions{
+		Name:                customer.Name,
+		Type:                customer.Type,
+		IsAirgapEnabled:     customer.IsAirgapEnabled,
+		IsGitopsSupported:   customer.IsGitopsSupported,
+		IsSnapshotSupported: customer.IsSnapshotSupported,
+	}
+	if len(customer.Channels) > 0 {
+		opts.ChannelID = customer.Channels[0].ID
+	}
+	// customers that never expire have a zero expiry
+	if customer.Expires != nil && !customer.Expires.IsZero() {
+		opts.ExpiresAt = &customer.Expires.Time
+	}
+
+	if flags.Changed("name") {
+		opts.Name = r.args.customerUpdateName
+	}
+	if flags.Changed("channel") {
+		channel, err := r.api.GetOrCreateChannelByNameContext(cmd.Context(),
+			r.appID,
+			r.appType,
+			r.appSlug,
+			r.args.customerUpdateChannel,
+			"",
+			r.args.customerUpdateEnsureChannel,
+		)
+		if err != nil {
+			return errors.Wrap(err, "get channel")
+		}
+		opts.ChannelID = channel.ID
+	}
+	if flags.Changed("expires-in") {
+		opts.ExpiresAt = nil
+		if r.args.customerUpdateExpiryDuration > 0 {
+			expiresAt := time.Now().UTC().Add(r.args.customerUpdateExpiryDuration)
+			opts.ExpiresAt = &expiresAt
+		}
+	}
+	if flags.Changed("type") {
+		opts.Type = r.args.customerUpdateType
+	}
+	if flags.Changed("airgap") {
+		opts.IsAirgapEnabled = r.args.customerUpdateAirgap
+	}
+	if flags.Changed("gitops") {
+		opts.IsGitopsSupported = r.args.customerUpdateGitops
+	}
+	if flags.Changed("snapshot") {
+		opts.IsSnapshotSupported = r.args.customerUpdateSnapshot
+	}
+
+	updated, err := r.api.UpdateCustomerContext(cmd.Context(), r.appID, r.appType, customer.ID, opts)
+	if err != nil {
+		return errors.Wrap(err, "update customer")
+	}
+
+	return print.Customer(outputFormat, r.w, updated)
+}
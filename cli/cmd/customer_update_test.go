@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateCustomer(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		existing types.CustomerOptions
+		args     []string
+		want     types.CustomerOptions
+		wantErr  string
+	}{
+		{
+			name: "rename keeps everything else",
+			existing: types.CustomerOptions{
+				Name:                "acme",
+				ChannelID:           "stable-id",
+				Type:                "paid",
+				ExpiresAt:           &expiresAt,
+				IsAirgapEnabled:     true,
+				IsSnapshotSupported: true,
+			},
+			args: []string{"--name", "acme-corp"},
+			want: types.CustomerOptions{
+				Name:                "acme-corp",
+				ChannelID:           "stable-id",
+				Type:                "paid",
+				ExpiresAt:           &expiresAt,
+				IsAirgapEnabled:     true,
+				IsSnapshotSupported: true,
+			},
+		},
+		{
+			name:     "customer that never expires",
+			existing: types.CustomerOptions{Name: "acme", ChannelID: "stable-id", Type: "trial"},
+			args:     []string{"--gitops"},
+			want: types.CustomerOptions{
+				Name:              "acme",
+				ChannelID:         "stable-id",
+				Type:              "trial",
+				IsGitopsSupported: true,
+			},
+		},
+		{
+			name:     "expires-in 0 removes the expiry",
+			existing: types.CustomerOptions{Name: "acme", ChannelID: "stable-id", Type: "paid", ExpiresAt: &expiresAt},
+			args:     []string{"--expires-in", "0"},
+			want:     types.CustomerOptions{Name: "acme", ChannelID: "stable-id", Type: "paid"},
+		},
+		{
+			name:     "channel, type and features",
+			existing: types.CustomerOptions{Name: "acme", ChannelID: "stable-id", Type: "trial", IsAirgapEnabled: true},
+			args:     []string{"--channel", "Beta", "--type", "paid", "--airgap=false", "--snapshot"},
+			want: types.CustomerOptions{
+				Name:                "acme",
+				ChannelID:           "beta-id",
+				Type:                "paid",
+				IsSnapshotSupported: true,
+			},
+		},
+		{
+			name:     "ensure channel",
+			existing: types.CustomerOptions{Name: "acme", ChannelID: "stable-id", Type: "dev"},
+			args:     []string{"--channel", "Nightly", "--ensure-channel"},
+			want:     types.CustomerOptions{Name: "acme", ChannelID: "channel-4", Type: "dev"},
+		},
+		{
+			name:     "unknown channel",
+			existing: types.CustomerOptions{Name: "acme", ChannelID: "stable-id", Type: "dev"},
+			args:     []string{"--channel", "Nightly"},
+			wantErr:  `get channel: find channel "Nightly": No channel "Nightly" `,
+		},
+		{
+			name:     "invalid type",
+			existing: types.CustomerOptions{Name: "acme", ChannelID: "stable-id", Type: "dev"},
+			args:     []string{"--type", "enterprise"},
+			wantErr:  `type "enterprise" not supported, supported values are [dev, trial, paid, community]`,
+		},
+		{
+			name:     "unknown customer",
+			existing: types.CustomerOptions{Name: "acme", ChannelID: "stable-id", Type: "dev"},
+			args:     []string{"--customer", "globex", "--type", "paid"},
+			wantErr:  `find customer "globex": customer "globex" not found`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			api := newTestVendorAPI()
+			r := newTestRunners(t, api)
+			before := api.addCustomer(test.existing)
+
+			args := append([]string{"update", "--customer", test.existing.Name}, test.args...)
+			_, err := executeTestCommand(r, func(parent *cobra.Command) { r.InitCustomersUpdateCommand(parent) }, args...)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+				// nothing is changed when the update fails
+				req.Equal(before, api.customer(before.ID))
+				return
+			}
+			req.NoError(err)
+
+			got := api.customer(before.ID)
+			req.Equal(test.want.Name, got.Name)
+			req.Len(got.Channels, 1)
+			req.Equal(test.want.ChannelID, got.Channels[0].ID)
+			req.Equal(test.want.Type, got.Type)
+			if test.want.ExpiresAt == nil {
+				req.Nil(got.Expires)
+			} else {
+				req.NotNil(got.Expires)
+				req.True(test.want.ExpiresAt.Equal(got.Expires.Time), "expires at %s, want %s", got.Expires, test.want.ExpiresAt)
+			}
+			req.Equal(test.want.IsAirgapEnabled, got.IsAirgapEnabled)
+			req.Equal(test.want.IsGitopsSupported, got.IsGitopsSupported)
+			req.Equal(test.want.IsSnapshotSupported, got.IsSnapshotSupported)
+		})
+	}
+}
+
+func TestUpdateCustomerExpiresIn(t *testing.T) {
+	req := require.New(t)
+
+	api := newTestVendorAPI()
+	r := newTestRunners(t, api)
+	api.addCustomer(types.CustomerOptions{Name: "acme", ChannelID: "stable-id", Type: "paid"})
+
+	start := time.Now().UTC()
+	_, err := executeTestCommand(r, func(parent *cobra.Command) { r.InitCustomersUpdateCommand(parent) }, "update", "--customer", "acme", "--expires-in", "72h")
+	req.NoError(err)
+
+	expires := api.customer("acme").Expires
+	req.NotNil(expires)
+	// the expiry is sent to the second
+	req.False(expires.Before(start.Add(72 * time.Hour).Truncate(time.Second)))
+	req.False(expires.After(time.Now().UTC().Add(72 * time.Hour)))
+}
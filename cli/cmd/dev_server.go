@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/fakeapi"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitDevServer(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev-server",
+		Short: "Run a fake Vendor API for local development and tests",
+		Long: `Run a fake Vendor API for local development and tests.
+
+The server implements the KOTS endpoints the CLI uses for apps, channels,
+releases, customers, licenses and installers, the v1 endpoints for platform
+apps' channels and releases, and the GraphQL operations for ship apps'
+channels and releases.
+
+State is kept in memory, or in the --state file so it survives restarts. When
+--token is set, requests must use that token, otherwise any token is accepted.
+Point the CLI at the server with the environment variables it prints.`,
+		Example: `  # start a server with one KOTS app
+  replicated dev-server --kots-app "My App" --state /tmp/replicated-dev.json
+
+  # in another shell
+  export REPLICATED_API_ORIGIN=http://127.0.0.1:3000
+  export REPLICATED_GRAPHQL_ORIGIN=http://127.0.0.1:3000/graphql
+  export REPLICATED_API_TOKEN=fake
+  replicated release ls --app my-app`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+
+	cmd.Flags().StringVar(&r.args.devServerListen, "listen", "127.0.0.1:3000", "The address to listen on")
+	cmd.Flags().StringVar(&r.args.devServerState, "state", "", "A JSON file to load the state from and save it to")
+	cmd.Flags().StringSliceVar(&r.args.devServerKotsApps, "kots-app", nil, "Create a KOTS app with this name on start, unless it exists. Can be repeated.")
+	cmd.Flags().StringSliceVar(&r.args.devServerPlatformApps, "platform-app", nil, "Create a platform app with this name on start, unless it exists. Can be repeated.")
+	cmd.Flags().StringSliceVar(&r.args.devServerShipApps, "ship-app", nil, "Create a ship app with this name on start, unless it exists. Can be repeated.")
+
+	cmd.RunE = r.devServer
+	return cmd
+}
+
+func (r *runners) devServer(cmd *cobra.Command, _ []string) error {
+	server, err := fakeapi.NewServer(fakeapi.Options{
+		StatePath: r.args.devServerState,
+		Token:     apiToken,
+	})
+	if err != nil {
+		return errors.Wrap(err, "create server")
+	}
+
+	existing := map[string]bool{}
+	for _, app := range server.State().Apps {
+		existing[app.Type+"/"+app.Name] = true
+	}
+	seeds := []struct {
+		appType string
+		names   []string
+	}{
+		{fakeapi.AppTypeKots, r.args.devServerKotsApps},
+		{fakeapi.AppTypePlatform, r.args.devServerPlatformApps},
+		{fakeapi.AppTypeShip, r.args.devServerShipApps},
+	}
+	for _, seed := range seeds {
+		for _, name := range seed.names {
+			if existing[seed.appType+"/"+name] {
+				continue
+			}
+			if _, err := server.AddApp(name, seed.appType); err != nil {
+				return errors.Wrapf(err, "create app %s", name)
+			}
+		}
+	}
+
+	listener, err := net.Listen("tcp", r.args.devServerListen)
+	if err != nil {
+		return errors.Wrap(err, "listen")
+	}
+
+	token := apiToken
+	if token == "" {
+		token = "fake"
+	}
+	origin := "http://" + listener.Addr().String()
+	fmt.Fprintf(r.w, "export REPLICATED_API_ORIGIN=%s\n", origin)
+	fmt.Fprintf(r.w, "export REPLICATED_GRAPHQL_ORIGIN=%s/graphql\n", origin)
+	fmt.Fprintf(r.w, "export REPLICATED_API_TOKEN=%s\n", token)
+	for _, app := range server.State().Apps {
+		fmt.Fprintf(r.w, "# %s app %q, slug %s\n", app.Type, app.Name, app.Slug)
+	}
+	r.w.Flush()
+
+	httpServer := &http.Server{Handler: server}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return errors.Wrap(err, "serve")
+	case <-cmd.Context().Done():
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return errors.Wrap(httpServer.Shutdown(ctx), "shut down server")
+}
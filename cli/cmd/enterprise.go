@@ -1,9 +1,10 @@
 package cmd
 
 import (
-	"io/ioutil"
 	"os"
 
+	"github.com/manifoldco/promptui"
+	"github.com/mattn/go-isatty"
 	"github.com/pkg/errors"
 	"github.com/replicatedhq/replicated/pkg/enterpriseclient"
 	"github.com/spf13/cobra"
@@ -16,6 +17,13 @@ func (r *runners) InitEnterpriseCommand(parent *cobra.Command) *cobra.Command {
 		SilenceUsage: true,
 		Long:         `The enterprise command allows approved enterprise to create custom installers, release channels and policies for vendors`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := configureTransport(); err != nil {
+				return err
+			}
+			if err := applyProfile(); err != nil {
+				return errors.Wrap(err, "apply profile")
+			}
+
 			if cmd.Use == "init" {
 				r.enterpriseClient = enterpriseclient.NewHTTPClient(enterpriseOrigin, nil)
 				return nil
@@ -28,20 +36,12 @@ func (r *runners) InitEnterpriseCommand(parent *cobra.Command) *cobra.Command {
 				}
 			}
 
-			if _, err := os.Stat(enterprisePrivateKeyPath); err != nil {
-				if os.IsNotExist(err) {
-					return errors.Errorf("file %s does not exist", enterprisePrivateKeyPath)
-				}
-
-				return err
-			}
-
-			privateKeyContents, err := ioutil.ReadFile(enterprisePrivateKeyPath)
+			signer, err := enterpriseclient.NewSigner(enterprisePrivateKeyPath, enterprisePassphrase)
 			if err != nil {
 				return err
 			}
 
-			r.enterpriseClient = enterpriseclient.NewHTTPClient(enterpriseOrigin, privateKeyContents)
+			r.enterpriseClient = enterpriseclient.NewHTTPClientWithSigner(enterpriseOrigin, signer)
 
 			return nil
 		},
@@ -50,11 +50,44 @@ func (r *runners) InitEnterpriseCommand(parent *cobra.Command) *cobra.Command {
 
 	// TODO remove the app and token persistent flags
 
-	enterpriseCommand.PersistentFlags().StringVar(&enterprisePrivateKeyPath, "private-key", enterprisePrivateKeyPath, "Path to the private key used to sign requests")
+	enterpriseCommand.PersistentFlags().StringVar(&enterprisePrivateKeyPath, "private-key", enterprisePrivateKeyPath, "Private key used to sign requests: a path or file:// URI to a PEM key, ssh-agent://[/path/to/socket][?fingerprint=SHA256:...] for a key in ssh-agent, or exec:///path/to/command[?arg=...] for an external signer command")
 
 	return enterpriseCommand
 }
 
+// enterprisePassphrase reads the passphrase for an encrypted private key
+// from the environment, prompting for it when it is not set. It can only
+// prompt when stdin is a terminal.
+func enterprisePassphrase() ([]byte, error) {
+	if passphrase := os.Getenv("REPLICATED_PRIVATEKEY_PASSPHRASE"); passphrase != "" {
+		return []byte(passphrase), nil
+	}
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return nil, errors.New("stdin is not a terminal to prompt for the passphrase, set REPLICATED_PRIVATEKEY_PASSPHRASE")
+	}
+
+	prompt := promptui.Prompt{
+		Label:     "Private Key Passphrase:",
+		Templates: templates,
+		Mask:      '*',
+		Validate: func(input string) error {
+			if input == "" {
+				return errors.New("a passphrase is required")
+			}
+			return nil
+		},
+	}
+
+	// the prompt asks again until the input is valid, so any error ends it
+	result, err := prompt.Run()
+	if err == promptui.ErrInterrupt {
+		return nil, errors.New("interrupted")
+	} else if err != nil {
+		return nil, errors.Wrap(err, "prompt for passphrase")
+	}
+	return []byte(result), nil
+}
+
 func homeDir() string {
 	if h := os.Getenv("HOME"); h != "" {
 		return h
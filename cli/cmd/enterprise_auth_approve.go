@@ -22,7 +22,7 @@ func (r *runners) InitEnterpriseAuthApprove(parent *cobra.Command) {
 }
 
 func (r *runners) enterpriseAuthApprove(cmd *cobra.Command, args []string) error {
-	err := r.enterpriseClient.AuthApprove(r.args.enterpriseAuthApproveFingerprint)
+	err := r.enterpriseClient.AuthApproveContext(cmd.Context(), r.args.enterpriseAuthApproveFingerprint)
 	if err != nil {
 		return err
 	}
@@ -1,27 +1,59 @@
 package cmd
 
 import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/enterpriseclient"
 	"github.com/spf13/cobra"
 )
 
 func (r *runners) InitEnterpriseAuthInit(parent *cobra.Command) {
 	cmd := &cobra.Command{
-		Use:          "init",
-		Short:        "initialize authentication",
-		Long:         `Create a keypair to begin authentication`,
+		Use:   "init",
+		Short: "initialize authentication",
+		Long: `Create a keypair to begin authentication.
+
+A new key is written to ~/.replicated/enterprise, encrypted with a passphrase
+when --encrypt is set. To register a key held in ssh-agent or by an external
+signer command instead, pass an ssh-agent:// or exec:// URI to --private-key.`,
 		SilenceUsage: true,
 	}
 	parent.AddCommand(cmd)
 
 	cmd.Flags().StringVar(&r.args.enterpriseAuthInitCreateOrg, "create-org", "", "If this flag is provided, a new organization will be created with the specified name. If not, the auth request will have to be approved by Replicated or your already authenticated organization")
 
+	cmd.Flags().BoolVar(&r.args.enterpriseAuthInitEncrypt, "encrypt", false, "Encrypt the generated private key with a passphrase, read from REPLICATED_PRIVATEKEY_PASSPHRASE or prompted for")
+
 	cmd.RunE = r.enterpriseAuthInit
 }
 
 func (r *runners) enterpriseAuthInit(cmd *cobra.Command, args []string) error {
-	err := r.enterpriseClient.AuthInit(r.args.enterpriseAuthInitCreateOrg)
-	if err != nil {
-		return err
+	opts := enterpriseclient.AuthInitOptions{
+		OrganizationName: r.args.enterpriseAuthInitCreateOrg,
 	}
-	return nil
+
+	privateKey := enterprisePrivateKeyPath
+	if privateKey == "" {
+		privateKey = os.Getenv("REPLICATED_PRIVATEKEY")
+	}
+	if strings.HasPrefix(privateKey, "ssh-agent://") || strings.HasPrefix(privateKey, "exec://") {
+		if r.args.enterpriseAuthInitEncrypt {
+			return errors.New("--encrypt only applies to generated keys")
+		}
+		signer, err := enterpriseclient.NewSigner(privateKey, nil)
+		if err != nil {
+			return err
+		}
+		opts.Signer = signer
+	} else if r.args.enterpriseAuthInitEncrypt {
+		passphrase, err := enterprisePassphrase()
+		if err != nil {
+			return errors.Wrap(err, "read passphrase")
+		}
+		opts.Passphrase = passphrase
+	}
+
+	return r.enterpriseClient.AuthInitWithOptionsContext(cmd.Context(), opts)
 }
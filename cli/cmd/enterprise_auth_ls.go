@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitEnterpriseAuthLS(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:          "ls",
+		Short:        "list auth keys",
+		Long:         `list the keys that can authenticate as your organization, including pending requests`,
+		RunE:         r.enterpriseAuthList,
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+}
+
+func (r *runners) enterpriseAuthList(cmd *cobra.Command, args []string) error {
+	authKeys, err := r.enterpriseClient.ListAuthKeysContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	return print.EnterpriseAuthKeys(outputFormat, r.w, authKeys)
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitEnterpriseAuthRevoke(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:          "revoke FINGERPRINT",
+		SilenceUsage: true,
+		Short:        "revoke an auth key",
+		Long: `revoke an auth key or pending auth request, so it can no longer sign requests
+
+  Example:
+  replicated enterprise auth revoke SHA256:...`,
+	}
+	parent.AddCommand(cmd)
+
+	cmd.RunE = r.enterpriseAuthRevoke
+}
+
+func (r *runners) enterpriseAuthRevoke(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("fingerprint is required")
+	}
+
+	if err := r.enterpriseClient.RevokeAuthKeyContext(cmd.Context(), args[0]); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(r.w, "Auth key %s successfully revoked\n", args[0])
+	return r.w.Flush()
+}
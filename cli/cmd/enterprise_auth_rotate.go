@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/enterpriseclient"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitEnterpriseAuthRotate(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:          "rotate",
+		SilenceUsage: true,
+		Short:        "rotate the auth key",
+		Long: `Replace the private key with a new one.
+
+The first run generates a new key next to the current one and submits it for
+approval, which is done the same way as for auth init. The current key keeps
+working in the meantime. Run rotate again once the new key is approved to
+switch over to it. The replaced key is kept with a .old suffix, and is revoked
+when --revoke-old is set.
+
+Keys held in ssh-agent or by a signer command are rotated with their own
+tooling, then registered with auth init.`,
+	}
+	parent.AddCommand(cmd)
+
+	cmd.Flags().BoolVar(&r.args.enterpriseAuthRotateEncrypt, "encrypt", false, "Encrypt the new private key with a passphrase, read from REPLICATED_PRIVATEKEY_PASSPHRASE or prompted for")
+	cmd.Flags().BoolVar(&r.args.enterpriseAuthRotateRevokeOld, "revoke-old", false, "Revoke the replaced key once the new key is in place")
+
+	cmd.RunE = r.enterpriseAuthRotate
+}
+
+func (r *runners) enterpriseAuthRotate(cmd *cobra.Command, args []string) error {
+	keyPath, ok := enterpriseclient.KeyFilePath(enterprisePrivateKeyPath)
+	if !ok {
+		return errors.Errorf("only key files can be rotated, not %s", enterprisePrivateKeyPath)
+	}
+
+	var passphrase []byte
+	if r.args.enterpriseAuthRotateEncrypt {
+		var err error
+		passphrase, err = enterprisePassphrase()
+		if err != nil {
+			return errors.Wrap(err, "read passphrase")
+		}
+	}
+
+	rotation, err := r.enterpriseClient.AuthRotateContext(cmd.Context(), keyPath, passphrase)
+	if err != nil {
+		return err
+	}
+
+	if rotation.Status == enterpriseclient.AuthRotationPending {
+		if rotation.Code != "" {
+			fmt.Fprintf(r.w, "\nA new key %s has been submitted. Please contact your organization or Replicated at support@replicated.com to approve it with the following code: %s\n", rotation.NewFingerprint, rotation.Code)
+		} else {
+			fmt.Fprintf(r.w, "\nThe new key %s is waiting for approval.\n", rotation.NewFingerprint)
+		}
+		fmt.Fprintf(r.w, "Key %s is still in use until then, run rotate again once the new key is approved.\n\n", rotation.OldFingerprint)
+		return r.w.Flush()
+	}
+
+	fmt.Fprintf(r.w, "\nSwitched to key %s. The replaced key was moved to %s.\n", rotation.NewFingerprint, rotation.OldKeyPath)
+	if !r.args.enterpriseAuthRotateRevokeOld {
+		fmt.Fprintf(r.w, "Revoke it with: replicated enterprise auth revoke %s\n\n", rotation.OldFingerprint)
+		return r.w.Flush()
+	}
+
+	// the client still signs with the replaced key, which is valid until now
+	if err := r.enterpriseClient.RevokeAuthKeyContext(cmd.Context(), rotation.OldFingerprint); err != nil {
+		r.w.Flush()
+		return errors.Wrapf(err, "revoke replaced key %s", rotation.OldFingerprint)
+	}
+	fmt.Fprintf(r.w, "Key %s has been revoked.\n\n", rotation.OldFingerprint)
+	return r.w.Flush()
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitEnterpriseAuthWhoami(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:          "whoami",
+		Short:        "show the local auth key",
+		Long:         `show the fingerprint of the key requests are signed with, and its status`,
+		RunE:         r.enterpriseAuthWhoami,
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+}
+
+func (r *runners) enterpriseAuthWhoami(cmd *cobra.Command, args []string) error {
+	identity, err := r.enterpriseClient.AuthWhoamiContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	return print.EnterpriseAuthIdentity(outputFormat, r.w, identity)
+}
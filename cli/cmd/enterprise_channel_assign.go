@@ -25,7 +25,7 @@ func (r *runners) InitEnterpriseChannelAssign(parent *cobra.Command) {
 }
 
 func (r *runners) enterpriseChannelAssign(cmd *cobra.Command, args []string) error {
-	err := r.enterpriseClient.AssignChannel(r.args.enterpriseChannelAssignChannelID, r.args.enterpriseChannelAssignTeamID)
+	err := r.enterpriseClient.AssignChannelContext(cmd.Context(), r.args.enterpriseChannelAssignChannelID, r.args.enterpriseChannelAssignTeamID)
 	if err != nil {
 		return err
 	}
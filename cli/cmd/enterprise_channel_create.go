@@ -24,11 +24,10 @@ func (r *runners) InitEnterpriseChannelCreate(parent *cobra.Command) {
 }
 
 func (r *runners) enterpriseChannelCreate(cmd *cobra.Command, args []string) error {
-	channel, err := r.enterpriseClient.CreateChannel(r.args.enterpriseChannelCreateName, r.args.enterpriseChannelCreateDescription)
+	channel, err := r.enterpriseClient.CreateChannelContext(cmd.Context(), r.args.enterpriseChannelCreateName, r.args.enterpriseChannelCreateDescription)
 	if err != nil {
 		return err
 	}
 
-	print.EnterpriseChannel(r.w, channel)
-	return nil
+	return print.EnterpriseChannel(outputFormat, r.w, channel)
 }
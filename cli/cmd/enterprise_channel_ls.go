@@ -19,11 +19,10 @@ func (r *runners) InitEnterpriseChannelLS(parent *cobra.Command) *cobra.Command
 }
 
 func (r *runners) enterpriseChannelList(cmd *cobra.Command, args []string) error {
-	channels, err := r.enterpriseClient.ListChannels()
+	channels, err := r.enterpriseClient.ListChannelsContext(cmd.Context())
 	if err != nil {
 		return err
 	}
 
-	print.EnterpriseChannels(r.w, channels)
-	return nil
+	return print.EnterpriseChannels(outputFormat, r.w, channels)
 }
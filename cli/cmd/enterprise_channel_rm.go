@@ -24,7 +24,7 @@ func (r *runners) InitEnterpriseChannelRM(parent *cobra.Command) {
 }
 
 func (r *runners) enterpriseChannelRemove(cmd *cobra.Command, args []string) error {
-	err := r.enterpriseClient.RemoveChannel(r.args.enterpriseChannelRmId)
+	err := r.enterpriseClient.RemoveChannelContext(cmd.Context(), r.args.enterpriseChannelRmId)
 	if err != nil {
 		return err
 	}
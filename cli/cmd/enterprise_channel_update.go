@@ -25,11 +25,10 @@ func (r *runners) InitEnterpriseChannelUpdate(parent *cobra.Command) {
 }
 
 func (r *runners) enterpriseChannelUpdate(cmd *cobra.Command, args []string) error {
-	channel, err := r.enterpriseClient.UpdateChannel(r.args.enterpriseChannelUpdateID, r.args.enterpriseChannelUpdateName, r.args.enterpriseChannelUpdateDescription)
+	channel, err := r.enterpriseClient.UpdateChannelContext(cmd.Context(), r.args.enterpriseChannelUpdateID, r.args.enterpriseChannelUpdateName, r.args.enterpriseChannelUpdateDescription)
 	if err != nil {
 		return err
 	}
 
-	print.EnterpriseChannel(r.w, channel)
-	return nil
+	return print.EnterpriseChannel(outputFormat, r.w, channel)
 }
@@ -25,7 +25,7 @@ func (r *runners) InitEnterpriseInstallerAssign(parent *cobra.Command) {
 }
 
 func (r *runners) enterpriseInstallerAssign(cmd *cobra.Command, args []string) error {
-	err := r.enterpriseClient.AssignInstaller(r.args.enterpriseInstallerAssignInstallerID, r.args.enterpriseInstallerAssignChannelID)
+	err := r.enterpriseClient.AssignInstallerContext(cmd.Context(), r.args.enterpriseInstallerAssignInstallerID, r.args.enterpriseInstallerAssignChannelID)
 	if err != nil {
 		return err
 	}
@@ -31,11 +31,10 @@ func (r *runners) enterpriseInstallerCreate(cmd *cobra.Command, args []string) e
 		return errors.Wrap(err, "failed to read file")
 	}
 
-	installer, err := r.enterpriseClient.CreateInstaller(string(b))
+	installer, err := r.enterpriseClient.CreateInstallerContext(cmd.Context(), string(b))
 	if err != nil {
 		return err
 	}
 
-	print.EnterpriseInstaller(r.w, installer)
-	return nil
+	return print.EnterpriseInstaller(outputFormat, r.w, installer)
 }
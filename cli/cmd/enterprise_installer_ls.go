@@ -19,11 +19,10 @@ func (r *runners) InitEnterpriseInstallerLS(parent *cobra.Command) *cobra.Comman
 }
 
 func (r *runners) enterpriseInstallerList(cmd *cobra.Command, args []string) error {
-	installers, err := r.enterpriseClient.ListInstallers()
+	installers, err := r.enterpriseClient.ListInstallersContext(cmd.Context())
 	if err != nil {
 		return err
 	}
 
-	print.EnterpriseInstallers(r.w, installers)
-	return nil
+	return print.EnterpriseInstallers(outputFormat, r.w, installers)
 }
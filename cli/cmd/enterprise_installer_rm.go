@@ -24,7 +24,7 @@ func (r *runners) InitEnterpriseInstallerRM(parent *cobra.Command) {
 }
 
 func (r *runners) enterpriseInstallerRemove(cmd *cobra.Command, args []string) error {
-	err := r.enterpriseClient.RemoveInstaller(r.args.enterpriseInstallerRmId)
+	err := r.enterpriseClient.RemoveInstallerContext(cmd.Context(), r.args.enterpriseInstallerRmId)
 	if err != nil {
 		return err
 	}
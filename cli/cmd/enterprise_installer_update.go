@@ -32,11 +32,10 @@ func (r *runners) enterpriseInstallerUpdate(cmd *cobra.Command, args []string) e
 		return errors.Wrap(err, "failed to read file")
 	}
 
-	installer, err := r.enterpriseClient.UpdateInstaller(r.args.enterpriseInstallerUpdateID, string(b))
+	installer, err := r.enterpriseClient.UpdateInstallerContext(cmd.Context(), r.args.enterpriseInstallerUpdateID, string(b))
 	if err != nil {
 		return err
 	}
 
-	print.EnterpriseInstaller(r.w, installer)
-	return nil
+	return print.EnterpriseInstaller(outputFormat, r.w, installer)
 }
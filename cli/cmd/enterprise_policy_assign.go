@@ -25,7 +25,7 @@ func (r *runners) InitEnterprisePolicyAssign(parent *cobra.Command) {
 }
 
 func (r *runners) enterprisePolicyAssign(cmd *cobra.Command, args []string) error {
-	err := r.enterpriseClient.AssignPolicy(r.args.enterprisePolicyAssignPolicyID, r.args.enterprisePolicyAssignChannelID)
+	err := r.enterpriseClient.AssignPolicyContext(cmd.Context(), r.args.enterprisePolicyAssignPolicyID, r.args.enterprisePolicyAssignChannelID)
 	if err != nil {
 		return err
 	}
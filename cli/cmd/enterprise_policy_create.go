@@ -33,11 +33,10 @@ func (r *runners) enterprisePolicyCreate(cmd *cobra.Command, args []string) erro
 		return errors.Wrap(err, "failed to read file")
 	}
 
-	policy, err := r.enterpriseClient.CreatePolicy(r.args.enterprisePolicyCreateName, r.args.enterprisePolicyCreateDescription, string(b))
+	policy, err := r.enterpriseClient.CreatePolicyContext(cmd.Context(), r.args.enterprisePolicyCreateName, r.args.enterprisePolicyCreateDescription, string(b))
 	if err != nil {
 		return err
 	}
 
-	print.EnterprisePolicy(r.w, policy)
-	return nil
+	return print.EnterprisePolicy(outputFormat, r.w, policy)
 }
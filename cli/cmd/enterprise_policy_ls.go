@@ -21,17 +21,16 @@ func (r *runners) InitEnterprisePolicyLS(parent *cobra.Command) *cobra.Command {
 }
 
 func (r *runners) enterprisePolicyList(cmd *cobra.Command, args []string) error {
-	policies, err := r.enterpriseClient.ListPolicies()
+	policies, err := r.enterpriseClient.ListPoliciesContext(cmd.Context())
 	if err != nil {
 		return err
 	}
 
-	if len(policies) == 0 {
+	if len(policies) == 0 && outputFormat == print.FormatTable {
 		fmt.Fprintf(r.w, "No policies found. Create one with \"replicated enterprise policy create\"\n")
 		r.w.Flush()
 		return nil
 	}
 
-	print.EnterprisePolicies(r.w, policies)
-	return nil
+	return print.EnterprisePolicies(outputFormat, r.w, policies)
 }
@@ -24,7 +24,7 @@ func (r *runners) InitEnterprisePolicyRM(parent *cobra.Command) {
 }
 
 func (r *runners) enterprisePolicyRemove(cmd *cobra.Command, args []string) error {
-	err := r.enterpriseClient.RemovePolicy(r.args.enterprisePolicyRmId)
+	err := r.enterpriseClient.RemovePolicyContext(cmd.Context(), r.args.enterprisePolicyRmId)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/replicatedhq/replicated/pkg/lint"
+	"github.com/replicatedhq/replicated/pkg/policy"
+	"github.com/replicatedhq/replicated/pkg/releasefiles"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitEnterprisePolicyTest(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "test",
+		SilenceUsage: true,
+		Short:        "Evaluate a policy locally",
+		Long: `Evaluate an OPA policy against a directory of manifests, or against a directory
+of fixtures, without uploading anything. No private key is needed.
+
+With --yaml-dir, the lint[output] results of the policy are printed in the same
+form as "release lint".
+
+With --fixtures, every subdirectory of the fixtures directory is a test case,
+with the manifests in a manifests directory and the messages the policy should
+report in expected.yaml:
+
+  - type: error
+    path: deployment.yaml
+    message: Cannot accept hard coded replica count when it's set to 1
+    line: 6
+
+  Example:
+  replicated enterprise policy test --policy-file replicas.rego --yaml-dir ./manifests
+  replicated enterprise policy test --policy-file replicas.rego --fixtures ./fixtures`,
+	}
+	parent.AddCommand(cmd)
+
+	cmd.Flags().StringVar(&r.args.enterprisePolicyTestFile, "policy-file", "", "The filename containing an OPA policy")
+	cmd.Flags().StringVar(&r.args.enterprisePolicyTestYamlDir, "yaml-dir", "", "The directory containing the manifests to evaluate the policy against")
+	cmd.Flags().StringVar(&r.args.enterprisePolicyTestFixtures, "fixtures", "", "The directory containing policy test fixtures")
+	cmd.Flags().StringVar(&r.args.enterprisePolicyTestFailOn, "fail-on", "error", "With --yaml-dir, the minimum severity to cause the command to exit with a non-zero exit code. Supported values are [info, warn, error, none].")
+	cmd.Flags().StringVar(&r.args.enterprisePolicyTestFormat, "format", "", "With --yaml-dir, the format of the lint report. Defaults to the --output format. Supported values are [table, json, yaml, sarif, junit, github].")
+
+	cmd.RunE = r.enterprisePolicyTest
+	return cmd
+}
+
+func (r *runners) enterprisePolicyTest(cmd *cobra.Command, args []string) error {
+	if r.args.enterprisePolicyTestFile == "" {
+		return errors.New("policy-file is required")
+	}
+	if (r.args.enterprisePolicyTestYamlDir == "") == (r.args.enterprisePolicyTestFixtures == "") {
+		return errors.New("exactly one of yaml-dir or fixtures is required")
+	}
+
+	b, err := ioutil.ReadFile(r.args.enterprisePolicyTestFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read file")
+	}
+
+	p, err := policy.Load(cmd.Context(), r.args.enterprisePolicyTestFile, b)
+	if err != nil {
+		return errors.Wrap(err, "failed to load policy")
+	}
+
+	if r.args.enterprisePolicyTestFixtures != "" {
+		return r.enterprisePolicyTestFixtures(cmd, p)
+	}
+
+	if _, ok := validFailOnValues[r.args.enterprisePolicyTestFailOn]; !ok {
+		return errors.Errorf("fail-on value %q not supported, supported values are [info, warn, error, none]", r.args.enterprisePolicyTestFailOn)
+	}
+
+	format := r.args.enterprisePolicyTestFormat
+	if format == "" {
+		format = outputFormat
+	}
+	if _, ok := validLintFormatValues[format]; !ok {
+		return errors.Errorf("format value %q not supported, supported values are [table, json, yaml, sarif, junit, github]", format)
+	}
+
+	files, err := lint.ReadDir(r.args.enterprisePolicyTestYamlDir, releasefiles.Options{})
+	if err != nil {
+		return errors.Wrap(err, "failed to read yaml dir")
+	}
+
+	lintResult, err := p.Eval(cmd.Context(), files)
+	if err != nil {
+		return err
+	}
+
+	if err := r.printLintResult(cmd.OutOrStdout(), format, r.args.enterprisePolicyTestYamlDir, lintResult); err != nil {
+		return err
+	}
+
+	if hasError := shouldFail(lintResult, r.args.enterprisePolicyTestFailOn); hasError {
+		return errors.Errorf("One or more errors of severity %q or higher were found", r.args.enterprisePolicyTestFailOn)
+	}
+
+	return nil
+}
+
+func (r *runners) enterprisePolicyTestFixtures(cmd *cobra.Command, p *policy.Policy) error {
+	results, err := p.RunFixtures(cmd.Context(), r.args.enterprisePolicyTestFixtures)
+	if err != nil {
+		return err
+	}
+
+	if err := print.PolicyFixtureResults(outputFormat, r.w, results); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if !result.Passed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("%d of %d fixtures failed", failed, len(results))
+	}
+	return nil
+}
@@ -25,7 +25,7 @@ func (r *runners) InitEnterprisePolicyUnassign(parent *cobra.Command) {
 }
 
 func (r *runners) enterprisePolicyUnassign(cmd *cobra.Command, args []string) error {
-	err := r.enterpriseClient.UnassignPolicy(r.args.enterprisePolicyUnassignPolicyID, r.args.enterprisePolicyUnassignChannelID)
+	err := r.enterpriseClient.UnassignPolicyContext(cmd.Context(), r.args.enterprisePolicyUnassignPolicyID, r.args.enterprisePolicyUnassignChannelID)
 	if err != nil {
 		return err
 	}
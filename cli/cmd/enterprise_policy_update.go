@@ -34,11 +34,10 @@ func (r *runners) enterprisePolicyUpdate(cmd *cobra.Command, args []string) erro
 		return errors.Wrap(err, "failed to read file")
 	}
 
-	policy, err := r.enterpriseClient.UpdatePolicy(r.args.enterprisePolicyUpdateID, r.args.enterprisePolicyUpdateName, r.args.enterprisePolicyUpdateDescription, string(b))
+	policy, err := r.enterpriseClient.UpdatePolicyContext(cmd.Context(), r.args.enterprisePolicyUpdateID, r.args.enterprisePolicyUpdateName, r.args.enterprisePolicyUpdateDescription, string(b))
 	if err != nil {
 		return err
 	}
 
-	print.EnterprisePolicy(r.w, policy)
-	return nil
+	return print.EnterprisePolicy(outputFormat, r.w, policy)
 }
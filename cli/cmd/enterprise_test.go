@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mattn/go-isatty"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnterprisePassphrase(t *testing.T) {
+	req := require.New(t)
+
+	t.Setenv("REPLICATED_PRIVATEKEY_PASSPHRASE", "secret")
+	passphrase, err := enterprisePassphrase()
+	req.NoError(err)
+	req.Equal("secret", string(passphrase))
+
+	if isatty.IsTerminal(os.Stdin.Fd()) {
+		t.Skip("stdin is a terminal")
+	}
+	t.Setenv("REPLICATED_PRIVATEKEY_PASSPHRASE", "")
+	_, err = enterprisePassphrase()
+	req.EqualError(err, "stdin is not a terminal to prompt for the passphrase, set REPLICATED_PRIVATEKEY_PASSPHRASE")
+}
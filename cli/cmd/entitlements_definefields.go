@@ -31,12 +31,12 @@ func (r *runners) entitlementsDefineFields(cmd *cobra.Command, args []string) er
 		return err
 	}
 
-	definitions, err := r.api.CreateEntitlementSpec(r.appID, r.appType, r.args.entitlementsDefineFieldsName, string(spec))
+	definitions, err := r.api.CreateEntitlementSpecContext(cmd.Context(), r.appID, r.appType, r.args.entitlementsDefineFieldsName, string(spec))
 	if err != nil {
 		return errors.Wrap(err, "create definitions")
 	}
 
-	err = r.api.SetDefaultEntitlementSpec(definitions.ID)
+	err = r.api.SetDefaultEntitlementSpecContext(cmd.Context(), definitions.ID)
 	if err != nil {
 		return errors.Wrap(err, "set as default definitions")
 	}
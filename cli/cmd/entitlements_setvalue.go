@@ -30,7 +30,7 @@ func (r *runners) InitEntitlementsSetValueCommand(parent *cobra.Command) {
 }
 
 func (r *runners) entitlementsSetValue(cmd *cobra.Command, args []string) error {
-	created, err := r.api.SetEntitlementValue(
+	created, err := r.api.SetEntitlementValueContext(cmd.Context(),
 		r.args.entitlementsSetValueCustomerID,
 		r.args.entitlementsSetValueDefinitionsID,
 		r.args.entitlementsSetValueKey,
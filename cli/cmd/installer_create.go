@@ -53,7 +53,7 @@ func (r *runners) setKOTSDefaultInstallerParams() error {
 	return nil
 }
 
-func (r *runners) installerCreate(_ *cobra.Command, _ []string) error {
+func (r *runners) installerCreate(cmd *cobra.Command, _ []string) error {
 	if r.appType != "kots" {
 		return errors.Errorf("Installer specs are only supported for KOTS applications, app %q has type %q", r.appID, r.appType)
 	}
@@ -130,6 +130,7 @@ Prepared to create release with defaults:
 	if r.args.createInstallerPromote != "" {
 		var err error
 		promoteChanID, err = r.getOrCreateChannelForPromotion(
+			cmd.Context(),
 			r.args.createInstallerPromote,
 			r.args.createInstallerPromoteEnsureChannel,
 		)
@@ -138,7 +139,7 @@ Prepared to create release with defaults:
 		}
 	}
 	log.ActionWithSpinner("Creating Installer")
-	installerSpec, err := r.api.CreateInstaller(r.appID, r.appType, r.args.createInstallerYaml)
+	installerSpec, err := r.api.CreateInstallerContext(cmd.Context(), r.appID, r.appType, r.args.createInstallerYaml)
 	if err != nil {
 		log.FinishSpinnerWithError()
 		return err
@@ -152,7 +153,7 @@ Prepared to create release with defaults:
 
 	if promoteChanID != "" {
 		log.ActionWithSpinner("Promoting")
-		if err := r.api.PromoteInstaller(
+		if err := r.api.PromoteInstallerContext(cmd.Context(),
 			r.appID,
 			r.appType,
 			installerSpec.Sequence,
@@ -16,11 +16,11 @@ func (r *runners) InitInstallerList(parent *cobra.Command) {
 	cmd.RunE = r.installerList
 }
 
-func (r *runners) installerList(_ *cobra.Command, _ []string) error {
-	installers, err := r.api.ListInstallers(r.appID, r.appType)
+func (r *runners) installerList(cmd *cobra.Command, _ []string) error {
+	installers, err := r.api.ListInstallersContext(cmd.Context(), r.appID, r.appType)
 	if err != nil {
 		return err
 	}
 
-	return print.Installers(r.w, installers)
+	return print.Installers(outputFormat, r.w, installers)
 }
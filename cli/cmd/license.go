@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitLicenseCommand(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "license",
+		Short: "Work with license files",
+		Long:  `The license command inspects KOTS license files locally, without an API token.`,
+	}
+	parent.AddCommand(cmd)
+
+	return cmd
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/replicatedhq/replicated/pkg/license"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitLicenseInspect(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect LICENSE_FILE",
+		Short: "Decode a license and verify its signature",
+		Long: `Decode a KOTS license file and print its customer, channel, expiry, features and
+entitlements.
+
+Pass your app's public key with --public-key to verify the license signature.
+Without it the license data is only checked against the key embedded in the
+signature, which anyone can replace, so the signature is not reported as valid
+and --strict fails.`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         r.inspectLicense,
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+	cmd.Flags().StringVar(&r.args.licenseInspectPublicKey, "public-key", "", "Path to the PEM encoded public key the license must be signed with")
+	cmd.Flags().DurationVar(&r.args.licenseInspectExpiryWarning, "expiry-warning", 30*24*time.Hour, "Report the license as expiring when it expires within this long")
+	cmd.Flags().BoolVar(&r.args.licenseInspectStrict, "strict", false, "Exit with an error when the signature is not verified with --public-key or the license has expired")
+
+	return cmd
+}
+
+func (r *runners) inspectLicense(cmd *cobra.Command, args []string) error {
+	l, err := license.Load(args[0])
+	if err != nil {
+		return errors.Wrapf(err, "load license %s", args[0])
+	}
+
+	var publicKey []byte
+	if r.args.licenseInspectPublicKey != "" {
+		publicKey, err = ioutil.ReadFile(r.args.licenseInspectPublicKey)
+		if err != nil {
+			return errors.Wrap(err, "read public key")
+		}
+	}
+
+	inspection, err := license.Inspect(l, publicKey, time.Now(), r.args.licenseInspectExpiryWarning)
+	if err != nil {
+		return errors.Wrap(err, "inspect license")
+	}
+
+	if err := print.License(outputFormat, r.w, inspection); err != nil {
+		return err
+	}
+
+	if r.args.licenseInspectStrict {
+		return inspection.Check()
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/replicatedhq/replicated/pkg/profiles"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitProfileCommand(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage named profiles",
+		Long: `Manage the named profiles in ~/.replicated/config.yaml.
+
+A profile holds an API token, app and API origins. They are used when the
+matching flag or environment variable is not set, so --token and --app take
+precedence over REPLICATED_API_TOKEN and REPLICATED_APP, which take precedence
+over the profile. Select a profile with --profile, REPLICATED_PROFILE or
+"replicated profile use".`,
+	}
+	parent.AddCommand(cmd)
+
+	return cmd
+}
+
+func profilesPath() string {
+	return profiles.DefaultPath(homeDir())
+}
+
+// applyProfile fills in the token, app and origins from the selected profile
+// for any of them not already set with a flag or environment variable
+func applyProfile() error {
+	name := profileName
+	if name == "" {
+		name = os.Getenv("REPLICATED_PROFILE")
+	}
+
+	config, err := profiles.Load(profilesPath())
+	if err != nil {
+		return err
+	}
+	profile, err := config.Get(name)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		return nil
+	}
+
+	if apiToken == "" && os.Getenv("REPLICATED_API_TOKEN") == "" {
+		apiToken = profile.APIToken
+	}
+	if appSlugOrID == "" && os.Getenv("REPLICATED_APP") == "" {
+		appSlugOrID = profile.App
+	}
+	if profile.APIOrigin != "" && os.Getenv("REPLICATED_API_ORIGIN") == "" {
+		platformOrigin = profile.APIOrigin
+	}
+	if profile.GraphQLOrigin != "" && os.Getenv("REPLICATED_SHIP_ORIGIN") == "" && os.Getenv("REPLICATED_GRAPHQL_ORIGIN") == "" {
+		graphqlOrigin = profile.GraphQLOrigin
+	}
+	if profile.EnterpriseOrigin != "" && os.Getenv("REPLICATED_ENTERPRISE_ORIGIN") == "" {
+		enterpriseOrigin = profile.EnterpriseOrigin
+	}
+	if profile.KurlOrigin != "" && os.Getenv("KURL_SH_ORIGIN") == "" {
+		kurlDotSHOrigin = profile.KurlOrigin
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/profiles"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitProfileAdd(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add NAME",
+		Short: "Add or replace a named profile",
+		Long: `Add or replace a named profile.
+
+The app is set with --app. The API token is never passed as a flag. It is read from stdin when stdin is
+not a terminal, and prompted for otherwise. The first profile added becomes
+the current profile.`,
+		Example: `  # prompt for the token
+  replicated profile add staging --app my-app --api-origin https://api.staging.replicated.com/vendor
+
+  # read the token from a secret store
+  vault kv get -field=token secret/replicated | replicated profile add ci --app my-app`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+
+	cmd.Flags().StringVar(&r.args.profileAddAPIOrigin, "api-origin", "", "The Vendor API origin to use with this profile")
+	cmd.Flags().StringVar(&r.args.profileAddGraphQLOrigin, "graphql-origin", "", "The GraphQL API origin to use with this profile")
+	cmd.Flags().StringVar(&r.args.profileAddEnterpriseOrigin, "enterprise-origin", "", "The Enterprise API origin to use with this profile")
+	cmd.Flags().StringVar(&r.args.profileAddKurlOrigin, "kurl-origin", "", "The kurl.sh origin to use with this profile")
+
+	cmd.RunE = r.profileAdd
+	return cmd
+}
+
+func (r *runners) profileAdd(_ *cobra.Command, args []string) error {
+	if apiToken != "" {
+		return errors.New("the token can't be passed with --token, it is read from stdin or prompted for")
+	}
+
+	token, err := r.readProfileToken()
+	if err != nil {
+		return err
+	}
+
+	path := profilesPath()
+	config, err := profiles.Load(path)
+	if err != nil {
+		return err
+	}
+
+	err = config.Set(args[0], profiles.Profile{
+		APIToken:         token,
+		App:              appSlugOrID,
+		APIOrigin:        r.args.profileAddAPIOrigin,
+		GraphQLOrigin:    r.args.profileAddGraphQLOrigin,
+		EnterpriseOrigin: r.args.profileAddEnterpriseOrigin,
+		KurlOrigin:       r.args.profileAddKurlOrigin,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := config.Save(path); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(r.w, "Profile %s saved to %s\n", args[0], path)
+	return r.w.Flush()
+}
+
+func (r *runners) readProfileToken() (string, error) {
+	if f, ok := r.stdin.(*os.File); ok {
+		if info, err := f.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			return promptForProfileToken()
+		}
+	}
+
+	token, err := bufio.NewReader(r.stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", errors.Wrap(err, "read token from stdin")
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", errors.New("no token was provided on stdin")
+	}
+	return token, nil
+}
+
+func promptForProfileToken() (string, error) {
+	prompt := promptui.Prompt{
+		Label:     "API Token:",
+		Templates: templates,
+		Mask:      '*',
+		Validate: func(input string) error {
+			if strings.TrimSpace(input) == "" {
+				return errors.New("a token is required")
+			}
+			return nil
+		},
+	}
+
+	for {
+		result, err := prompt.Run()
+		if err != nil {
+			if err == promptui.ErrInterrupt {
+				return "", errors.New("interrupted")
+			}
+			continue
+		}
+
+		return strings.TrimSpace(result), nil
+	}
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/replicatedhq/replicated/pkg/profiles"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitProfileList(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "ls",
+		Short:        "List named profiles",
+		Long:         "List named profiles. API tokens are not printed.",
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+
+	cmd.RunE = r.profileList
+	return cmd
+}
+
+func (r *runners) profileList(_ *cobra.Command, _ []string) error {
+	config, err := profiles.Load(profilesPath())
+	if err != nil {
+		return err
+	}
+
+	return print.Profiles(outputFormat, r.w, config)
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/replicatedhq/replicated/pkg/profiles"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitProfileRM(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "rm NAME",
+		Short:        "Remove a named profile",
+		Long:         "Remove a named profile and its stored API token",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+
+	cmd.RunE = r.profileRemove
+	return cmd
+}
+
+func (r *runners) profileRemove(_ *cobra.Command, args []string) error {
+	path := profilesPath()
+	config, err := profiles.Load(path)
+	if err != nil {
+		return err
+	}
+
+	if err := config.Remove(args[0]); err != nil {
+		return err
+	}
+	if err := config.Save(path); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(r.w, "Profile %s removed\n", args[0])
+	return r.w.Flush()
+}
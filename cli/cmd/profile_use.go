@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/replicatedhq/replicated/pkg/profiles"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitProfileUse(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "use NAME",
+		Short:        "Set the current profile",
+		Long:         "Set the profile used when --profile and REPLICATED_PROFILE are not set",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+
+	cmd.RunE = r.profileUse
+	return cmd
+}
+
+func (r *runners) profileUse(_ *cobra.Command, args []string) error {
+	path := profilesPath()
+	config, err := profiles.Load(path)
+	if err != nil {
+		return err
+	}
+
+	if err := config.Use(args[0]); err != nil {
+		return err
+	}
+	if err := config.Save(path); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(r.w, "Now using profile %s\n", args[0])
+	return r.w.Flush()
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -12,6 +13,8 @@ import (
 
 	"github.com/manifoldco/promptui"
 	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/replicatedhq/replicated/pkg/releasefiles"
+	"github.com/replicatedhq/replicated/pkg/types"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -44,17 +47,24 @@ func (r *runners) InitReleaseCreate(parent *cobra.Command) error {
 	cmd.Flags().StringVar(&r.args.createReleaseYaml, "yaml", "", "The YAML config for this release. Use '-' to read from stdin. Cannot be used with the --yaml-file flag.")
 	cmd.Flags().StringVar(&r.args.createReleaseYamlFile, "yaml-file", "", "The file name with YAML config for this release. Cannot be used with the --yaml flag.")
 	cmd.Flags().StringVar(&r.args.createReleaseYamlDir, "yaml-dir", "", "The directory containing multiple yamls for a Kots release. Cannot be used with the --yaml flag.")
-	cmd.Flags().StringVar(&r.args.createReleasePromote, "promote", "", "Channel name or id to promote this release to")
+	cmd.Flags().StringArrayVar(&r.args.createReleaseHelmCharts, "helm-chart", nil, "A Helm chart source directory to package into the release. A HelmChart manifest is generated unless the release already has one for the chart. Can be repeated.")
+	cmd.Flags().StringArrayVar(&r.args.createReleaseKustomize, "kustomize", nil, "A Kustomize overlay directory to render into the release with kustomize or kubectl. Can be repeated.")
+	cmd.Flags().StringArrayVar(&r.args.createReleasePromote, "promote", nil, "Channel name or id to promote this release to. Can be repeated to promote to several channels at once.")
 	cmd.Flags().StringVar(&r.args.createReleasePromoteNotes, "release-notes", "", "When used with --promote <channel>, sets the **markdown** release notes")
 	cmd.Flags().StringVar(&r.args.createReleasePromoteVersion, "version", "", "When used with --promote <channel>, sets the version label for the release in this channel")
 	// Fail-on linting flag (from release_lint.go)
 	cmd.Flags().StringVar(&r.args.lintReleaseFailOn, "fail-on", "error", "The minimum severity to cause the command to exit with a non-zero exit code. Supported values are [info, warn, error, none].")
 	// Replicated release create lint flag
 	cmd.Flags().BoolVar(&r.args.createReleaseLint, "lint", false, "Lint a manifests directory prior to creation of the KOTS Release.")
+	cmd.Flags().StringVar(&r.args.lintReleaseEngine, "lint-engine", "remote", "When used with --lint, the lint engine to use, like --engine on release lint. \"remote\" uses the hosted lint service, \"local\" runs the built-in linter without any network access. Supported values are [local, remote].")
 	cmd.Flags().BoolVar(&r.args.createReleasePromoteRequired, "required", false, "When used with --promote <channel>, marks this release as required during upgrades.")
 	cmd.Flags().BoolVar(&r.args.createReleasePromoteEnsureChannel, "ensure-channel", false, "When used with --promote <channel>, will create the channel if it doesn't exist")
 	cmd.Flags().BoolVar(&r.args.createReleaseAutoDefaults, "auto", false, "generate default values for use in CI")
 	cmd.Flags().BoolVarP(&r.args.createReleaseAutoDefaultsAccept, "confirm-auto", "y", false, "auto-accept the configuration generated by the --auto flag")
+	cmd.Flags().BoolVar(&r.args.createReleaseWatch, "watch", false, "Watch --yaml-dir and create and promote a new release whenever it changes. Releases are promoted to the --promote channels, or to dev-$USER by default, and are only created when the local linter finds nothing at or above the --fail-on severity.")
+	cmd.Flags().DurationVar(&r.args.createReleaseWatchDebounce, "debounce", time.Second, "When used with --watch, how long the yaml dir must be unchanged before a release is created")
+	r.addReleaseFileFlags(cmd)
+	r.addReleaseSizeFlags(cmd)
 
 	// not supported for KOTS
 	cmd.Flags().MarkHidden("required")
@@ -102,7 +112,7 @@ func (r *runners) gitSHABranch() (sha string, branch string, dirty bool, err err
 }
 
 func (r *runners) setKOTSDefaultReleaseParams() error {
-	if r.args.createReleaseYamlDir == "" {
+	if r.args.createReleaseYamlDir == "" && !r.hasReleaseSources() {
 		r.args.createReleaseYamlDir = "./manifests"
 	}
 
@@ -138,15 +148,15 @@ func (r *runners) setKOTSDefaultReleaseParams() error {
 		}
 	}
 
-	if r.args.createReleasePromote == "" {
-		r.args.createReleasePromote = branch
+	if len(r.args.createReleasePromote) == 0 {
+		r.args.createReleasePromote = []string{branch}
 		if branch == "master" || branch == "main" {
-			r.args.createReleasePromote = "Unstable"
+			r.args.createReleasePromote = []string{"Unstable"}
 		}
 	}
 
 	if r.args.createReleasePromoteVersion == "" {
-		r.args.createReleasePromoteVersion = fmt.Sprintf("%s-%s%s", r.args.createReleasePromote, rev, dirtyStatus)
+		r.args.createReleasePromoteVersion = fmt.Sprintf("%s-%s%s", r.args.createReleasePromote[0], rev, dirtyStatus)
 	}
 
 	r.args.createReleasePromoteEnsureChannel = true
@@ -178,7 +188,7 @@ Prepared to create release with defaults:
     ensure-channel  %t
     lint-release    %t
 
-`, r.args.createReleaseYamlDir, r.args.createReleasePromote, r.args.createReleasePromoteVersion, r.args.createReleasePromoteNotes, r.args.createReleasePromoteEnsureChannel, r.args.createReleaseLint)
+`, r.args.createReleaseYamlDir, strings.Join(r.args.createReleasePromote, ", "), r.args.createReleasePromoteVersion, r.args.createReleasePromoteNotes, r.args.createReleasePromoteEnsureChannel, r.args.createReleaseLint)
 		if !r.args.createReleaseAutoDefaultsAccept {
 			confirmed, err := promptForConfirm()
 			if err != nil {
@@ -196,8 +206,20 @@ Prepared to create release with defaults:
 		return errors.Wrap(err, "validate params")
 	}
 
+	if r.args.releaseFilesList {
+		specs, err := r.releaseSpecs(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "read release sources")
+		}
+		return r.printReleaseFiles(specs)
+	}
+
+	if r.args.createReleaseWatch {
+		return r.releaseCreateWatch(cmd)
+	}
+
 	// Check if --lint argument has been passed in by the enduser
-	if r.args.createReleaseLint {
+	if r.args.createReleaseLint && r.args.createReleaseYamlDir != "" {
 		// Request lint release yaml directory to check
 		r.args.lintReleaseYamlDir = r.args.createReleaseYamlDir
 		// Call release_lint.go releaseLint function
@@ -223,34 +245,43 @@ Prepared to create release with defaults:
 		r.args.createReleaseYaml = string(bytes)
 	}
 
-	if r.args.createReleaseYamlDir != "" {
+	if r.args.createReleaseYamlDir != "" || r.hasReleaseSources() {
 		fmt.Fprintln(r.w)
-		log.ActionWithSpinner("Reading manifests from %s", r.args.createReleaseYamlDir)
+		log.ActionWithSpinner("Reading manifests from %s", strings.Join(r.releaseSourceNames(), ", "))
 		var err error
-		r.args.createReleaseYaml, err = readYAMLDir(r.args.createReleaseYamlDir)
+		r.args.createReleaseYaml, err = r.readReleaseSources(cmd.Context())
 		if err != nil {
 			log.FinishSpinnerWithError()
-			return errors.Wrap(err, "read yaml dir")
+			return errors.Wrap(err, "read release sources")
 		}
 		log.FinishSpinner()
 	}
 
-	// if the --promote param was used make sure it identifies exactly one
-	// channel before proceeding
-	var promoteChanID string
-	if r.args.createReleasePromote != "" {
-		var err error
-		promoteChanID, err = r.getOrCreateChannelForPromotion(
+	if err := r.checkReleaseSize(cmd, r.args.createReleaseYaml); err != nil {
+		return err
+	}
+
+	// if the --promote param was used make sure every channel resolves
+	// before proceeding
+	var promotions []types.ChannelPromotion
+	if len(r.args.createReleasePromote) > 0 {
+		promotions, err = r.resolvePromotionChannels(
+			cmd.Context(),
 			r.args.createReleasePromote,
 			r.args.createReleasePromoteEnsureChannel,
 		)
 		if err != nil {
-			return errors.Wrapf(err, "get or create channel %q for promotion", promoteChanID)
+			for _, promotion := range promotions {
+				if promotion.Error != "" {
+					log.ChildActionWithoutSpinner("Channel %s: %s", promotion.Channel, promotion.Error)
+				}
+			}
+			return errors.Wrap(err, "get or create channels for promotion")
 		}
 	}
 
 	log.ActionWithSpinner("Creating Release")
-	release, err := r.api.CreateRelease(r.appID, r.appType, r.args.createReleaseYaml)
+	release, err := r.api.CreateReleaseContext(cmd.Context(), r.appID, r.appType, r.args.createReleaseYaml)
 	if err != nil {
 		log.FinishSpinnerWithError()
 		return err
@@ -259,24 +290,23 @@ Prepared to create release with defaults:
 
 	log.ChildActionWithoutSpinner("SEQUENCE: %d", release.Sequence)
 
-	if promoteChanID != "" {
+	if len(r.args.createReleasePromote) > 0 {
 		log.ActionWithSpinner("Promoting")
-		if err := r.api.PromoteRelease(
-			r.appID,
-			r.appType,
+		if _, err := r.promoteToChannels(cmd.Context(),
 			release.Sequence,
+			promotions,
 			r.args.createReleasePromoteVersion,
 			r.args.createReleasePromoteNotes,
 			r.args.createReleasePromoteRequired,
-			promoteChanID,
 		); err != nil {
 			log.FinishSpinnerWithError()
 			return err
 		}
 		log.FinishSpinner()
 
-		// ignore error since operation was successful
-		log.ChildActionWithoutSpinner("Channel %s successfully set to release %d\n", promoteChanID, release.Sequence)
+		for _, promotion := range promotions {
+			log.ChildActionWithoutSpinner("Channel %s successfully set to release %d\n", promotion.ChannelID, release.Sequence)
+		}
 	}
 
 	return nil
@@ -296,12 +326,16 @@ func (r *runners) validateReleaseCreateParams() error {
 		return errors.New("use the --yaml-file flag when passing a yaml filename")
 	}
 
-	if r.args.createReleaseYamlDir == "" && r.appType == "kots" {
-		return errors.New("--yaml-dir flag must be provided for KOTS applications")
+	if r.args.createReleaseYamlDir == "" && !r.hasReleaseSources() && r.appType == "kots" {
+		return errors.New("one of --yaml-dir, --helm-chart or --kustomize must be provided for KOTS applications")
+	}
+
+	if r.hasReleaseSources() && r.appType != "kots" {
+		return errors.Errorf("the --helm-chart and --kustomize flags are only supported for KOTS applications, app %q is of type %q", r.appID, r.appType)
 	}
 
 	// can't ensure a channel if you didn't pass one
-	if r.args.createReleasePromoteEnsureChannel && r.args.createReleasePromote == "" {
+	if r.args.createReleasePromoteEnsureChannel && len(r.args.createReleasePromote) == 0 {
 		return errors.New("cannot use the flag --ensure-channel without also using --promote <channel> ")
 	}
 
@@ -325,10 +359,11 @@ func (r *runners) validateReleaseCreateParams() error {
 	return nil
 }
 
-func (r *runners) getOrCreateChannelForPromotion(channelName string, createIfAbsent bool) (string, error) {
+func (r *runners) getOrCreateChannelForPromotion(ctx context.Context, channelName string, createIfAbsent bool) (string, error) {
 	description := "" // todo: do we want a flag for the desired channel description
 
-	channel, err := r.api.GetOrCreateChannelByName(
+	channel, err := r.api.GetOrCreateChannelByNameContext(
+		ctx,
 		r.appID,
 		r.appType,
 		r.appSlug,
@@ -343,34 +378,14 @@ func (r *runners) getOrCreateChannelForPromotion(channelName string, createIfAbs
 	return channel.ID, nil
 }
 
-func encodeKotsFile(prefix, path string, info os.FileInfo, err error) (*kotsSingleSpec, error) {
-	if err != nil {
-		return nil, err
-	}
-
-	singlefile := strings.TrimPrefix(filepath.Clean(path), filepath.Clean(prefix)+"/")
-
-	if info.IsDir() {
-		return nil, nil
-	}
-	if strings.HasPrefix(info.Name(), ".") {
-		return nil, nil
-	}
-	ext := filepath.Ext(info.Name())
-	switch ext {
-	case ".tgz", ".gz", ".yaml", ".yml":
-		// continue
-	default:
-		return nil, nil
-	}
-
+func encodeKotsFile(path string, relPath string, info os.FileInfo) (*kotsSingleSpec, error) {
 	bytes, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, errors.Wrapf(err, "read file %s", path)
 	}
 
 	var str string
-	switch ext {
+	switch filepath.Ext(info.Name()) {
 	case ".tgz", ".gz":
 		str = base64.StdEncoding.EncodeToString(bytes)
 	default:
@@ -379,33 +394,44 @@ func encodeKotsFile(prefix, path string, info os.FileInfo, err error) (*kotsSing
 
 	return &kotsSingleSpec{
 		Name:     info.Name(),
-		Path:     singlefile,
+		Path:     relPath,
 		Content:  str,
 		Children: []string{},
 	}, nil
 }
 
-func readYAMLDir(yamlDir string) (string, error) {
+func readYAMLDir(yamlDir string, opts releasefiles.Options) (string, error) {
+	allKotsReleaseSpecs, err := readYAMLDirSpecs(yamlDir, opts)
+	if err != nil {
+		return "", err
+	}
+
+	jsonAllYamls, err := json.Marshal(allKotsReleaseSpecs)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal spec")
+	}
+	return string(jsonAllYamls), nil
+}
+
+func readYAMLDirSpecs(yamlDir string, opts releasefiles.Options) ([]kotsSingleSpec, error) {
+	selector, err := releasefiles.NewSelector(yamlDir, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	var allKotsReleaseSpecs []kotsSingleSpec
-	err := filepath.Walk(yamlDir, func(path string, info os.FileInfo, err error) error {
-		spec, err := encodeKotsFile(yamlDir, path, info, err)
+	err = selector.Walk(func(path string, relPath string, info os.FileInfo) error {
+		spec, err := encodeKotsFile(path, relPath, info)
 		if err != nil {
 			return err
-		} else if spec == nil {
-			return nil
 		}
 		allKotsReleaseSpecs = append(allKotsReleaseSpecs, *spec)
 		return nil
 	})
 	if err != nil {
-		return "", errors.Wrapf(err, "walk %s", yamlDir)
+		return nil, errors.Wrapf(err, "walk %s", yamlDir)
 	}
-
-	jsonAllYamls, err := json.Marshal(allKotsReleaseSpecs)
-	if err != nil {
-		return "", errors.Wrap(err, "marshal spec")
-	}
-	return string(jsonAllYamls), nil
+	return allKotsReleaseSpecs, nil
 }
 
 func promptForConfirm() (string, error) {
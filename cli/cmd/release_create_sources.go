@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/releasesource"
+)
+
+func (r *runners) hasReleaseSources() bool {
+	return len(r.args.createReleaseHelmCharts) > 0 || len(r.args.createReleaseKustomize) > 0
+}
+
+func (r *runners) releaseSourceNames() []string {
+	var names []string
+	if r.args.createReleaseYamlDir != "" {
+		names = append(names, r.args.createReleaseYamlDir)
+	}
+	names = append(names, r.args.createReleaseHelmCharts...)
+	names = append(names, r.args.createReleaseKustomize...)
+	return names
+}
+
+// readReleaseSources returns the release files from releaseSpecs as the JSON
+// the API expects
+func (r *runners) readReleaseSources(ctx context.Context) (string, error) {
+	specs, err := r.releaseSpecs(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(specs)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal spec")
+	}
+	return string(b), nil
+}
+
+// releaseSpecs reads the yaml dir, packages the --helm-chart directories and
+// renders the --kustomize overlays into a single set of release files. Helm
+// charts are added as <name>-<version>.tgz, which is also how release download
+// writes them back out.
+func (r *runners) releaseSpecs(ctx context.Context) ([]kotsSingleSpec, error) {
+	var specs []kotsSingleSpec
+	if r.args.createReleaseYamlDir != "" {
+		var err error
+		specs, err = readYAMLDirSpecs(r.args.createReleaseYamlDir, r.releaseFileOptions())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// manifests, used to look for HelmChart kinds that already exist
+	var manifests []releasesource.File
+	for _, spec := range specs {
+		if !isBase64Encoded(spec.Path) {
+			manifests = append(manifests, releasesource.File{Path: spec.Path, Content: []byte(spec.Content)})
+		}
+	}
+
+	var files []releasesource.File
+	for _, dir := range r.args.createReleaseKustomize {
+		rendered, err := releasesource.Kustomize(ctx, dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "render kustomize overlay %s", dir)
+		}
+		files = append(files, rendered...)
+		manifests = append(manifests, rendered...)
+	}
+
+	for _, dir := range r.args.createReleaseHelmCharts {
+		chart, meta, err := releasesource.PackageChart(dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "package helm chart %s", dir)
+		}
+		files = append(files, *chart)
+		if !releasesource.HasHelmChartKind(manifests, meta.Name) {
+			files = append(files, releasesource.HelmChartKind(*meta))
+		}
+	}
+
+	for _, file := range files {
+		content := string(file.Content)
+		if isBase64Encoded(file.Path) {
+			content = base64.StdEncoding.EncodeToString(file.Content)
+		}
+		specs = append(specs, kotsSingleSpec{
+			Name:     path.Base(file.Path),
+			Path:     file.Path,
+			Content:  content,
+			Children: []string{},
+		})
+	}
+
+	seen := map[string]bool{}
+	for _, spec := range specs {
+		if seen[spec.Path] {
+			return nil, errors.Errorf("more than one release source produces the file %s", spec.Path)
+		}
+		seen[spec.Path] = true
+	}
+	return specs, nil
+}
+
+func isBase64Encoded(filename string) bool {
+	switch path.Ext(filename) {
+	case ".tgz", ".gz":
+		return true
+	default:
+		return false
+	}
+}
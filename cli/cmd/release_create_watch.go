@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/dirwatch"
+	"github.com/replicatedhq/replicated/pkg/lint"
+	"github.com/replicatedhq/replicated/pkg/releasefiles"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+const watchPollInterval = 500 * time.Millisecond
+
+// releaseCreateWatch creates and promotes a release whenever the files in the
+// yaml dir change, until it is interrupted. Releases are only created when the
+// local linter finds nothing at or above the --fail-on severity.
+func (r *runners) releaseCreateWatch(cmd *cobra.Command) error {
+	if r.appType != "kots" {
+		return errors.Errorf("the --watch flag is only supported for KOTS applications, app %q is of type %q", r.appID, r.appType)
+	}
+	if r.args.createReleaseYamlDir == "" {
+		return errors.New("the --watch flag requires --yaml-dir")
+	}
+	if _, ok := validFailOnValues[r.args.lintReleaseFailOn]; !ok {
+		return errors.Errorf("fail-on value %q not supported, supported values are [info, warn, error, none]", r.args.lintReleaseFailOn)
+	}
+
+	channelNames := r.args.createReleasePromote
+	if len(channelNames) == 0 {
+		user := os.Getenv("USER")
+		if user == "" {
+			return errors.New("--promote is required when $USER is not set")
+		}
+		channelNames = []string{"dev-" + user}
+	}
+
+	// personal channels are created on first use
+	promotions, err := r.resolvePromotionChannels(cmd.Context(), channelNames, true)
+	if err != nil {
+		for _, promotion := range promotions {
+			if promotion.Error != "" {
+				fmt.Fprintf(r.w, "Channel %s: %s\n", promotion.Channel, promotion.Error)
+			}
+		}
+		r.w.Flush()
+		return errors.Wrap(err, "get or create channels for promotion")
+	}
+
+	fmt.Fprintf(r.w, "Watching %s, promoting to %s. Press Ctrl+C to stop.\n", r.args.createReleaseYamlDir, strings.Join(channelNames, ", "))
+	r.w.Flush()
+
+	yamlDir := r.args.createReleaseYamlDir
+	// the selector is read again before each poll, so that changes to ignore
+	// files change which files are watched. Changes to ignore files trigger a
+	// release too, since they change what is in it.
+	newFilter := func() (dirwatch.Filter, error) {
+		selector, err := releasefiles.NewSelector(yamlDir, r.releaseFileOptions())
+		if err != nil {
+			return nil, err
+		}
+		return func(path string, info os.FileInfo) bool {
+			if info.Name() == releasefiles.IgnoreFile {
+				return true
+			}
+			rel, err := filepath.Rel(yamlDir, path)
+			if err != nil {
+				return false
+			}
+			return selector.Selected(filepath.ToSlash(rel), false)
+		}, nil
+	}
+
+	r.watchRelease(cmd.Context(), promotions, dirwatch.Changes{})
+	return dirwatch.Watch(cmd.Context(), yamlDir, newFilter, watchPollInterval, r.args.createReleaseWatchDebounce, func(changes dirwatch.Changes) error {
+		r.watchRelease(cmd.Context(), promotions, changes)
+		return nil
+	})
+}
+
+// watchRelease lints, creates and promotes one release. Failures are printed
+// rather than returned so that watching continues.
+func (r *runners) watchRelease(ctx context.Context, promotions []types.ChannelPromotion, changes dirwatch.Changes) {
+	defer r.w.Flush()
+
+	now := time.Now().Format("15:04:05")
+	if !changes.Empty() {
+		fmt.Fprintf(r.w, "[%s] changed: %s\n", now, summarizeChanges(changes))
+	}
+
+	lintResult, err := lint.LintDir(r.args.createReleaseYamlDir, r.releaseFileOptions())
+	if err != nil {
+		fmt.Fprintf(r.w, "[%s] lint failed: %s\n", now, err)
+		return
+	}
+	if shouldFail(lintResult, r.args.lintReleaseFailOn) {
+		for _, msg := range lintResult {
+			line := ""
+			if len(msg.Positions) > 0 {
+				line = fmt.Sprintf(":%d", msg.Positions[0].Start.Line)
+			}
+			fmt.Fprintf(r.w, "  %s %s%s %s\n", msg.Type, msg.Path, line, msg.Message)
+		}
+		fmt.Fprintf(r.w, "[%s] release skipped, %d lint messages\n", now, len(lintResult))
+		return
+	}
+
+	yaml, err := r.readReleaseSources(ctx)
+	if err != nil {
+		fmt.Fprintf(r.w, "[%s] read release sources failed: %s\n", now, err)
+		return
+	}
+
+	release, err := r.api.CreateReleaseContext(ctx, r.appID, r.appType, yaml)
+	if err != nil {
+		if ctx.Err() == nil {
+			fmt.Fprintf(r.w, "[%s] create release failed: %s\n", now, err)
+		}
+		return
+	}
+
+	promoted := make([]types.ChannelPromotion, len(promotions))
+	copy(promoted, promotions)
+	if _, err := r.promoteToChannels(ctx, release.Sequence, promoted, r.args.createReleasePromoteVersion, r.args.createReleasePromoteNotes, false); err != nil {
+		if ctx.Err() == nil {
+			fmt.Fprintf(r.w, "[%s] release %d created, promote failed: %s\n", now, release.Sequence, err)
+		}
+		return
+	}
+
+	names := make([]string, 0, len(promoted))
+	for _, promotion := range promoted {
+		names = append(names, promotion.Channel)
+	}
+	fmt.Fprintf(r.w, "[%s] release %d promoted to %s\n", now, release.Sequence, strings.Join(names, ", "))
+}
+
+// summarizeChanges lists changed files compactly, prefixed with + for added,
+// ~ for modified and - for removed
+func summarizeChanges(changes dirwatch.Changes) string {
+	var parts []string
+	for _, path := range changes.Added {
+		parts = append(parts, "+"+path)
+	}
+	for _, path := range changes.Modified {
+		parts = append(parts, "~"+path)
+	}
+	for _, path := range changes.Removed {
+		parts = append(parts, "-"+path)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/replicatedhq/replicated/pkg/releasediff"
+	"github.com/replicatedhq/replicated/pkg/releasefiles"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitReleaseDiff(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:   "diff SEQUENCE [SEQUENCE]",
+		Short: "Show the differences between two releases",
+		Long: `Show the differences between two releases, or between a release and a local directory of manifests.
+
+Prints a unified diff for every added, removed or changed file, followed by a summary.
+Helm chart archives are compared by content and only listed in the summary.`,
+		Example: `  # compare release 41 to release 42
+  replicated release diff 41 42
+
+  # compare release 42 to the manifests that would be released next
+  replicated release diff 42 --yaml-dir ./manifests`,
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+
+	cmd.Flags().StringVar(&r.args.releaseDiffYamlDir, "yaml-dir", "", "Compare the release to this directory of manifests instead of to a second release")
+
+	cmd.RunE = r.releaseDiff
+}
+
+func (r *runners) releaseDiff(cmd *cobra.Command, args []string) error {
+	if r.args.releaseDiffYamlDir == "" && len(args) != 2 {
+		return errors.New("two release sequences, or one release sequence and --yaml-dir, are required")
+	}
+	if r.args.releaseDiffYamlDir != "" && len(args) != 1 {
+		return errors.New("exactly one release sequence is required with --yaml-dir")
+	}
+	if r.args.releaseDiffYamlDir != "" && r.appType != "kots" {
+		return errors.New("--yaml-dir is only supported for KOTS applications")
+	}
+
+	fromLabel, fromFiles, err := r.releaseDiffFiles(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+
+	var toLabel string
+	var toFiles []releasediff.File
+	if r.args.releaseDiffYamlDir != "" {
+		toLabel = filepath.Clean(r.args.releaseDiffYamlDir)
+		releaseYAML, err := readYAMLDir(r.args.releaseDiffYamlDir, releasefiles.Options{})
+		if err != nil {
+			return errors.Wrap(err, "read yaml dir")
+		}
+		toFiles, err = kotsReleaseFiles(releaseYAML)
+		if err != nil {
+			return err
+		}
+	} else {
+		toLabel, toFiles, err = r.releaseDiffFiles(cmd.Context(), args[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := releasediff.Compare(fromLabel, fromFiles, toLabel, toFiles)
+	if err != nil {
+		return errors.Wrap(err, "compare releases")
+	}
+
+	if outputFormat != print.FormatTable {
+		return print.ReleaseDiff(outputFormat, r.w, result)
+	}
+
+	// diffs are written as-is, the tabwriter would realign any tabs in them
+	w := cmd.OutOrStdout()
+	for _, file := range result.Files {
+		if file.Chart {
+			fmt.Fprintf(w, "Chart %s %s\n", file.Path, file.Status)
+			continue
+		}
+		fmt.Fprint(w, file.Diff)
+	}
+	if !result.IsEmpty() {
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, result.Summary())
+
+	return nil
+}
+
+// releaseDiffFiles fetches a release and returns its files, labelled with the sequence
+func (r *runners) releaseDiffFiles(ctx context.Context, sequenceArg string) (string, []releasediff.File, error) {
+	seq, err := strconv.ParseInt(sequenceArg, 10, 64)
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed to parse sequence argument %q", sequenceArg)
+	}
+
+	release, err := r.api.GetReleaseContext(ctx, r.appID, r.appType, seq)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "get release %d", seq)
+	}
+
+	label := strconv.FormatInt(seq, 10)
+	if r.appType != "kots" {
+		return label, []releasediff.File{{Path: "replicated.yaml", Content: release.Config}}, nil
+	}
+
+	files, err := kotsReleaseFiles(release.Config)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "release %d", seq)
+	}
+	return label, files, nil
+}
+
+func kotsReleaseFiles(config string) ([]releasediff.File, error) {
+	var releaseYamls []kotsSingleSpec
+	if err := json.Unmarshal([]byte(config), &releaseYamls); err != nil {
+		return nil, errors.Wrap(err, "unmarshal release yamls")
+	}
+
+	files := make([]releasediff.File, 0, len(releaseYamls))
+	for _, releaseYaml := range releaseYamls {
+		files = append(files, releasediff.File{Path: releaseYaml.Path, Content: releaseYaml.Content})
+	}
+	return files, nil
+}
@@ -44,7 +44,7 @@ func (r *runners) releaseDownload(command *cobra.Command, args []string) error {
 
 	log := print.NewLogger(os.Stdout)
 	log.ActionWithSpinner("Fetching Release %d", seq)
-	release, err := r.api.GetRelease(r.appID, r.appType, seq)
+	release, err := r.api.GetReleaseContext(command.Context(), r.appID, r.appType, seq)
 	if err != nil {
 		log.FinishSpinnerWithError()
 		return errors.Wrap(err, "get release")
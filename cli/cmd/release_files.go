@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/replicatedhq/replicated/pkg/releasefiles"
+	"github.com/spf13/cobra"
+)
+
+// addReleaseFileFlags adds the flags that choose which files under --yaml-dir
+// are part of a release
+func (r *runners) addReleaseFileFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&r.args.releaseFilesInclude, "include", nil, "Only include the files in --yaml-dir matching this gitignore-style pattern. Can be repeated.")
+	cmd.Flags().StringArrayVar(&r.args.releaseFilesExclude, "exclude", nil, "Leave out the files in --yaml-dir matching this gitignore-style pattern, in addition to those in "+releasefiles.IgnoreFile+" files. Can be repeated.")
+	cmd.Flags().BoolVar(&r.args.releaseFilesList, "list-files", false, "List the files that would be sent, without sending anything")
+}
+
+func (r *runners) releaseFileOptions() releasefiles.Options {
+	return releasefiles.Options{
+		Include: r.args.releaseFilesInclude,
+		Exclude: r.args.releaseFilesExclude,
+	}
+}
+
+func (r *runners) printReleaseFiles(specs []kotsSingleSpec) error {
+	paths := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		paths = append(paths, spec.Path)
+	}
+	return print.ReleaseFiles(outputFormat, r.w, paths)
+}
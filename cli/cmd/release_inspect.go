@@ -21,7 +21,7 @@ func (r *runners) InitReleaseInspect(parent *cobra.Command) {
 	cmd.RunE = r.releaseInspect
 }
 
-func (r *runners) releaseInspect(_ *cobra.Command, args []string) error {
+func (r *runners) releaseInspect(cmd *cobra.Command, args []string) error {
 	if len(args) != 1 {
 		return errors.New("release sequence is required")
 	}
@@ -30,7 +30,7 @@ func (r *runners) releaseInspect(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("Failed to parse sequence argument %s", args[0])
 	}
 
-	release, err := r.api.GetRelease(r.appID, r.appType, seq)
+	release, err := r.api.GetReleaseContext(cmd.Context(), r.appID, r.appType, seq)
 	if err != nil {
 		if err == platformclient.ErrNotFound {
 			return fmt.Errorf("No such release %d", seq)
@@ -38,5 +38,5 @@ func (r *runners) releaseInspect(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	return print.Release(r.w, release)
+	return print.Release(outputFormat, r.w, release)
 }
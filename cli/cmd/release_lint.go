@@ -1,13 +1,17 @@
 package cmd
 
 import (
+	"archive/tar"
+	"bytes"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 
-	"github.com/mholt/archiver/v3"
 	"github.com/pkg/errors"
 	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/replicatedhq/replicated/pkg/lint"
+	"github.com/replicatedhq/replicated/pkg/releasefiles"
 	"github.com/replicatedhq/replicated/pkg/types"
 	"github.com/spf13/cobra"
 )
@@ -20,9 +24,23 @@ var (
 		"none":  nil,
 		"":      nil,
 	}
+
+	validLintEngineValues = map[string]interface{}{
+		"local":  nil,
+		"remote": nil,
+	}
+
+	validLintFormatValues = map[string]interface{}{
+		print.FormatTable:      nil,
+		print.FormatJSON:       nil,
+		print.FormatYAML:       nil,
+		print.LintFormatSARIF:  nil,
+		print.LintFormatJUnit:  nil,
+		print.LintFormatGitHub: nil,
+	}
 )
 
-func (r *runners) InitReleaseLint(parent *cobra.Command) {
+func (r *runners) InitReleaseLint(parent *cobra.Command) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "lint",
 		Short:        "Lint a directory of KOTS manifests",
@@ -33,13 +51,18 @@ func (r *runners) InitReleaseLint(parent *cobra.Command) {
 
 	cmd.Flags().StringVar(&r.args.lintReleaseYamlDir, "yaml-dir", "", "The directory containing multiple yamls for a Kots release.  Cannot be used with the `yaml` flag.")
 	cmd.Flags().StringVar(&r.args.lintReleaseFailOn, "fail-on", "error", "The minimum severity to cause the command to exit with a non-zero exit code. Supported values are [info, warn, error, none].")
+	cmd.Flags().StringVar(&r.args.lintReleaseEngine, "engine", "remote", "The lint engine to use. \"remote\" uses the hosted lint service, \"local\" runs the built-in linter without any network access. Supported values are [local, remote].")
+
+	cmd.Flags().StringVar(&r.args.lintReleaseFormat, "format", "", "The format of the lint report. Defaults to the --output format. Supported values are [table, json, yaml, sarif, junit, github].")
+	r.addReleaseFileFlags(cmd)
 
 	cmd.RunE = r.releaseLint
+	return cmd
 }
 
-// releaseLint uses the replicatedhq/kots-lint service. This currently uses
-// the hosted version (lint.replicated.com). There are not changes and no auth required or sent.
-// This could be vendored in and run locally (respecting the size of the polcy files)
+// releaseLint uses either the replicatedhq/kots-lint service or the built-in
+// linter in pkg/lint. The remote engine uses the hosted version (lint.replicated.com),
+// no auth is required or sent. The local engine needs neither an API token nor an app.
 func (r *runners) releaseLint(cmd *cobra.Command, args []string) error {
 	if r.args.lintReleaseYamlDir == "" {
 		return errors.Errorf("yaml is required")
@@ -49,17 +72,52 @@ func (r *runners) releaseLint(cmd *cobra.Command, args []string) error {
 		return errors.Errorf("fail-on value %q not supported, supported values are [info, warn, error, none]", r.args.lintReleaseFailOn)
 	}
 
-	lintReleaseYAML, err := tarYAMLDir(r.args.lintReleaseYamlDir)
-	if err != nil {
-		return errors.Wrap(err, "failed to read yaml dir")
+	if _, ok := validLintEngineValues[r.args.lintReleaseEngine]; !ok {
+		return errors.Errorf("lint engine value %q not supported, supported values are [local, remote]", r.args.lintReleaseEngine)
 	}
 
-	lintResult, err := r.api.LintRelease(r.appType, lintReleaseYAML)
-	if err != nil {
-		return err
+	format := r.args.lintReleaseFormat
+	if format == "" {
+		format = outputFormat
+	}
+	if _, ok := validLintFormatValues[format]; !ok {
+		return errors.Errorf("format value %q not supported, supported values are [table, json, yaml, sarif, junit, github]", format)
+	}
+
+	if r.args.releaseFilesList {
+		paths, err := releasefiles.List(r.args.lintReleaseYamlDir, r.releaseFileOptions())
+		if err != nil {
+			return errors.Wrap(err, "failed to read yaml dir")
+		}
+		return print.ReleaseFiles(outputFormat, r.w, paths)
+	}
+
+	var lintResult []types.LintMessage
+	if r.args.lintReleaseEngine == "local" {
+		var err error
+		lintResult, err = lint.LintDir(r.args.lintReleaseYamlDir, r.releaseFileOptions())
+		if err != nil {
+			return errors.Wrap(err, "failed to lint yaml dir")
+		}
+	} else {
+		lintReleaseYAML, err := tarYAMLDir(r.args.lintReleaseYamlDir, r.releaseFileOptions())
+		if err != nil {
+			return errors.Wrap(err, "failed to read yaml dir")
+		}
+
+		lintResult, err = r.api.LintReleaseContext(cmd.Context(), r.appType, lintReleaseYAML)
+		if err != nil {
+			return err
+		}
+
+		files, err := lint.ReadDir(r.args.lintReleaseYamlDir, r.releaseFileOptions())
+		if err != nil {
+			return errors.Wrap(err, "failed to read yaml dir")
+		}
+		lintResult = append(lintResult, lint.LintTroubleshoot(files)...)
 	}
 
-	if err := print.LintErrors(r.w, lintResult); err != nil {
+	if err := r.printLintResult(cmd.OutOrStdout(), format, r.args.lintReleaseYamlDir, lintResult); err != nil {
 		return err
 	}
 
@@ -70,6 +128,18 @@ func (r *runners) releaseLint(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printLintResult prints lint messages as a report in the given format, with
+// paths relative to baseDir. Reports are written to out directly, not through
+// the tabwriter, which would realign tabs in the JSON and XML.
+func (r *runners) printLintResult(out io.Writer, format string, baseDir string, lintResult []types.LintMessage) error {
+	switch format {
+	case print.LintFormatSARIF, print.LintFormatJUnit, print.LintFormatGitHub:
+		return print.LintReport(format, out, baseDir, lintResult)
+	default:
+		return print.LintErrors(format, r.w, lintResult)
+	}
+}
+
 func shouldFail(lintResult []types.LintMessage, failOn string) bool {
 	switch failOn {
 	case "", "none":
@@ -94,27 +164,44 @@ func shouldFail(lintResult []types.LintMessage, failOn string) bool {
 	return len(lintResult) > 0
 }
 
-func tarYAMLDir(yamlDir string) ([]byte, error) {
-	archiveDir, err := ioutil.TempDir("", "replicated")
+// tarYAMLDir archives the release files in yamlDir under a top level folder
+// named after the directory, the layout the lint service expects
+func tarYAMLDir(yamlDir string, opts releasefiles.Options) ([]byte, error) {
+	absDir, err := filepath.Abs(yamlDir)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create temp dir for archive")
+		return nil, errors.Wrapf(err, "get absolute path for %s", yamlDir)
 	}
-	defer os.RemoveAll(archiveDir)
+	topLevel := filepath.Base(absDir)
 
-	archiveFile := filepath.Join(archiveDir, "kots-release.tar")
-
-	tar := archiver.Tar{
-		ImplicitTopLevelFolder: true,
+	selector, err := releasefiles.NewSelector(yamlDir, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := tar.Archive([]string{yamlDir}, archiveFile); err != nil {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	err = selector.Walk(func(path string, relPath string, info os.FileInfo) error {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "read file %s", path)
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return errors.Wrapf(err, "create header for %s", path)
+		}
+		header.Name = topLevel + "/" + relPath
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err = tw.Write(content)
+		return err
+	})
+	if err != nil {
 		return nil, errors.Wrap(err, "failed to archive")
 	}
-
-	data, err := ioutil.ReadFile(archiveFile)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to read archive file")
+	if err := tw.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to archive")
 	}
 
-	return data, nil
+	return buf.Bytes(), nil
 }
@@ -17,10 +17,10 @@ func (r *runners) IniReleaseList(parent *cobra.Command) {
 }
 
 func (r *runners) releaseList(cmd *cobra.Command, args []string) error {
-	releases, err := r.api.ListReleases(r.appID, r.appType)
+	releases, err := r.api.ListReleasesContext(cmd.Context(), r.appID, r.appType)
 	if err != nil {
 		return err
 	}
 
-	return print.Releases(r.w, releases)
+	return print.Releases(outputFormat, r.w, releases)
 }
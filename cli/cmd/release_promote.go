@@ -1,20 +1,27 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
 	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/replicatedhq/replicated/pkg/types"
 	"github.com/spf13/cobra"
 )
 
 func (r *runners) InitReleasePromote(parent *cobra.Command) {
 	cmd := &cobra.Command{
-		Use:   "promote SEQUENCE CHANNEL_ID",
-		Short: "Set the release for a channel",
-		Long: `Set the release for a channel
+		Use:   "promote SEQUENCE CHANNEL_ID [CHANNEL_ID...]",
+		Short: "Set the release for one or more channels",
+		Long: `Set the release for one or more channels
 
-  Example: replicated release promote 15 fe4901690971757689f022f7a460f9b2`,
+All of the channels are resolved before anything is promoted, and the release
+is promoted to all of them in a single request.
+
+  Example: replicated release promote 15 fe4901690971757689f022f7a460f9b2
+  Example: replicated release promote 42 Beta Stable Enterprise`,
 	}
 
 	parent.AddCommand(cmd)
@@ -28,32 +35,85 @@ func (r *runners) InitReleasePromote(parent *cobra.Command) {
 
 func (r *runners) releasePromote(cmd *cobra.Command, args []string) error {
 	// parse sequence and channel ID positional arguments
-	if len(args) != 2 {
+	if len(args) < 2 {
 		return errors.New("release sequence and channel ID are required")
 	}
 	seq, err := strconv.ParseInt(args[0], 10, 64)
 	if err != nil {
 		return fmt.Errorf("Failed to parse sequence argument %s", args[0])
 	}
-	channelName := args[1]
-	newID := channelName
 
-	if r.appType != "ship" {
-		// try to turn chanID into an actual id if it was a channel name
-		channelID, err := r.api.GetOrCreateChannelByName(r.appID, r.appType, r.appSlug, channelName, "", false)
+	var promotions []types.ChannelPromotion
+	if r.appType == "ship" {
+		// ship channels are always given by ID
+		for _, channelID := range args[1:] {
+			promotions = append(promotions, types.ChannelPromotion{Channel: channelID, ChannelID: channelID})
+		}
+	} else {
+		promotions, err = r.resolvePromotionChannels(cmd.Context(), args[1:], false)
+	}
+	for i := range promotions {
+		promotions[i].Sequence = seq
+	}
+	if err == nil {
+		promotions, err = r.promoteToChannels(cmd.Context(), seq, promotions, r.args.releaseVersion, r.args.releaseNotes, !r.args.releaseOptional)
+	}
+	if printErr := print.ChannelPromotions(outputFormat, r.w, promotions); printErr != nil {
+		return printErr
+	}
+	return err
+}
+
+// resolvePromotionChannels resolves each channel name or ID to promote a
+// release to. The result for each channel is returned, along with an error if
+// any channel could not be resolved.
+func (r *runners) resolvePromotionChannels(ctx context.Context, channelNames []string, createIfAbsent bool) ([]types.ChannelPromotion, error) {
+	promotions := make([]types.ChannelPromotion, 0, len(channelNames))
+	failed := 0
+	for _, channelName := range channelNames {
+		promotion := types.ChannelPromotion{
+			Channel: channelName,
+		}
+
+		channelID, err := r.getOrCreateChannelForPromotion(ctx, channelName, createIfAbsent)
 		if err != nil {
-			return errors.Wrapf(err, "unable to get channel ID from name")
+			promotion.Error = err.Error()
+			failed++
+		} else {
+			promotion.ChannelID = channelID
 		}
-		newID = channelID.ID
+
+		promotions = append(promotions, promotion)
 	}
 
-	if err := r.api.PromoteRelease(r.appID, r.appType, seq, r.args.releaseVersion, r.args.releaseNotes, !r.args.releaseOptional, newID); err != nil {
-		return err
+	if failed > 0 {
+		return promotions, errors.Errorf("%d of %d channels could not be resolved, the release was not promoted", failed, len(channelNames))
 	}
+	return promotions, nil
+}
 
-	// ignore error since operation was successful
-	fmt.Fprintf(r.w, "Channel %s successfully set to release %d\n", channelName, seq)
-	r.w.Flush()
+// promoteToChannels promotes a release to all of the resolved channels in one
+// request, so that either every channel is set to the release or none is
+func (r *runners) promoteToChannels(ctx context.Context, sequence int64, promotions []types.ChannelPromotion, label string, notes string, required bool) ([]types.ChannelPromotion, error) {
+	var channelIDs []string
+	seen := map[string]bool{}
+	for i := range promotions {
+		promotions[i].Sequence = sequence
+		if !seen[promotions[i].ChannelID] {
+			seen[promotions[i].ChannelID] = true
+			channelIDs = append(channelIDs, promotions[i].ChannelID)
+		}
+	}
 
-	return nil
+	if err := r.api.PromoteReleaseContext(ctx, r.appID, r.appType, sequence, label, notes, required, channelIDs...); err != nil {
+		for i := range promotions {
+			promotions[i].Error = err.Error()
+		}
+		return promotions, err
+	}
+
+	for i := range promotions {
+		promotions[i].Promoted = true
+	}
+	return promotions, nil
 }
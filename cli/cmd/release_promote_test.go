@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePromotionChannels(t *testing.T) {
+	tests := []struct {
+		name           string
+		channels       []string
+		createIfAbsent bool
+		want           []types.ChannelPromotion
+		wantChannels   []string
+		wantErr        string
+	}{
+		{
+			name:     "names and ids",
+			channels: []string{"Stable", "beta-id"},
+			want: []types.ChannelPromotion{
+				{Channel: "Stable", ChannelID: "stable-id"},
+				{Channel: "beta-id", ChannelID: "beta-id"},
+			},
+			wantChannels: []string{"Stable", "Beta", "Unstable"},
+		},
+		{
+			name:     "resolved and unknown channels",
+			channels: []string{"Stable", "Nightly", "Beta", "Canary"},
+			want: []types.ChannelPromotion{
+				{Channel: "Stable", ChannelID: "stable-id"},
+				{Channel: "Nightly", Error: `get-or-create channel "Nightly": find channel "Nightly": No channel "Nightly" `},
+				{Channel: "Beta", ChannelID: "beta-id"},
+				{Channel: "Canary", Error: `get-or-create channel "Canary": find channel "Canary": No channel "Canary" `},
+			},
+			wantChannels: []string{"Stable", "Beta", "Unstable"},
+			wantErr:      "2 of 4 channels could not be resolved, the release was not promoted",
+		},
+		{
+			name:           "ensure channel creates only the missing ones",
+			channels:       []string{"Stable", "Nightly", "Beta"},
+			createIfAbsent: true,
+			want: []types.ChannelPromotion{
+				{Channel: "Stable", ChannelID: "stable-id"},
+				{Channel: "Nightly", ChannelID: "channel-4"},
+				{Channel: "Beta", ChannelID: "beta-id"},
+			},
+			wantChannels: []string{"Stable", "Beta", "Unstable", "Nightly"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			api := newTestVendorAPI()
+			r := newTestRunners(t, api)
+
+			promotions, err := r.resolvePromotionChannels(context.Background(), test.channels, test.createIfAbsent)
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+			} else {
+				req.NoError(err)
+			}
+			req.Equal(test.want, promotions)
+			req.Equal(test.wantChannels, api.channelNames())
+			req.Empty(api.promoted)
+		})
+	}
+}
+
+func TestReleasePromote(t *testing.T) {
+	semverErr := "POST /v3/app/app-id/release/1/promote 400: {\"message\":\"channel Stable requires a semantic version, got \\\"latest\\\"\"}\n"
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+		// wantPromoted are the channel IDs of each promote request
+		wantPromoted [][]string
+		wantErr      string
+	}{
+		{
+			name:         "several channels in one request",
+			args:         []string{"1", "Beta", "Stable", "--version", "1.0.0"},
+			want:         "Channel Beta successfully set to release 1\nChannel Stable successfully set to release 1\n",
+			wantPromoted: [][]string{{"beta-id", "stable-id"}},
+		},
+		{
+			name:         "same channel by name and id",
+			args:         []string{"1", "Beta", "beta-id", "--version", "1.0.0"},
+			want:         "Channel Beta successfully set to release 1\nChannel beta-id successfully set to release 1\n",
+			wantPromoted: [][]string{{"beta-id"}},
+		},
+		{
+			name: "unknown channel",
+			args: []string{"1", "Beta", "Nightly", "--version", "1.0.0"},
+			want: "Channel Beta not set to release 1\n" +
+				"Channel Nightly not set to release 1: get-or-create channel \"Nightly\": find channel \"Nightly\": No channel \"Nightly\" \n",
+			wantErr: "1 of 2 channels could not be resolved, the release was not promoted",
+		},
+		{
+			name: "promotion rejected",
+			args: []string{"1", "Beta", "Stable", "--version", "latest"},
+			want: "Channel Beta not set to release 1: " + semverErr + "\n" +
+				"Channel Stable not set to release 1: " + semverErr + "\n",
+			wantErr: semverErr,
+		},
+		{
+			name:    "invalid sequence",
+			args:    []string{"latest", "Beta"},
+			wantErr: "Failed to parse sequence argument latest",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			api := newTestVendorAPI()
+			api.channels[0].SemverRequired = true
+			r := newTestRunners(t, api)
+
+			args := append([]string{"promote"}, test.args...)
+			out, err := executeTestCommand(r, func(parent *cobra.Command) { r.InitReleasePromote(parent) }, args...)
+			if test.wantErr != "" {
+				req.Error(err)
+				req.Equal(test.wantErr, stripOrigin(err.Error()))
+			} else {
+				req.NoError(err)
+			}
+			req.Equal(test.want, stripOrigin(out))
+			req.Equal(test.wantPromoted, api.promoted)
+		})
+	}
+}
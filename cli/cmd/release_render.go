@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/replicatedhq/replicated/pkg/lint"
+	"github.com/replicatedhq/replicated/pkg/releasefiles"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitReleaseRender(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render the KOTS templates in a directory of manifests",
+		Long: `Render the KOTS templates in a directory of manifests, without installing.
+
+Templates like repl{{ ConfigOption "hostname" }} are evaluated locally with the
+config values and license given. Config options without a value use the value
+or default from the release's Config. Functions that need a cluster, like
+Lookup, fail. Helm templates are left as they are.
+
+Rendered files are printed, or written under --dest. Files that fail to
+render are reported with the line of the failing template.`,
+		Example: `  # review the rendered output
+  replicated release render --yaml-dir ./manifests --config-values values.yaml --license license.yaml
+
+  # write the rendered files to diff them in a PR
+  replicated release render --yaml-dir ./manifests --config-values values.yaml --dest ./rendered`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+
+	cmd.Flags().StringVar(&r.args.renderReleaseYamlDir, "yaml-dir", "", "The directory containing the manifests to render")
+	cmd.Flags().StringVar(&r.args.renderReleaseConfigValues, "config-values", "", "A ConfigValues manifest with the values of config options")
+	cmd.Flags().StringVar(&r.args.renderReleaseLicense, "license", "", "A License manifest, used by LicenseFieldValue")
+	cmd.Flags().StringVar(&r.args.renderReleaseNamespace, "namespace", "default", "The namespace returned by Namespace")
+	cmd.Flags().StringVar(&r.args.renderReleaseDest, "dest", "", "A directory to write the rendered files to, instead of printing them")
+	r.addReleaseFileFlags(cmd)
+
+	cmd.RunE = r.releaseRender
+	return cmd
+}
+
+func (r *runners) releaseRender(cmd *cobra.Command, _ []string) error {
+	if r.args.renderReleaseYamlDir == "" {
+		return errors.New("yaml-dir is required")
+	}
+
+	if r.args.releaseFilesList {
+		paths, err := releasefiles.List(r.args.renderReleaseYamlDir, r.releaseFileOptions())
+		if err != nil {
+			return errors.Wrap(err, "failed to read yaml dir")
+		}
+		return print.ReleaseFiles(outputFormat, r.w, paths)
+	}
+
+	opts := lint.RenderOptions{
+		Namespace: r.args.renderReleaseNamespace,
+	}
+	if r.args.renderReleaseConfigValues != "" {
+		configValues, err := ioutil.ReadFile(r.args.renderReleaseConfigValues)
+		if err != nil {
+			return errors.Wrap(err, "read config values")
+		}
+		opts.ConfigValues = configValues
+	}
+	if r.args.renderReleaseLicense != "" {
+		license, err := ioutil.ReadFile(r.args.renderReleaseLicense)
+		if err != nil {
+			return errors.Wrap(err, "read license")
+		}
+		opts.License = license
+	}
+
+	files, err := lint.ReadDir(r.args.renderReleaseYamlDir, r.releaseFileOptions())
+	if err != nil {
+		return errors.Wrap(err, "failed to read yaml dir")
+	}
+	rendered, err := lint.Render(files, opts)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, file := range rendered {
+		if len(file.Errors) > 0 {
+			failed++
+			for _, msg := range file.Errors {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s:%d: %s\n", msg.Path, msg.Positions[0].Start.Line, msg.Message)
+			}
+			continue
+		}
+
+		if r.args.renderReleaseDest != "" {
+			dest := filepath.Join(r.args.renderReleaseDest, filepath.FromSlash(file.Path))
+			if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+				return errors.Wrapf(err, "create directory for %s", dest)
+			}
+			if err := ioutil.WriteFile(dest, file.Content, 0644); err != nil {
+				return errors.Wrapf(err, "write %s", dest)
+			}
+			continue
+		}
+
+		content := string(file.Content)
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		// not through r.w, the tabwriter would realign tabs in the yaml
+		fmt.Fprintf(cmd.OutOrStdout(), "---\n# Source: %s\n%s", file.Path, content)
+	}
+
+	if failed > 0 {
+		return errors.Errorf("%d of %d files failed to render", failed, len(rendered))
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/replicatedhq/replicated/pkg/releasesize"
+	"github.com/spf13/cobra"
+)
+
+// largestFilesShown is how many files are listed when a release is over a
+// size threshold
+const largestFilesShown = 5
+
+// addReleaseSizeFlags adds the flags that check the size of a KOTS release
+// before it is uploaded
+func (r *runners) addReleaseSizeFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&r.args.releaseSizeReport, "size-report", false, "Print the size of each file and of the upload before uploading the release")
+	cmd.Flags().StringVar(&r.args.releaseSizeWarn, "size-warn", "", "Warn when the compressed upload is larger than this size, for example 5MB")
+	cmd.Flags().StringVar(&r.args.releaseSizeLimit, "size-limit", "", "Fail without uploading when the compressed upload is larger than this size, for example 10MB")
+}
+
+// checkReleaseSize measures a release spec before upload. It prints the size
+// report when asked to, to stderr when the output is json or yaml, warns above
+// --size-warn and returns an error above --size-limit.
+func (r *runners) checkReleaseSize(cmd *cobra.Command, spec string) error {
+	if !r.args.releaseSizeReport && r.args.releaseSizeWarn == "" && r.args.releaseSizeLimit == "" {
+		return nil
+	}
+	if r.appType != "kots" {
+		return errors.Errorf("the --size-report, --size-warn and --size-limit flags are only supported for KOTS applications, app %q is of type %q", r.appID, r.appType)
+	}
+
+	var warnAt, limit int64
+	var err error
+	if r.args.releaseSizeWarn != "" {
+		if warnAt, err = releasesize.ParseSize(r.args.releaseSizeWarn); err != nil {
+			return errors.Wrap(err, "parse --size-warn")
+		}
+	}
+	if r.args.releaseSizeLimit != "" {
+		if limit, err = releasesize.ParseSize(r.args.releaseSizeLimit); err != nil {
+			return errors.Wrap(err, "parse --size-limit")
+		}
+	}
+
+	report, err := releasesize.Analyze(spec)
+	if err != nil {
+		return errors.Wrap(err, "analyze release size")
+	}
+
+	if r.args.releaseSizeReport {
+		// a json or yaml report goes to stderr, so that it is not mixed into
+		// what the command prints to stdout
+		w := r.w
+		if outputFormat != print.FormatTable {
+			w = tabwriter.NewWriter(cmd.ErrOrStderr(), minWidth, tabWidth, padding, padChar, tabwriter.TabIndent)
+		}
+		if err := print.ReleaseSize(outputFormat, w, report); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case limit > 0 && report.PayloadSize > limit:
+		printLargestFiles(cmd.ErrOrStderr(), report)
+		return errors.Errorf("release upload is %s, over the --size-limit of %s", releasesize.FormatSize(report.PayloadSize), releasesize.FormatSize(limit))
+	case warnAt > 0 && report.PayloadSize > warnAt:
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: release upload is %s, over the --size-warn of %s\n", releasesize.FormatSize(report.PayloadSize), releasesize.FormatSize(warnAt))
+		printLargestFiles(cmd.ErrOrStderr(), report)
+	}
+	return nil
+}
+
+func printLargestFiles(w io.Writer, report *releasesize.Report) {
+	fmt.Fprintln(w, "Largest files:")
+	for _, file := range report.Largest(largestFilesShown) {
+		fmt.Fprintf(w, "  %s (%s)\n", file.Path, releasesize.FormatSize(file.EncodedSize))
+	}
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckReleaseSizeReport(t *testing.T) {
+	spec := `[{"path": "deployment.yaml", "content": "kind: Deployment"}]`
+
+	tests := []struct {
+		name       string
+		format     string
+		wantStdout bool
+	}{
+		{
+			name:       "table",
+			format:     print.FormatTable,
+			wantStdout: true,
+		},
+		{
+			name:   "json",
+			format: print.FormatJSON,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			r := newTestRunners(t, http.NotFoundHandler())
+			r.args.releaseSizeReport = true
+			outputFormat = tt.format
+
+			var stdout, stderr bytes.Buffer
+			r.w = tabwriter.NewWriter(&stdout, minWidth, tabWidth, padding, padChar, tabwriter.TabIndent)
+			cmd := &cobra.Command{}
+			cmd.SetErr(&stderr)
+
+			req.NoError(r.checkReleaseSize(cmd, spec))
+			if tt.wantStdout {
+				req.Contains(stdout.String(), "deployment.yaml")
+				req.Empty(stderr.String())
+				return
+			}
+			req.Empty(stdout.String())
+			req.True(json.Valid(stderr.Bytes()), stderr.String())
+			req.Contains(stderr.String(), "deployment.yaml")
+		})
+	}
+}
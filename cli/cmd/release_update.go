@@ -22,6 +22,8 @@ func (r *runners) InitReleaseUpdate(parent *cobra.Command) {
 	cmd.Flags().StringVar(&r.args.updateReleaseYaml, "yaml", "", "The new YAML config for this release. Use '-' to read from stdin. Cannot be used with the --yaml-file flag.")
 	cmd.Flags().StringVar(&r.args.updateReleaseYamlFile, "yaml-file", "", "The file name with YAML config for this release. Cannot be used with the --yaml flag.")
 	cmd.Flags().StringVar(&r.args.updateReleaseYamlDir, "yaml-dir", "", "The directory containing multiple yamls for a Kots release. Cannot be used with the --yaml flag.")
+	r.addReleaseFileFlags(cmd)
+	r.addReleaseSizeFlags(cmd)
 
 	cmd.RunE = r.releaseUpdate
 }
@@ -56,6 +58,17 @@ func (r *runners) releaseUpdate(cmd *cobra.Command, args []string) error {
 		r.args.updateReleaseYaml = string(bytes)
 	}
 
+	if r.args.releaseFilesList {
+		if r.args.updateReleaseYamlDir == "" {
+			return errors.New("--list-files requires --yaml-dir")
+		}
+		specs, err := readYAMLDirSpecs(r.args.updateReleaseYamlDir, r.releaseFileOptions())
+		if err != nil {
+			return errors.Wrap(err, "read yaml dir")
+		}
+		return r.printReleaseFiles(specs)
+	}
+
 	if len(args) < 1 {
 		return errors.New("release sequence is required")
 	}
@@ -65,12 +78,15 @@ func (r *runners) releaseUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	if r.args.updateReleaseYamlDir != "" {
-		r.args.updateReleaseYaml, err = readYAMLDir(r.args.updateReleaseYamlDir)
+		r.args.updateReleaseYaml, err = readYAMLDir(r.args.updateReleaseYamlDir, r.releaseFileOptions())
 		if err != nil {
 			return errors.Wrap(err, "read yaml dir")
 		}
 	}
-	if err := r.api.UpdateRelease(r.appID, r.appType, seq, r.args.updateReleaseYaml); err != nil {
+	if err := r.checkReleaseSize(cmd, r.args.updateReleaseYaml); err != nil {
+		return err
+	}
+	if err := r.api.UpdateReleaseContext(cmd.Context(), r.appID, r.appType, seq, r.args.updateReleaseYaml); err != nil {
 		return errors.Wrap(err, "failure setting new yaml config for release")
 	}
 
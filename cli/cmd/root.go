@@ -1,18 +1,24 @@
 package cmd
 
 import (
+	"context"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/pkg/errors"
 
+	"github.com/replicatedhq/replicated/cli/print"
+
 	"github.com/replicatedhq/replicated/pkg/kotsclient"
 	"github.com/replicatedhq/replicated/pkg/shipclient"
 
 	"github.com/replicatedhq/replicated/client"
 	"github.com/replicatedhq/replicated/pkg/platformclient"
+	"github.com/replicatedhq/replicated/pkg/transport"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +32,11 @@ const (
 
 var appSlugOrID string
 var apiToken string
+var outputFormat string
+var profileName string
+var appTypeOverride string
+var requestTimeout time.Duration
+var maxRetries int
 var enterprisePrivateKeyPath = filepath.Join(homeDir(), ".replicated", "enterprise", "ecdsa")
 var platformOrigin = "https://api.replicated.com/vendor"
 var graphqlOrigin = "https://g.replicated.com/graphql"
@@ -62,7 +73,12 @@ func GetRootCmd() *cobra.Command {
 		Long:  `The replicated CLI allows vendors to manage their apps' channels, releases and collectors.`,
 	}
 	rootCmd.PersistentFlags().StringVar(&appSlugOrID, "app", "", "The app slug or app id to use in all calls")
+	rootCmd.PersistentFlags().StringVar(&appTypeOverride, "app-type", "", "The type of the app, skips looking it up. Supported values are [platform, ship, kots].")
 	rootCmd.PersistentFlags().StringVar(&apiToken, "token", "", "The API token to use to access your app in the Vendor API")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "The named profile to use from ~/.replicated/config.yaml. Defaults to REPLICATED_PROFILE, then the current profile.")
+	rootCmd.PersistentFlags().DurationVar(&requestTimeout, "timeout", transport.DefaultTimeout, "The timeout for each attempt of an API request. 0 disables the timeout.")
+	rootCmd.PersistentFlags().IntVar(&maxRetries, "max-retries", transport.DefaultMaxRetries, "The maximum number of times a failed API request is retried. Only requests that are safe to repeat are retried, except when rate limited.")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", print.FormatTable, "The output format to use for list and inspect commands. Supported values are [table, json, yaml].")
 
 	return rootCmd
 }
@@ -95,8 +111,14 @@ Use "{{.CommandPath}} [command] --help" for more information about a command.{{e
 `
 
 // Execute adds all child commands to the root command and sets flags appropriately.
-// This is called by main.main(). It only needs to happen once to the rootCmd.
+// It only needs to happen once to the rootCmd.
 func Execute(rootCmd *cobra.Command, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	return ExecuteContext(context.Background(), rootCmd, stdin, stdout, stderr)
+}
+
+// ExecuteContext is like Execute, API requests made by the commands are
+// cancelled when ctx is done. This is called by main.main().
+func ExecuteContext(ctx context.Context, rootCmd *cobra.Command, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
 	w := tabwriter.NewWriter(stdout, minWidth, tabWidth, padding, padChar, tabwriter.TabIndent)
 
 	// get api client and app ID after flags are parsed
@@ -131,6 +153,8 @@ func Execute(rootCmd *cobra.Command, stdin io.Reader, stdout io.Writer, stderr i
 	runCmds.InitChannelInspect(channelCmd)
 	runCmds.InitChannelAdoption(channelCmd)
 	runCmds.InitChannelReleases(channelCmd)
+	runCmds.InitChannelHistory(channelCmd)
+	runCmds.InitChannelRollback(channelCmd)
 	runCmds.InitChannelCounts(channelCmd)
 	runCmds.InitChannelList(channelCmd)
 	runCmds.InitChannelRemove(channelCmd)
@@ -144,10 +168,12 @@ func Execute(rootCmd *cobra.Command, stdin io.Reader, stdout io.Writer, stderr i
 	}
 	runCmds.InitReleaseInspect(releaseCmd)
 	runCmds.InitReleaseDownload(releaseCmd)
+	runCmds.InitReleaseDiff(releaseCmd)
 	runCmds.IniReleaseList(releaseCmd)
 	runCmds.InitReleaseUpdate(releaseCmd)
 	runCmds.InitReleasePromote(releaseCmd)
-	runCmds.InitReleaseLint(releaseCmd)
+	releaseLintCmd := runCmds.InitReleaseLint(releaseCmd)
+	releaseRenderCmd := runCmds.InitReleaseRender(releaseCmd)
 
 	collectorsCmd := runCmds.InitCollectorsCommand(runCmds.rootCmd)
 	runCmds.InitCollectorList(collectorsCmd)
@@ -155,6 +181,7 @@ func Execute(rootCmd *cobra.Command, stdin io.Reader, stdout io.Writer, stderr i
 	runCmds.InitCollectorPromote(collectorsCmd)
 	runCmds.InitCollectorCreate(collectorsCmd)
 	runCmds.InitCollectorInspect(collectorsCmd)
+	runCmds.InitCollectorMigrate(collectorsCmd)
 
 	entitlementsCmd := runCmds.InitEntitlementsCommand(runCmds.rootCmd)
 	runCmds.InitEntitlementsDefineFields(entitlementsCmd)
@@ -164,7 +191,17 @@ func Execute(rootCmd *cobra.Command, stdin io.Reader, stdout io.Writer, stderr i
 	customersCmd := runCmds.InitCustomersCommand(runCmds.rootCmd)
 	runCmds.InitCustomersLSCommand(customersCmd)
 	runCmds.InitCustomersCreateCommand(customersCmd)
+	runCmds.InitCustomersInspectCommand(customersCmd)
+	runCmds.InitCustomersUpdateCommand(customersCmd)
+	runCmds.InitCustomersArchiveCommand(customersCmd)
+	runCmds.InitCustomersUnarchiveCommand(customersCmd)
+	runCmds.InitCustomersRmCommand(customersCmd)
 	runCmds.InitCustomersDownloadLicenseCommand(customersCmd)
+	runCmds.InitCustomersImportCommand(customersCmd)
+	runCmds.InitCustomersExportCommand(customersCmd)
+
+	licenseCmd := runCmds.InitLicenseCommand(runCmds.rootCmd)
+	runCmds.InitLicenseInspect(licenseCmd)
 
 	installerCmd := runCmds.InitInstallerCommand(runCmds.rootCmd)
 	runCmds.InitInstallerCreate(installerCmd)
@@ -174,6 +211,10 @@ func Execute(rootCmd *cobra.Command, stdin io.Reader, stdout io.Writer, stderr i
 	enterpriseAuthCmd := runCmds.InitEnterpriseAuth(enterpriseCmd)
 	runCmds.InitEnterpriseAuthInit(enterpriseAuthCmd)
 	runCmds.InitEnterpriseAuthApprove(enterpriseAuthCmd)
+	runCmds.InitEnterpriseAuthRotate(enterpriseAuthCmd)
+	runCmds.InitEnterpriseAuthLS(enterpriseAuthCmd)
+	runCmds.InitEnterpriseAuthRevoke(enterpriseAuthCmd)
+	runCmds.InitEnterpriseAuthWhoami(enterpriseAuthCmd)
 	enterpriseChannelCmd := runCmds.InitEnterpriseChannel(enterpriseCmd)
 	runCmds.InitEnterpriseChannelLS(enterpriseChannelCmd)
 	runCmds.InitEnterpriseChannelCreate(enterpriseChannelCmd)
@@ -187,6 +228,7 @@ func Execute(rootCmd *cobra.Command, stdin io.Reader, stdout io.Writer, stderr i
 	runCmds.InitEnterprisePolicyRM(enterprisePolicyCmd)
 	runCmds.InitEnterprisePolicyAssign(enterprisePolicyCmd)
 	runCmds.InitEnterprisePolicyUnassign(enterprisePolicyCmd)
+	enterprisePolicyTestCmd := runCmds.InitEnterprisePolicyTest(enterprisePolicyCmd)
 	enterpriseInstallerCmd := runCmds.InitEnterpriseInstaller(enterpriseCmd)
 	runCmds.InitEnterpriseInstallerLS(enterpriseInstallerCmd)
 	runCmds.InitEnterpriseInstallerCreate(enterpriseInstallerCmd)
@@ -194,14 +236,32 @@ func Execute(rootCmd *cobra.Command, stdin io.Reader, stdout io.Writer, stderr i
 	runCmds.InitEnterpriseInstallerRM(enterpriseInstallerCmd)
 	runCmds.InitEnterpriseInstallerAssign(enterpriseInstallerCmd)
 
+	syncCmd := runCmds.InitSyncCommand(runCmds.rootCmd)
+
+	profileCmd := runCmds.InitProfileCommand(runCmds.rootCmd)
+	runCmds.InitProfileAdd(profileCmd)
+	runCmds.InitProfileUse(profileCmd)
+	runCmds.InitProfileList(profileCmd)
+	runCmds.InitProfileRM(profileCmd)
+
 	appCmd := runCmds.InitAppCommand(runCmds.rootCmd)
 	runCmds.InitAppList(appCmd)
 	runCmds.InitAppCreate(appCmd)
 	runCmds.InitAppDelete(appCmd)
 
+	runCmds.InitDevServer(runCmds.rootCmd)
+
 	runCmds.rootCmd.SetUsageTemplate(rootCmdUsageTmpl)
 
 	preRunSetupAPIs := func(_ *cobra.Command, _ []string) error {
+		if err := configureTransport(); err != nil {
+			return err
+		}
+
+		if err := applyProfile(); err != nil {
+			return errors.Wrap(err, "apply profile")
+		}
+
 		if apiToken == "" {
 			apiToken = os.Getenv("REPLICATED_API_TOKEN")
 			if apiToken == "" {
@@ -238,10 +298,13 @@ func Execute(rootCmd *cobra.Command, stdin io.Reader, stdout io.Writer, stderr i
 			appSlugOrID = os.Getenv("REPLICATED_APP")
 		}
 
-		app, appType, err := runCmds.api.GetAppType(appSlugOrID)
+		app, appType, cached, err := runCmds.resolveApp(cmd.Context(), appSlugOrID)
 		if err != nil {
 			return err
 		}
+		if cached {
+			runCmds.retryWithFreshApp(cmd, appSlugOrID)
+		}
 
 		runCmds.appType = appType
 
@@ -253,13 +316,82 @@ func Execute(rootCmd *cobra.Command, stdin io.Reader, stdout io.Writer, stderr i
 
 	channelCmd.PersistentPreRunE = prerunCommand
 	releaseCmd.PersistentPreRunE = prerunCommand
+	releaseLintCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		// the local linter and --list-files don't talk to the API, so they
+		// work without a token or app
+		if runCmds.args.lintReleaseEngine == "local" || runCmds.args.releaseFilesList {
+			return nil
+		}
+		return prerunCommand(cmd, args)
+	}
+	releaseRenderCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		// templates are rendered locally, so no token or app is needed
+		return nil
+	}
 	collectorsCmd.PersistentPreRunE = prerunCommand
 	entitlementsCmd.PersistentPreRunE = prerunCommand
 	customersCmd.PersistentPreRunE = prerunCommand
 	installerCmd.PersistentPreRunE = prerunCommand
+	syncCmd.PersistentPreRunE = prerunCommand
 	appCmd.PersistentPreRunE = preRunSetupAPIs
+	enterprisePolicyTestCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		// policies are evaluated locally, so no private key is needed
+		return nil
+	}
 
 	runCmds.rootCmd.AddCommand(Version())
 
-	return runCmds.rootCmd.Execute()
+	return executeRootCommand(ctx, runCmds.rootCmd)
+}
+
+// executeRootCommand runs the root command, printing errors and usage to the
+// command's output like cobra 1.0 did. Newer versions of cobra print them to
+// stderr, and add a completion command. Commands can still silence their
+// errors and usage.
+func executeRootCommand(ctx context.Context, rootCmd *cobra.Command) error {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+
+	rootSilenceErrors, rootSilenceUsage := rootCmd.SilenceErrors, rootCmd.SilenceUsage
+	rootCmd.SilenceErrors, rootCmd.SilenceUsage = true, true
+	defer func() {
+		rootCmd.SilenceErrors, rootCmd.SilenceUsage = rootSilenceErrors, rootSilenceUsage
+	}()
+
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+	if err == nil {
+		return nil
+	}
+	silenceErrors, silenceUsage := rootSilenceErrors, rootSilenceUsage
+	if cmd != rootCmd {
+		silenceErrors = silenceErrors || cmd.SilenceErrors
+		silenceUsage = silenceUsage || cmd.SilenceUsage
+	}
+
+	if !silenceErrors {
+		cmd.Println("Error:", err.Error())
+	}
+	// an unknown subcommand fails before any command runs
+	if cmd == rootCmd && strings.HasPrefix(err.Error(), "unknown command ") {
+		if !silenceErrors {
+			cmd.Printf("Run '%v --help' for usage.\n", cmd.CommandPath())
+		}
+		return err
+	}
+	if !silenceUsage {
+		cmd.Println(cmd.UsageString())
+	}
+	return err
+}
+
+// configureTransport applies the --timeout and --max-retries flags to the
+// HTTP client shared by all of the API clients
+func configureTransport() error {
+	if maxRetries < 0 {
+		return errors.Errorf("max-retries must not be negative, got %d", maxRetries)
+	}
+	if requestTimeout < 0 {
+		return errors.Errorf("timeout must not be negative, got %s", requestTimeout)
+	}
+	transport.Configure(requestTimeout, maxRetries)
+	return nil
 }
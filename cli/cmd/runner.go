@@ -44,25 +44,33 @@ type runnerArgs struct {
 	updateCollectorYaml     string
 	updateCollectorYamlFile string
 	updateCollectorName     string
+	migrateCollectorYamlDir string
+	migrateCollectorForce   bool
 
 	createReleaseYaml                 string
 	createReleaseYamlFile             string
 	createReleaseYamlDir              string
+	createReleaseHelmCharts           []string
+	createReleaseKustomize            []string
 	createReleaseConfigYaml           string
 	createReleaseDeploymentYaml       string
 	createReleaseServiceYaml          string
 	createReleasePreflightYaml        string
 	createReleaseSupportBundleYaml    string
-	createReleasePromote              string
+	createReleasePromote              []string
 	createReleasePromoteDir           string
 	createReleasePromoteRequired      bool
 	createReleasePromoteNotes         string
 	createReleasePromoteVersion       string
 	createReleasePromoteEnsureChannel bool
+	createReleaseWatch                bool
+	createReleaseWatchDebounce        time.Duration
 	// Add Create Release Lint
 	createReleaseLint     bool
 	lintReleaseYamlDir    string
 	lintReleaseFailOn     string
+	lintReleaseFormat     string
+	lintReleaseEngine     string
 	releaseOptional       bool
 	releaseNotes          string
 	releaseVersion        string
@@ -70,6 +78,22 @@ type runnerArgs struct {
 	updateReleaseYamlDir  string
 	updateReleaseYamlFile string
 
+	renderReleaseYamlDir      string
+	renderReleaseConfigValues string
+	renderReleaseLicense      string
+	renderReleaseNamespace    string
+	renderReleaseDest         string
+
+	// file selection for --yaml-dir, shared by release create, update, lint and render
+	releaseFilesInclude []string
+	releaseFilesExclude []string
+	releaseFilesList    bool
+
+	// release size checks, shared by release create and update
+	releaseSizeReport bool
+	releaseSizeWarn   string
+	releaseSizeLimit  string
+
 	entitlementsAPIServer                string
 	entitlementsVerbose                  bool
 	entitlementsDefineFieldsFile         string
@@ -83,10 +107,41 @@ type runnerArgs struct {
 	entitlementsSetValueValue            string
 	entitlementsSetValueType             string
 
+	channelRollbackTo int64
+
 	customerCreateName           string
 	customerCreateChannel        string
 	customerCreateEnsureChannel  bool
 	customerCreateExpiryDuration time.Duration
+	customerCreateType           string
+	customerCreateAirgap         bool
+	customerCreateGitops         bool
+	customerCreateSnapshot       bool
+
+	customerInspectCustomer string
+
+	customerUpdateCustomer       string
+	customerUpdateName           string
+	customerUpdateChannel        string
+	customerUpdateEnsureChannel  bool
+	customerUpdateExpiryDuration time.Duration
+	customerUpdateType           string
+	customerUpdateAirgap         bool
+	customerUpdateGitops         bool
+	customerUpdateSnapshot       bool
+
+	customerArchiveCustomer string
+	customerRmForce         bool
+
+	licenseInspectPublicKey     string
+	licenseInspectExpiryWarning time.Duration
+	licenseInspectStrict        bool
+
+	customerImportFile   string
+	customerImportDryRun bool
+
+	customerExportFile   string
+	customerExportFormat string
 
 	createInstallerYaml                 string
 	createInstallerYamlFile             string
@@ -94,6 +149,10 @@ type runnerArgs struct {
 	createInstallerPromoteEnsureChannel bool
 
 	enterpriseAuthInitCreateOrg string
+	enterpriseAuthInitEncrypt   bool
+
+	enterpriseAuthRotateEncrypt   bool
+	enterpriseAuthRotateRevokeOld bool
 
 	enterpriseAuthApproveFingerprint string
 
@@ -126,6 +185,12 @@ type runnerArgs struct {
 	enterprisePolicyUnassignPolicyID  string
 	enterprisePolicyUnassignChannelID string
 
+	enterprisePolicyTestFile     string
+	enterprisePolicyTestYamlDir  string
+	enterprisePolicyTestFixtures string
+	enterprisePolicyTestFailOn   string
+	enterprisePolicyTestFormat   string
+
 	enterpriseInstallerCreateFile string
 
 	enterpriseInstallerUpdateID   string
@@ -141,7 +206,23 @@ type runnerArgs struct {
 	createReleaseAutoDefaultsAccept      bool
 
 	releaseDownloadDest               string
+	releaseDiffYamlDir                string
 	createInstallerAutoDefaults       bool
 	createInstallerAutoDefaultsAccept bool
 	deleteAppForceYes                 bool
+
+	profileAddAPIOrigin        string
+	profileAddGraphQLOrigin    string
+	profileAddEnterpriseOrigin string
+	profileAddKurlOrigin       string
+
+	syncSpecFile string
+	syncDryRun   bool
+	syncConfirm  bool
+
+	devServerListen       string
+	devServerState        string
+	devServerKotsApps     []string
+	devServerPlatformApps []string
+	devServerShipApps     []string
 }
@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/replicatedhq/replicated/client"
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
+	"github.com/replicatedhq/replicated/pkg/platformclient"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/replicatedhq/replicated/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+const testAppID = "app-id"
+
+var testOriginRegex = regexp.MustCompile(`http://127\.0\.0\.1:\d+`)
+
+// stripOrigin removes the address of the test API from request errors
+func stripOrigin(s string) string {
+	return testOriginRegex.ReplaceAllString(s, "")
+}
+
+// newTestRunners returns runners for the kots app testAppID on a test server
+// that serves handler
+func newTestRunners(t *testing.T, handler http.Handler) *runners {
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	// the output format is a global flag
+	previousFormat := outputFormat
+	outputFormat = print.FormatTable
+	t.Cleanup(func() { outputFormat = previousFormat })
+
+	platformAPI := platformclient.NewHTTPClient(ts.URL, "token")
+	return &runners{
+		appID:       testAppID,
+		appSlug:     "my-app",
+		appType:     "kots",
+		api:         client.NewClient(ts.URL, ts.URL+"/graphql", "token", ""),
+		platformAPI: platformAPI,
+		kotsAPI:     &kotsclient.VendorV3Client{HTTPClient: *platformAPI},
+	}
+}
+
+// executeTestCommand runs the command that init adds to its parent with args,
+// and returns everything it printed
+func executeTestCommand(r *runners, init func(parent *cobra.Command), args ...string) (string, error) {
+	var out bytes.Buffer
+	r.w = tabwriter.NewWriter(&out, minWidth, tabWidth, padding, padChar, tabwriter.TabIndent)
+
+	parent := &cobra.Command{Use: "replicated", SilenceErrors: true, SilenceUsage: true}
+	init(parent)
+	parent.SetOut(&out)
+	parent.SetErr(&out)
+	parent.SetArgs(args)
+
+	err := parent.ExecuteContext(context.Background())
+	r.w.Flush()
+	return out.String(), err
+}
+
+var semverRegex = regexp.MustCompile(`^v?\d+\.\d+\.\d+`)
+
+// testVendorAPI serves the kots channel, release and customer endpoints of the
+// Vendor API for the app testAppID, from the channels and customers it holds
+type testVendorAPI struct {
+	mu        sync.Mutex
+	channels  []*types.KotsChannel
+	customers []*types.Customer
+	// promoted has the channel IDs of each promote request that succeeded
+	promoted [][]string
+	// summaryList leaves the type, expiry and features out of the customer
+	// list, so only a customer's own endpoint has them
+	summaryList bool
+}
+
+// newTestVendorAPI returns an API with the Stable, Beta and Unstable channels
+func newTestVendorAPI() *testVendorAPI {
+	return &testVendorAPI{
+		channels: []*types.KotsChannel{
+			{Id: "stable-id", Name: "Stable", ChannelSlug: "stable"},
+			{Id: "beta-id", Name: "Beta", ChannelSlug: "beta"},
+			{Id: "unstable-id", Name: "Unstable", ChannelSlug: "unstable"},
+		},
+	}
+}
+
+func (a *testVendorAPI) channelNames() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	names := []string{}
+	for _, channel := range a.channels {
+		names = append(names, channel.Name)
+	}
+	return names
+}
+
+func (a *testVendorAPI) customer(nameOrID string) *types.Customer {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, customer := range a.customers {
+		if customer.Name == nameOrID || customer.ID == nameOrID {
+			copied := *customer
+			return &copied
+		}
+	}
+	return nil
+}
+
+func (a *testVendorAPI) addCustomer(opts types.CustomerOptions) *types.Customer {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.createCustomer(opts)
+}
+
+func (a *testVendorAPI) createCustomer(opts types.CustomerOptions) *types.Customer {
+	customer := &types.Customer{ID: fmt.Sprintf("customer-%d", len(a.customers)+1)}
+	a.setCustomer(customer, opts)
+	a.customers = append(a.customers, customer)
+	return customer
+}
+
+func (a *testVendorAPI) setCustomer(customer *types.Customer, opts types.CustomerOptions) {
+	customer.Name = opts.Name
+	customer.Type = opts.Type
+	customer.Expires = nil
+	if opts.ExpiresAt != nil {
+		customer.Expires = &util.Time{Time: *opts.ExpiresAt}
+	}
+	customer.IsAirgapEnabled = opts.IsAirgapEnabled
+	customer.IsGitopsSupported = opts.IsGitopsSupported
+	customer.IsSnapshotSupported = opts.IsSnapshotSupported
+	customer.Channels = nil
+	for _, channel := range a.channels {
+		if channel.Id == opts.ChannelID {
+			customer.Channels = []types.Channel{{ID: channel.Id, Name: channel.Name}}
+		}
+	}
+}
+
+func (a *testVendorAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	appPath := "/v3/app/" + testAppID
+	path := r.URL.Path
+	switch {
+	case r.Method == "GET" && path == appPath+"/channels":
+		writeTestJSON(w, http.StatusOK, map[string]interface{}{"channels": a.channels})
+
+	case r.Method == "GET" && strings.HasPrefix(path, appPath+"/channel/"):
+		id := strings.TrimPrefix(path, appPath+"/channel/")
+		for _, channel := range a.channels {
+			if channel.Id == id {
+				writeTestJSON(w, http.StatusOK, map[string]interface{}{"channel": channel})
+				return
+			}
+		}
+		http.NotFound(w, r)
+
+	case r.Method == "POST" && path == appPath+"/channel":
+		var request types.CreateChannelRequest
+		if !readTestJSON(w, r, &request) {
+			return
+		}
+		channel := &types.KotsChannel{
+			Id:          fmt.Sprintf("channel-%d", len(a.channels)+1),
+			Name:        request.Name,
+			ChannelSlug: strings.ToLower(request.Name),
+		}
+		a.channels = append(a.channels, channel)
+		writeTestJSON(w, http.StatusCreated, map[string]interface{}{"channel": channel})
+
+	case r.Method == "POST" && strings.HasPrefix(path, appPath+"/release/") && strings.HasSuffix(path, "/promote"):
+		var request types.KotsPromoteReleaseRequest
+		if !readTestJSON(w, r, &request) {
+			return
+		}
+		for _, channelID := range request.ChannelIDs {
+			for _, channel := range a.channels {
+				if channel.Id == channelID && channel.SemverRequired && !semverRegex.MatchString(request.VersionLabel) {
+					message := fmt.Sprintf("channel %s requires a semantic version, got %q", channel.Name, request.VersionLabel)
+					writeTestJSON(w, http.StatusBadRequest, map[string]string{"message": message})
+					return
+				}
+			}
+		}
+		a.promoted = append(a.promoted, request.ChannelIDs)
+		w.WriteHeader(http.StatusOK)
+
+	case r.Method == "GET" && path == appPath+"/customers":
+		customers := []types.Customer{}
+		for _, customer := range a.customers {
+			if a.summaryList {
+				customers = append(customers, types.Customer{ID: customer.ID, Name: customer.Name, Channels: customer.Channels})
+				continue
+			}
+			customers = append(customers, *customer)
+		}
+		writeTestJSON(w, http.StatusOK, kotsclient.CustomerListResponse{Customers: customers, TotalCustomers: len(customers)})
+
+	case r.Method == "GET" && strings.HasPrefix(path, appPath+"/customer/"):
+		id := strings.TrimPrefix(path, appPath+"/customer/")
+		for _, customer := range a.customers {
+			if customer.ID == id {
+				writeTestJSON(w, http.StatusOK, kotsclient.GetCustomerResponse{Customer: customer})
+				return
+			}
+		}
+		http.NotFound(w, r)
+
+	case r.Method == "POST" && path == "/v3/customer":
+		var request kotsclient.CreateCustomerRequest
+		if !readTestJSON(w, r, &request) {
+			return
+		}
+		opts, ok := testCustomerOptions(w, request)
+		if !ok {
+			return
+		}
+		customer := a.createCustomer(opts)
+		writeTestJSON(w, http.StatusCreated, kotsclient.CreateCustomerResponse{Customer: customer})
+
+	case r.Method == "PUT" && strings.HasPrefix(path, "/v3/customer/"):
+		var request kotsclient.UpdateCustomerRequest
+		if !readTestJSON(w, r, &request) {
+			return
+		}
+		opts, ok := testCustomerOptions(w, request)
+		if !ok {
+			return
+		}
+		id := strings.TrimPrefix(path, "/v3/customer/")
+		for _, customer := range a.customers {
+			if customer.ID == id {
+				a.setCustomer(customer, opts)
+				writeTestJSON(w, http.StatusOK, kotsclient.CreateCustomerResponse{Customer: customer})
+				return
+			}
+		}
+		http.NotFound(w, r)
+
+	case r.Method == "POST" && strings.HasPrefix(path, "/v3/customer/"):
+		id, action := strings.TrimPrefix(path, "/v3/customer/"), ""
+		if i := strings.LastIndex(id, "/"); i >= 0 {
+			id, action = id[:i], id[i+1:]
+		}
+		for _, customer := range a.customers {
+			if customer.ID == id && (action == "archive" || action == "unarchive") {
+				customer.IsArchived = action == "archive"
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		http.NotFound(w, r)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// testCustomerOptions converts a customer request to the options it sets
+func testCustomerOptions(w http.ResponseWriter, request kotsclient.CreateCustomerRequest) (types.CustomerOptions, bool) {
+	opts := types.CustomerOptions{
+		Name:                request.Name,
+		ChannelID:           request.ChannelID,
+		Type:                request.Type,
+		IsAirgapEnabled:     request.IsAirgapEnabled,
+		IsGitopsSupported:   request.IsGitopsSupported,
+		IsSnapshotSupported: request.IsSnapshotSupported,
+	}
+	if request.ExpiresAt != "" {
+		expiresAt, err := util.ParseTime(request.ExpiresAt)
+		if err != nil {
+			writeTestJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+			return opts, false
+		}
+		opts.ExpiresAt = &expiresAt
+	}
+	return opts, true
+}
+
+func readTestJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeTestJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return false
+	}
+	return true
+}
+
+func writeTestJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/cli/print"
+	"github.com/replicatedhq/replicated/pkg/releasediff"
+	"github.com/replicatedhq/replicated/pkg/releasefiles"
+	"github.com/replicatedhq/replicated/pkg/syncplan"
+	"github.com/spf13/cobra"
+)
+
+func (r *runners) InitSyncCommand(parent *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Sync channels, releases and customers from a spec file",
+		Long: `Sync the app's channels, releases and customers with the desired state declared in a replicated.yaml spec.
+
+The current state of the app is compared against the spec and the resulting plan is printed.
+A release is created when its version is not on its channel, or when the files in its yamlDir
+differ from those of the channel's current release.
+Nothing is changed until the plan is confirmed, use --dry-run to only print the plan.
+
+  Example replicated.yaml:
+
+  channels:
+    - name: Beta
+      semver: true
+  releases:
+    - yamlDir: ./manifests
+      channel: Beta
+      version: 1.2.0
+      releaseNotes: Beta release
+  customers:
+    - name: dev-alice
+      channel: Beta
+      expiresIn: 720h`,
+		SilenceUsage: true,
+	}
+	parent.AddCommand(cmd)
+
+	cmd.Flags().StringVarP(&r.args.syncSpecFile, "file", "f", "replicated.yaml", "Path to the spec file")
+	cmd.Flags().BoolVar(&r.args.syncDryRun, "dry-run", false, "Print the plan without applying it")
+	cmd.Flags().BoolVarP(&r.args.syncConfirm, "confirm", "y", false, "Apply the plan without prompting for confirmation")
+
+	cmd.RunE = r.sync
+	return cmd
+}
+
+func (r *runners) sync(cmd *cobra.Command, _ []string) error {
+	spec, err := syncplan.ParseFile(r.args.syncSpecFile)
+	if err != nil {
+		return err
+	}
+
+	state, err := r.syncState(cmd.Context(), spec)
+	if err != nil {
+		return err
+	}
+
+	plan, err := syncplan.Compute(*spec, *state)
+	if err != nil {
+		return errors.Wrap(err, "compute plan")
+	}
+
+	for _, warning := range plan.Warnings {
+		fmt.Fprintf(r.w, "Warning: %s\n", warning)
+	}
+	if plan.IsEmpty() {
+		fmt.Fprintln(r.w, "No changes. Channels, releases and customers are up to date.")
+		return r.w.Flush()
+	}
+
+	for _, action := range plan.Actions {
+		fmt.Fprintf(r.w, "  %s\n", action)
+	}
+	fmt.Fprintf(r.w, "\n%s\n", plan.Summary())
+	if err := r.w.Flush(); err != nil {
+		return err
+	}
+
+	if r.args.syncDryRun {
+		return nil
+	}
+
+	if !r.args.syncConfirm {
+		confirmed, err := promptForApplyPlan()
+		if err != nil {
+			return err
+		}
+		if strings.ToLower(confirmed) != "y" {
+			return errors.New("plan declined")
+		}
+	}
+
+	log := print.NewLogger(r.w)
+	for _, action := range plan.Actions {
+		log.ActionWithSpinner("%s", strings.TrimLeft(action.String(), "+~ "))
+		if err := r.applySyncAction(cmd.Context(), action); err != nil {
+			log.FinishSpinnerWithError()
+			return errors.Wrapf(err, "apply %s", action.Type)
+		}
+		log.FinishSpinner()
+	}
+
+	return nil
+}
+
+// syncState fetches only the parts of the app the spec refers to
+func (r *runners) syncState(ctx context.Context, spec *syncplan.Spec) (*syncplan.State, error) {
+	state := syncplan.State{AppType: r.appType}
+
+	channels, err := r.api.ListChannelsContext(ctx, r.appID, r.appType, r.appSlug, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "list channels")
+	}
+	state.Channels = channels
+
+	for _, release := range spec.Releases {
+		if release.Sequence != 0 {
+			releases, err := r.api.ListReleasesContext(ctx, r.appID, r.appType)
+			if err != nil {
+				return nil, errors.Wrap(err, "list releases")
+			}
+			state.Releases = releases
+			break
+		}
+	}
+
+	// a release whose version label is already on its channel is only up to
+	// date if its files are too
+	if r.appType == "kots" {
+		state.ChannelFiles = map[string][]releasediff.File{}
+		state.YAMLDirFiles = map[string][]releasediff.File{}
+		for _, release := range spec.Releases {
+			if release.YAMLDir == "" {
+				continue
+			}
+			for _, channel := range channels {
+				if channel.Name != release.Channel || channel.ReleaseLabel != release.Version || channel.ReleaseSequence == 0 {
+					continue
+				}
+				if _, ok := state.ChannelFiles[channel.Name]; !ok {
+					current, err := r.api.GetReleaseContext(ctx, r.appID, r.appType, channel.ReleaseSequence)
+					if err != nil {
+						return nil, errors.Wrapf(err, "get release %d", channel.ReleaseSequence)
+					}
+					files, err := kotsReleaseFiles(current.Config)
+					if err != nil {
+						return nil, errors.Wrapf(err, "release %d", channel.ReleaseSequence)
+					}
+					state.ChannelFiles[channel.Name] = files
+				}
+				if _, ok := state.YAMLDirFiles[release.YAMLDir]; !ok {
+					releaseYAML, err := readYAMLDir(release.YAMLDir, releasefiles.Options{})
+					if err != nil {
+						return nil, errors.Wrapf(err, "read yaml dir %s", release.YAMLDir)
+					}
+					files, err := kotsReleaseFiles(releaseYAML)
+					if err != nil {
+						return nil, err
+					}
+					state.YAMLDirFiles[release.YAMLDir] = files
+				}
+			}
+		}
+	}
+
+	if len(spec.Customers) > 0 {
+		customers, err := r.api.ListCustomersContext(ctx, r.appID, r.appType)
+		if err != nil {
+			return nil, errors.Wrap(err, "list customers")
+		}
+		state.Customers = customers
+	}
+
+	return &state, nil
+}
+
+func (r *runners) applySyncAction(ctx context.Context, action syncplan.Action) error {
+	switch action.Type {
+	case syncplan.ActionCreateChannel:
+		if _, err := r.api.CreateChannelContext(ctx, r.appID, r.appType, r.appSlug, action.Channel.Name, action.Channel.Description); err != nil {
+			return err
+		}
+		if action.Channel.Semver == nil || !*action.Channel.Semver {
+			return nil
+		}
+		return r.syncChannelSemver(ctx, action.Channel)
+
+	case syncplan.ActionUpdateChannelSemver:
+		return r.syncChannelSemver(ctx, action.Channel)
+
+	case syncplan.ActionCreateRelease:
+		releaseYAML, err := readYAMLDir(action.Release.YAMLDir, releasefiles.Options{})
+		if err != nil {
+			return errors.Wrapf(err, "read yaml dir %s", action.Release.YAMLDir)
+		}
+		release, err := r.api.CreateReleaseContext(ctx, r.appID, r.appType, releaseYAML)
+		if err != nil {
+			return errors.Wrap(err, "create release")
+		}
+		return r.syncPromote(ctx, release.Sequence, action.Release)
+
+	case syncplan.ActionPromoteRelease:
+		return r.syncPromote(ctx, action.Release.Sequence, action.Release)
+
+	case syncplan.ActionCreateCustomer:
+		channel, err := r.api.GetChannelByNameContext(ctx, r.appID, r.appType, r.appSlug, action.Customer.Channel)
+		if err != nil {
+			return errors.Wrapf(err, "get channel %q", action.Customer.Channel)
+		}
+		var expiresIn time.Duration
+		if action.Customer.ExpiresIn != "" {
+			// validated when the spec was parsed
+			expiresIn, _ = time.ParseDuration(action.Customer.ExpiresIn)
+		}
+		_, err = r.api.CreateCustomerContext(ctx, r.appID, r.appType, action.Customer.Name, channel.ID, expiresIn)
+		return err
+	}
+
+	return errors.Errorf("unknown action %q", action.Type)
+}
+
+func (r *runners) syncChannelSemver(ctx context.Context, channelSpec syncplan.ChannelSpec) error {
+	channel, err := r.api.GetChannelByNameContext(ctx, r.appID, r.appType, r.appSlug, channelSpec.Name)
+	if err != nil {
+		return errors.Wrapf(err, "get channel %q", channelSpec.Name)
+	}
+	return r.api.UpdateSemanticVersioningForChannelContext(ctx, r.appType, r.appID, channel.ID, *channelSpec.Semver)
+}
+
+func (r *runners) syncPromote(ctx context.Context, sequence int64, release syncplan.ReleaseSpec) error {
+	channel, err := r.api.GetChannelByNameContext(ctx, r.appID, r.appType, r.appSlug, release.Channel)
+	if err != nil {
+		return errors.Wrapf(err, "get channel %q", release.Channel)
+	}
+	return r.api.PromoteReleaseContext(ctx, r.appID, r.appType, sequence, release.Version, release.ReleaseNotes, false, channel.ID)
+}
+
+func promptForApplyPlan() (string, error) {
+	prompt := promptui.Prompt{
+		Label:     "Apply this plan? [y/N]",
+		Templates: templates,
+		Default:   "n",
+		Validate: func(input string) error {
+			switch strings.ToLower(input) {
+			case "y", "n":
+				return nil
+			default:
+				return errors.New(`please choose "y" or "n"`)
+			}
+		},
+	}
+
+	for {
+		result, err := prompt.Run()
+		if err != nil {
+			if err == promptui.ErrInterrupt {
+				return "", errors.New("interrupted")
+			}
+			continue
+		}
+
+		return result, nil
+	}
+}
@@ -1,13 +1,20 @@
 package main
 
 import (
+	"context"
 	"os"
+	"os/signal"
 
 	"github.com/replicatedhq/replicated/cli/cmd"
 )
 
 func main() {
-	if err := cmd.Execute(nil, os.Stdin, os.Stdout, os.Stderr); err != nil {
+	// cancel in-flight API requests on ctrl-c
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := cmd.ExecuteContext(ctx, nil, os.Stdin, os.Stdout, os.Stderr); err != nil {
+		stop()
 		os.Exit(1)
 	}
 }
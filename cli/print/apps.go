@@ -14,7 +14,11 @@ var appsTmplSrc = `ID	NAME	SLUG	SCHEDULER
 
 var appsTmpl = template.Must(template.New("apps").Funcs(funcs).Parse(appsTmplSrc))
 
-func Apps(w *tabwriter.Writer, apps []types.AppAndChannels) error {
+func Apps(outputFormat string, w *tabwriter.Writer, apps []types.AppAndChannels) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, apps)
+	}
+
 	var as []*types.App
 
 	for _, a := range apps {
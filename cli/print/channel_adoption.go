@@ -28,7 +28,11 @@ type licenseAdoption struct {
 	Other    allActiveCounts
 }
 
-func ChannelAdoption(w *tabwriter.Writer, adoption *channels.ChannelAdoption) error {
+func ChannelAdoption(outputFormat string, w *tabwriter.Writer, adoption *channels.ChannelAdoption) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, adoption)
+	}
+
 	countsByLicense := make(map[string]*licenseAdoption)
 
 	var getOrSetLicenseAdoption = func(licenseType string) *licenseAdoption {
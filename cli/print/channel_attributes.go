@@ -27,7 +27,11 @@ AIRGAP:
 
 var channelAttrsTmpl = template.Must(template.New("ChannelAttributes").Parse(channelAttrsTmplSrc))
 
-func ChannelAttrs(w *tabwriter.Writer, appChan *types.Channel) error {
+func ChannelAttrs(outputFormat string, w *tabwriter.Writer, appChan *types.Channel) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, appChan)
+	}
+
 	if err := channelAttrsTmpl.Execute(w, appChan); err != nil {
 		return err
 	}
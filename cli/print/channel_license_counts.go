@@ -20,7 +20,11 @@ type licenseTypeCounts struct {
 	Active, Airgap, Inactive, Total int64
 }
 
-func LicenseCounts(w *tabwriter.Writer, counts *channels.LicenseCounts) error {
+func LicenseCounts(outputFormat string, w *tabwriter.Writer, counts *channels.LicenseCounts) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, counts)
+	}
+
 	countsByLicenseType := make(map[string]*licenseTypeCounts)
 
 	var getOrSetLicenseCounts = func(licenseType string) *licenseTypeCounts {
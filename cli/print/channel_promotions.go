@@ -0,0 +1,25 @@
+package print
+
+import (
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/replicatedhq/replicated/pkg/types"
+)
+
+var channelPromotionsTmplSrc = `{{ range . -}}
+{{ if .Promoted }}Channel {{ .Channel }} successfully set to release {{ .Sequence }}{{ else if .Error }}Channel {{ .Channel }} not set to release {{ .Sequence }}: {{ .Error }}{{ else }}Channel {{ .Channel }} not set to release {{ .Sequence }}{{ end }}
+{{ end }}`
+
+var channelPromotionsTmpl = template.Must(template.New("channel-promotions").Parse(channelPromotionsTmplSrc))
+
+func ChannelPromotions(outputFormat string, w *tabwriter.Writer, promotions []types.ChannelPromotion) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, promotions)
+	}
+
+	if err := channelPromotionsTmpl.Execute(w, promotions); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -15,7 +15,11 @@ var channelReleasesTmplSrc = `CHANNEL_SEQUENCE	RELEASE_SEQUENCE	RELEASED	VERSION
 
 var channelReleasesTmpl = template.Must(template.New("ChannelReleases").Funcs(funcs).Parse(channelReleasesTmplSrc))
 
-func ChannelReleases(w *tabwriter.Writer, releases []channels.ChannelRelease) error {
+func ChannelReleases(outputFormat string, w *tabwriter.Writer, releases []channels.ChannelRelease) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, releases)
+	}
+
 	if len(releases) == 0 {
 		if _, err := fmt.Fprintln(w, "No releases in channel"); err != nil {
 			return err
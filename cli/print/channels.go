@@ -15,14 +15,22 @@ var channelsTmplSrc = `ID	NAME	RELEASE	VERSION
 
 var channelsTmpl = template.Must(template.New("channels").Parse(channelsTmplSrc))
 
-func Channels(w *tabwriter.Writer, channels []types.Channel) error {
+func Channels(outputFormat string, w *tabwriter.Writer, channels []types.Channel) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, channels)
+	}
+
 	if err := channelsTmpl.Execute(w, channels); err != nil {
 		return err
 	}
 	return w.Flush()
 }
 
-func PlatformChannels(w *tabwriter.Writer, channels []platformChannels.AppChannel) error {
+func PlatformChannels(outputFormat string, w *tabwriter.Writer, channels []platformChannels.AppChannel) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, channels)
+	}
+
 	if err := channelsTmpl.Execute(w, channels); err != nil {
 		return err
 	}
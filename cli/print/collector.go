@@ -15,7 +15,11 @@ CONFIG:
 
 var collectorTmpl = template.Must(template.New("Collector").Funcs(funcs).Parse(collectorTmplSrc))
 
-func Collector(w *tabwriter.Writer, collector *collectors.AppCollectorInfo) error {
+func Collector(outputFormat string, w *tabwriter.Writer, collector *collectors.AppCollectorInfo) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, collector)
+	}
+
 	if err := collectorTmpl.Execute(w, collector); err != nil {
 		return err
 	}
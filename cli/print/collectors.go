@@ -15,7 +15,11 @@ var collectorsTmplSrc = `NAME	CREATED	 SPEC_ID 	ACTIVE_CHANNELS
 
 var collectorsTmpl = template.Must(template.New("Collectors").Funcs(funcs).Parse(collectorsTmplSrc))
 
-func Collectors(w *tabwriter.Writer, appCollectors []types.CollectorInfo) error {
+func Collectors(outputFormat string, w *tabwriter.Writer, appCollectors []types.CollectorInfo) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, appCollectors)
+	}
+
 	rs := make([]map[string]interface{}, len(appCollectors))
 
 	for i, r := range appCollectors {
@@ -0,0 +1,32 @@
+package print
+
+import (
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/replicatedhq/replicated/pkg/types"
+)
+
+var customerTmplSrc = `ID:	{{ .ID }}
+NAME:	{{ .Name }}
+CHANNELS:	{{ range $i, $c := .Channels }}{{ if $i }}, {{ end }}{{ $c.Name }}{{ end }}
+TYPE:	{{ .Type }}
+EXPIRES:	{{ if not .Expires }}Never{{ else if .Expires.IsZero }}Never{{ else }}{{ .Expires }}{{ end }}
+AIRGAP:	{{ .IsAirgapEnabled }}
+GITOPS:	{{ .IsGitopsSupported }}
+SNAPSHOTS:	{{ .IsSnapshotSupported }}
+ARCHIVED:	{{ .IsArchived }}
+`
+
+var customerTmpl = template.Must(template.New("customer").Parse(customerTmplSrc))
+
+func Customer(outputFormat string, w *tabwriter.Writer, customer *types.Customer) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, customer)
+	}
+
+	if err := customerTmpl.Execute(w, customer); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,26 @@
+package print
+
+import (
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/replicatedhq/replicated/pkg/customerimport"
+)
+
+var customerImportTmplSrc = `ROW	NAME	ACTION	RESULT
+{{ range . -}}
+{{ .Row }}	{{ .Name }}	{{ .Action }}	{{ if .Error }}failed: {{ .Error }}{{ else if .DryRun }}dry run{{ else }}ok{{ end }}
+{{ end }}`
+
+var customerImportTmpl = template.Must(template.New("customer-import").Parse(customerImportTmplSrc))
+
+func CustomerImportResults(outputFormat string, w *tabwriter.Writer, results []customerimport.Result) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, results)
+	}
+
+	if err := customerImportTmpl.Execute(w, results); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -9,12 +9,16 @@ import (
 
 var customersTmplSrc = `ID	NAME	CHANNELS	EXPIRES	TYPE
 {{ range . -}}
-{{ .ID }}	{{ .Name }}	{{range .Channels}} {{.Name}}{{end}}	{{if not .Expires}}Never{{else}}{{.Expires}}{{end}}	{{.Type}}
+{{ .ID }}	{{ .Name }}	{{range .Channels}} {{.Name}}{{end}}	{{if not .Expires}}Never{{else if .Expires.IsZero}}Never{{else}}{{.Expires}}{{end}}	{{.Type}}
 {{ end }}`
 
 var customersTmpl = template.Must(template.New("channels").Parse(customersTmplSrc))
 
-func Customers(w *tabwriter.Writer, customers []types.Customer) error {
+func Customers(outputFormat string, w *tabwriter.Writer, customers []types.Customer) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, customers)
+	}
+
 	if err := customersTmpl.Execute(w, customers); err != nil {
 		return err
 	}
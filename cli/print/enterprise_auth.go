@@ -0,0 +1,44 @@
+package print
+
+import (
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/replicatedhq/replicated/pkg/enterprisetypes"
+)
+
+var enterpriseAuthKeysTmplSrc = `FINGERPRINT	STATUS	CREATED	LAST USED
+{{ range . -}}
+{{ .Fingerprint }}	{{ .Status }}	{{ time .CreatedAt }}	{{ if .LastUsedAt }}{{ time .LastUsedAt }}{{ else }}Never{{ end }}
+{{ end }}`
+
+var enterpriseAuthKeysTmpl = template.Must(template.New("enterpriseauthkeys").Funcs(funcs).Parse(enterpriseAuthKeysTmplSrc))
+
+func EnterpriseAuthKeys(outputFormat string, w *tabwriter.Writer, authKeys []*enterprisetypes.AuthKey) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, authKeys)
+	}
+
+	if err := enterpriseAuthKeysTmpl.Execute(w, authKeys); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+var enterpriseAuthIdentityTmplSrc = `FINGERPRINT:	{{ .Fingerprint }}
+KEY TYPE:	{{ .KeyType }}
+STATUS:	{{ .Status }}{{ if .StatusError }} ({{ .StatusError }}){{ end }}
+`
+
+var enterpriseAuthIdentityTmpl = template.Must(template.New("enterpriseauthidentity").Parse(enterpriseAuthIdentityTmplSrc))
+
+func EnterpriseAuthIdentity(outputFormat string, w *tabwriter.Writer, identity *enterprisetypes.AuthIdentity) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, identity)
+	}
+
+	if err := enterpriseAuthIdentityTmpl.Execute(w, identity); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -13,7 +13,11 @@ var enterpriseChannelTmplSrc = `ID	NAME
 
 var enterpriseChannelTmpl = template.Must(template.New("enterprisechannel").Parse(enterpriseChannelTmplSrc))
 
-func EnterpriseChannel(w *tabwriter.Writer, channel *enterprisetypes.Channel) error {
+func EnterpriseChannel(outputFormat string, w *tabwriter.Writer, channel *enterprisetypes.Channel) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, channel)
+	}
+
 	if err := enterpriseChannelTmpl.Execute(w, channel); err != nil {
 		return err
 	}
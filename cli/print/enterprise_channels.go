@@ -14,7 +14,11 @@ var enterpriseChannelsTmplSrc = `ID	NAME
 
 var enterpriseChannelsTmpl = template.Must(template.New("enterprisechannels").Parse(enterpriseChannelsTmplSrc))
 
-func EnterpriseChannels(w *tabwriter.Writer, channels []*enterprisetypes.Channel) error {
+func EnterpriseChannels(outputFormat string, w *tabwriter.Writer, channels []*enterprisetypes.Channel) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, channels)
+	}
+
 	if err := enterpriseChannelsTmpl.Execute(w, channels); err != nil {
 		return err
 	}
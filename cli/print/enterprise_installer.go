@@ -13,7 +13,11 @@ var enterpriseInstallerTmplSrc = `ID
 
 var enterpriseInstallerTmpl = template.Must(template.New("enterpriseinstaller").Parse(enterpriseInstallerTmplSrc))
 
-func EnterpriseInstaller(w *tabwriter.Writer, installer *enterprisetypes.Installer) error {
+func EnterpriseInstaller(outputFormat string, w *tabwriter.Writer, installer *enterprisetypes.Installer) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, installer)
+	}
+
 	if err := enterpriseInstallerTmpl.Execute(w, installer); err != nil {
 		return err
 	}
@@ -14,7 +14,11 @@ var enterpriseInstallersTmplSrc = `ID
 
 var enterpriseInstallersTmpl = template.Must(template.New("enterpriseinstallers").Parse(enterpriseInstallersTmplSrc))
 
-func EnterpriseInstallers(w *tabwriter.Writer, installers []*enterprisetypes.Installer) error {
+func EnterpriseInstallers(outputFormat string, w *tabwriter.Writer, installers []*enterprisetypes.Installer) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, installers)
+	}
+
 	if err := enterpriseInstallersTmpl.Execute(w, installers); err != nil {
 		return err
 	}
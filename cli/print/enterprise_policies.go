@@ -14,7 +14,11 @@ var enterprisePoliciesTmplSrc = `ID	NAME
 
 var enterprisePoliciesTmpl = template.Must(template.New("enterprisepolicies").Parse(enterprisePoliciesTmplSrc))
 
-func EnterprisePolicies(w *tabwriter.Writer, policies []*enterprisetypes.Policy) error {
+func EnterprisePolicies(outputFormat string, w *tabwriter.Writer, policies []*enterprisetypes.Policy) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, policies)
+	}
+
 	if err := enterprisePoliciesTmpl.Execute(w, policies); err != nil {
 		return err
 	}
@@ -13,7 +13,11 @@ var enterprisePolicyTmplSrc = `ID	NAME
 
 var enterprisePolicyTmpl = template.Must(template.New("entrerprisepolicy").Parse(enterprisePolicyTmplSrc))
 
-func EnterprisePolicy(w *tabwriter.Writer, policy *enterprisetypes.Policy) error {
+func EnterprisePolicy(outputFormat string, w *tabwriter.Writer, policy *enterprisetypes.Policy) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, policy)
+	}
+
 	if err := enterprisePolicyTmpl.Execute(w, policy); err != nil {
 		return err
 	}
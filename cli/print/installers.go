@@ -14,7 +14,11 @@ var installersTmplSrc = `SEQUENCE	CREATED	ACTIVE_CHANNELS
 
 var installersTmpl = template.Must(template.New("Installers").Funcs(funcs).Parse(installersTmplSrc))
 
-func Installers(w *tabwriter.Writer, appReleases []types.InstallerSpec) error {
+func Installers(outputFormat string, w *tabwriter.Writer, appReleases []types.InstallerSpec) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, appReleases)
+	}
+
 	rs := make([]map[string]interface{}, len(appReleases))
 
 	for i, r := range appReleases {
@@ -0,0 +1,49 @@
+package print
+
+import (
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/replicatedhq/replicated/pkg/license"
+)
+
+var licenseTmplSrc = `{{ with .License.Spec -}}
+LICENSE ID:	{{ .LicenseID }}
+APP:	{{ .AppSlug }}
+CUSTOMER:	{{ .CustomerName }}{{ if .CustomerEmail }} <{{ .CustomerEmail }}>{{ end }}
+CHANNEL:	{{ .ChannelName }}
+TYPE:	{{ .LicenseType }}
+{{ end -}}
+EXPIRES:	{{ if .ExpiresAt }}{{ time .ExpiresAt }} ({{ .ExpiryStatus }}){{ else }}Never{{ end }}
+SIGNATURE:	{{ with .Signature }}{{ if .Valid }}valid{{ else if .DataVerified }}not verified, signing key not checked{{ else }}invalid: {{ .Error }}{{ end }}{{ end }}
+{{ if .Signature.Modified -}}
+WARNING:	the license file has been edited, the values shown are the signed ones
+{{ end -}}
+{{ with .License.Spec -}}
+AIRGAP:	{{ .IsAirgapSupported }}
+GITOPS:	{{ .IsGitOpsSupported }}
+SNAPSHOTS:	{{ .IsSnapshotSupported }}
+SUPPORT BUNDLE UPLOAD:	{{ .IsSupportBundleUploadSupported }}
+{{ if .Entitlements }}
+ENTITLEMENT	TITLE	VALUE
+{{ range $name, $entitlement := .Entitlements -}}
+{{ if ne $name "expires_at" -}}
+{{ $name }}	{{ $entitlement.Title }}	{{ $entitlement.Value }}
+{{ end -}}
+{{ end -}}
+{{ end -}}
+{{ end -}}
+`
+
+var licenseTmpl = template.Must(template.New("license").Funcs(funcs).Parse(licenseTmplSrc))
+
+func License(outputFormat string, w *tabwriter.Writer, inspection *license.Inspection) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, inspection)
+	}
+
+	if err := licenseTmpl.Execute(w, inspection); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -14,7 +14,11 @@ var lintTmplSrc = `RULE	TYPE	FILENAME	LINE	MESSAGE
 
 var lintTmpl = template.Must(template.New("lint").Parse(lintTmplSrc))
 
-func LintErrors(w *tabwriter.Writer, lintErrors []types.LintMessage) error {
+func LintErrors(outputFormat string, w *tabwriter.Writer, lintErrors []types.LintMessage) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, lintErrors)
+	}
+
 	if err := lintTmpl.Execute(w, lintErrors); err != nil {
 		return err
 	}
@@ -0,0 +1,287 @@
+package print
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/types"
+)
+
+// Supported values for the lint --format flag, in addition to the --output formats
+const (
+	LintFormatSARIF  = "sarif"
+	LintFormatJUnit  = "junit"
+	LintFormatGitHub = "github"
+)
+
+// LintReport writes lintErrors in one of the CI report formats. Paths in the
+// report are joined with baseDir so they are relative to the working directory
+// the linter ran in, which is what code scanning and test reporters expect.
+func LintReport(format string, w io.Writer, baseDir string, lintErrors []types.LintMessage) error {
+	switch format {
+	case LintFormatSARIF:
+		return lintSARIF(w, baseDir, lintErrors)
+	case LintFormatJUnit:
+		return lintJUnit(w, baseDir, lintErrors)
+	case LintFormatGitHub:
+		return lintGitHub(w, baseDir, lintErrors)
+	}
+	return errors.Errorf("lint format %q not supported", format)
+}
+
+// lintReportPath returns baseDir itself for messages that apply to the whole
+// release rather than a single file, such as a missing spec
+func lintReportPath(baseDir string, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(filepath.Join(baseDir, path))
+}
+
+func lintStart(msg types.LintMessage) (types.LintLinePosition, types.LintLinePosition, bool) {
+	if len(msg.Positions) == 0 || msg.Positions[0] == nil {
+		return types.LintLinePosition{}, types.LintLinePosition{}, false
+	}
+	return msg.Positions[0].Start, msg.Positions[0].End, msg.Positions[0].Start.Line > 0
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int64 `json:"startLine"`
+	StartColumn int64 `json:"startColumn,omitempty"`
+	EndLine     int64 `json:"endLine,omitempty"`
+	EndColumn   int64 `json:"endColumn,omitempty"`
+}
+
+func sarifLevel(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "warn":
+		return "warning"
+	}
+	return "note"
+}
+
+func lintSARIF(w io.Writer, baseDir string, lintErrors []types.LintMessage) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "replicated-lint",
+				InformationURI: "https://github.com/replicatedhq/replicated",
+				Rules:          []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	ruleIDs := map[string]bool{}
+	for _, msg := range lintErrors {
+		ruleIDs[msg.Rule] = true
+
+		location := sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: lintReportPath(baseDir, msg.Path)},
+			},
+		}
+		if start, end, ok := lintStart(msg); ok {
+			region := &sarifRegion{StartLine: start.Line, StartColumn: start.Column}
+			if end.Line >= start.Line {
+				region.EndLine = end.Line
+				region.EndColumn = end.Column
+			}
+			location.PhysicalLocation.Region = region
+		}
+
+		result := sarifResult{
+			RuleID:    msg.Rule,
+			Level:     sarifLevel(msg.Type),
+			Message:   sarifMessage{Text: msg.Message},
+			Locations: []sarifLocation{location},
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	for id := range ruleIDs {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: id})
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool {
+		return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID
+	})
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// lintJUnit writes a test case per lint message. Errors and warnings are
+// failures, info messages are passing test cases so they still show up in
+// the report without failing the build.
+func lintJUnit(w io.Writer, baseDir string, lintErrors []types.LintMessage) error {
+	suite := junitTestSuite{Name: "replicated-lint"}
+
+	for _, msg := range lintErrors {
+		location := lintReportPath(baseDir, msg.Path)
+		if start, _, ok := lintStart(msg); ok {
+			location = fmt.Sprintf("%s:%d", location, start.Line)
+		}
+
+		testCase := junitTestCase{
+			Name:      fmt.Sprintf("%s %s", msg.Rule, location),
+			ClassName: lintReportPath(baseDir, msg.Path),
+		}
+		if msg.Type == "error" || msg.Type == "warn" {
+			testCase.Failure = &junitFailure{
+				Message: msg.Message,
+				Type:    msg.Type,
+				Body:    fmt.Sprintf("%s: %s (%s)", location, msg.Message, msg.Rule),
+			}
+			suite.Failures++
+		} else {
+			testCase.SystemOut = msg.Message
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	if len(suite.Cases) == 0 {
+		suite.Cases = append(suite.Cases, junitTestCase{Name: "lint", ClassName: filepath.ToSlash(baseDir)})
+	}
+	suite.Tests = len(suite.Cases)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+var (
+	githubDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+func githubCommand(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "warn":
+		return "warning"
+	}
+	return "notice"
+}
+
+// lintGitHub writes GitHub Actions workflow commands, which are shown as
+// annotations on the pull request
+func lintGitHub(w io.Writer, baseDir string, lintErrors []types.LintMessage) error {
+	for _, msg := range lintErrors {
+		properties := []string{"file=" + githubPropertyEscaper.Replace(lintReportPath(baseDir, msg.Path))}
+		if start, end, ok := lintStart(msg); ok {
+			properties = append(properties, fmt.Sprintf("line=%d", start.Line))
+			if start.Column > 0 {
+				properties = append(properties, fmt.Sprintf("col=%d", start.Column))
+			}
+			if end.Line >= start.Line {
+				properties = append(properties, fmt.Sprintf("endLine=%d", end.Line))
+				if end.Column > 0 {
+					properties = append(properties, fmt.Sprintf("endColumn=%d", end.Column))
+				}
+			}
+		}
+		properties = append(properties, "title="+githubPropertyEscaper.Replace(msg.Rule))
+
+		if _, err := fmt.Fprintf(w, "::%s %s::%s\n", githubCommand(msg.Type), strings.Join(properties, ","), githubDataEscaper.Replace(msg.Message)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
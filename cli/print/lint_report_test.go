@@ -0,0 +1,107 @@
+package print
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func lintPosition(line, column, endLine, endColumn int64) []*types.LintPosition {
+	return []*types.LintPosition{{
+		Start: types.LintLinePosition{Line: line, Column: column},
+		End:   types.LintLinePosition{Line: endLine, Column: endColumn},
+	}}
+}
+
+var lintReportMessages = []types.LintMessage{
+	{
+		Rule:      "invalid-yaml",
+		Type:      "error",
+		Path:      "deployment.yaml",
+		Message:   "yaml: line 4: mapping values are not allowed in this context",
+		Positions: lintPosition(4, 7, 4, 12),
+	},
+	{
+		Rule:      "container-resources",
+		Type:      "warn",
+		Path:      "charts/app, v2/values.yaml",
+		Message:   "Missing container resources:\n\tlimits and requests",
+		Positions: lintPosition(12, 0, 0, 0),
+	},
+	{
+		Rule:    "preflight-spec",
+		Type:    "info",
+		Path:    "",
+		Message: "Missing preflight spec, 100% of checks skipped",
+	},
+}
+
+func TestLintReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		messages []types.LintMessage
+		golden   string
+	}{
+		{
+			name:     "sarif",
+			format:   LintFormatSARIF,
+			messages: lintReportMessages,
+			golden:   "lint_report.sarif",
+		},
+		{
+			name:     "sarif without messages",
+			format:   LintFormatSARIF,
+			messages: nil,
+			golden:   "lint_report_empty.sarif",
+		},
+		{
+			name:     "junit",
+			format:   LintFormatJUnit,
+			messages: lintReportMessages,
+			golden:   "lint_report.junit.xml",
+		},
+		{
+			name:     "junit without messages",
+			format:   LintFormatJUnit,
+			messages: nil,
+			golden:   "lint_report_empty.junit.xml",
+		},
+		{
+			name:     "github",
+			format:   LintFormatGitHub,
+			messages: lintReportMessages,
+			golden:   "lint_report.github",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			var out bytes.Buffer
+			err := LintReport(test.format, &out, "manifests", test.messages)
+			req.NoError(err)
+
+			golden := filepath.Join("testdata", test.golden)
+			if *update {
+				req.NoError(ioutil.WriteFile(golden, out.Bytes(), 0644))
+			}
+			expected, err := ioutil.ReadFile(golden)
+			req.NoError(err)
+			req.Equal(string(expected), out.String())
+		})
+	}
+}
+
+func TestLintReportUnsupportedFormat(t *testing.T) {
+	err := LintReport("html", &bytes.Buffer{}, "manifests", lintReportMessages)
+	require.EqualError(t, err, `lint format "html" not supported`)
+}
@@ -0,0 +1,63 @@
+package print
+
+import (
+	"encoding/json"
+	"reflect"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Supported values for the --output flag
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+)
+
+// ValidateOutputFormat returns an error for any output format other than
+// table, json or yaml
+func ValidateOutputFormat(outputFormat string) error {
+	switch outputFormat {
+	case FormatTable, FormatJSON, FormatYAML:
+		return nil
+	}
+	return errors.Errorf("output format %q not supported, supported values are [table, json, yaml]", outputFormat)
+}
+
+// structured writes v as JSON or YAML. Field names come from the json tags on
+// the types, so both formats use the same keys.
+func structured(outputFormat string, w *tabwriter.Writer, v interface{}) error {
+	if err := ValidateOutputFormat(outputFormat); err != nil {
+		return err
+	}
+
+	// empty lists print as [] rather than null
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && rv.IsNil() {
+		v = []interface{}{}
+	}
+
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal json")
+	}
+
+	if outputFormat == FormatYAML {
+		var generic interface{}
+		if err := yaml.Unmarshal(b, &generic); err != nil {
+			return errors.Wrap(err, "convert json to yaml")
+		}
+		b, err = yaml.Marshal(generic)
+		if err != nil {
+			return errors.Wrap(err, "marshal yaml")
+		}
+	} else {
+		b = append(b, '\n')
+	}
+
+	if _, err := w.Write(b); err != nil {
+		return err
+	}
+	return w.Flush()
+}
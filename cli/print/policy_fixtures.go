@@ -0,0 +1,28 @@
+package print
+
+import (
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/replicatedhq/replicated/pkg/policy"
+)
+
+var policyFixturesTmplSrc = `FIXTURE	RESULT
+{{ range . -}}
+{{ .Name }}	{{ if .Error }}error: {{ .Error }}{{ else if .Passed }}pass{{ else }}fail{{ end }}
+{{ range .Missing }} 	missing: {{ .Type }} {{ .Path }}{{ if .Line }}:{{ .Line }}{{ end }} {{ .Message }}
+{{ end }}{{ range .Unexpected }} 	unexpected: {{ .Type }} {{ .Path }}{{ if .Line }}:{{ .Line }}{{ end }} {{ .Message }}
+{{ end }}{{ end }}`
+
+var policyFixturesTmpl = template.Must(template.New("policy-fixtures").Parse(policyFixturesTmplSrc))
+
+func PolicyFixtureResults(outputFormat string, w *tabwriter.Writer, results []policy.FixtureResult) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, results)
+	}
+
+	if err := policyFixturesTmpl.Execute(w, results); err != nil {
+		return err
+	}
+	return w.Flush()
+}
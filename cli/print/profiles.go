@@ -0,0 +1,51 @@
+package print
+
+import (
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/replicatedhq/replicated/pkg/profiles"
+)
+
+var profilesTmplSrc = `CURRENT	NAME	APP	API ORIGIN
+{{ range . -}}
+{{ if .Current }}*{{ else }} {{ end }}	{{ .Name }}	{{ .App }}	{{ .APIOrigin }}
+{{ end }}`
+
+var profilesTmpl = template.Must(template.New("profiles").Funcs(funcs).Parse(profilesTmplSrc))
+
+// profileRow is a profile without its token
+type profileRow struct {
+	Name             string `json:"name"`
+	Current          bool   `json:"current"`
+	App              string `json:"app"`
+	APIOrigin        string `json:"apiOrigin"`
+	GraphQLOrigin    string `json:"graphqlOrigin"`
+	EnterpriseOrigin string `json:"enterpriseOrigin"`
+	KurlOrigin       string `json:"kurlOrigin"`
+}
+
+func Profiles(outputFormat string, w *tabwriter.Writer, config *profiles.Config) error {
+	rows := []profileRow{}
+	for _, name := range config.Names() {
+		profile := config.Profiles[name]
+		rows = append(rows, profileRow{
+			Name:             name,
+			Current:          name == config.CurrentProfile,
+			App:              profile.App,
+			APIOrigin:        profile.APIOrigin,
+			GraphQLOrigin:    profile.GraphQLOrigin,
+			EnterpriseOrigin: profile.EnterpriseOrigin,
+			KurlOrigin:       profile.KurlOrigin,
+		})
+	}
+
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, rows)
+	}
+
+	if err := profilesTmpl.Execute(w, rows); err != nil {
+		return err
+	}
+	return w.Flush()
+}
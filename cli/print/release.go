@@ -16,7 +16,11 @@ CONFIG:
 
 var releaseTmpl = template.Must(template.New("Release").Funcs(funcs).Parse(releaseTmplSrc))
 
-func Release(w *tabwriter.Writer, release *releases.AppRelease) error {
+func Release(outputFormat string, w *tabwriter.Writer, release *releases.AppRelease) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, release)
+	}
+
 	if err := releaseTmpl.Execute(w, release); err != nil {
 		return err
 	}
@@ -0,0 +1,17 @@
+package print
+
+import (
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/releasediff"
+)
+
+// ReleaseDiff prints a release diff as json or yaml. The table format is a
+// plain unified diff, which the caller writes directly.
+func ReleaseDiff(outputFormat string, w *tabwriter.Writer, result *releasediff.Result) error {
+	if outputFormat == FormatTable {
+		return errors.New("release diffs are not printed as a table")
+	}
+	return structured(outputFormat, w, result)
+}
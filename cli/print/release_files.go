@@ -0,0 +1,24 @@
+package print
+
+import (
+	"text/tabwriter"
+	"text/template"
+)
+
+var releaseFilesTmplSrc = `PATH
+{{ range . -}}
+{{ . }}
+{{ end }}`
+
+var releaseFilesTmpl = template.Must(template.New("release-files").Parse(releaseFilesTmplSrc))
+
+func ReleaseFiles(outputFormat string, w *tabwriter.Writer, paths []string) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, paths)
+	}
+
+	if err := releaseFilesTmpl.Execute(w, paths); err != nil {
+		return err
+	}
+	return w.Flush()
+}
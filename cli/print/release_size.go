@@ -0,0 +1,33 @@
+package print
+
+import (
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/replicatedhq/replicated/pkg/releasesize"
+)
+
+var releaseSizeTmplSrc = `FILE	SIZE	ENCODED SIZE
+{{ range .Files -}}
+{{ .Path }}	{{ size .RawSize }}	{{ size .EncodedSize }}
+{{ end }}
+Spec size:	{{ size .SpecSize }}
+Compressed size:	{{ size .CompressedSize }}
+Upload size:	{{ size .PayloadSize }}
+`
+
+var releaseSizeTmpl = template.Must(template.New("release-size").Funcs(template.FuncMap{
+	"size": releasesize.FormatSize,
+}).Parse(releaseSizeTmplSrc))
+
+// ReleaseSize prints a release size report, with the largest files first
+func ReleaseSize(outputFormat string, w *tabwriter.Writer, report *releasesize.Report) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, report)
+	}
+
+	if err := releaseSizeTmpl.Execute(w, report); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -16,7 +16,11 @@ var releasesTmplSrc = `SEQUENCE	CREATED	EDITED	ACTIVE_CHANNELS
 
 var releasesTmpl = template.Must(template.New("Releases").Funcs(funcs).Parse(releasesTmplSrc))
 
-func Releases(w *tabwriter.Writer, appReleases []types.ReleaseInfo) error {
+func Releases(outputFormat string, w *tabwriter.Writer, appReleases []types.ReleaseInfo) error {
+	if outputFormat != FormatTable {
+		return structured(outputFormat, w, appReleases)
+	}
+
 	rs := make([]map[string]interface{}, len(appReleases))
 
 	for i, r := range appReleases {
@@ -50,6 +50,10 @@ var _ = Describe("kots release lint", func() {
 
 	Context("with just a single config map", func() {
 		It("should have errors about missing files", func() {
+			if usingFakeAPI() {
+				Skip("the hosted linter is not faked")
+			}
+
 			var stdout bytes.Buffer
 			var stderr bytes.Buffer
 
@@ -26,6 +26,15 @@ var _ = AfterSuite(func() {
 	}
 	t := GinkgoT()
 	origin := os.Getenv("REPLICATED_API_ORIGIN")
+
+	// the fake API takes the API token where the Vendor API needs a session
+	if usingFakeAPI() {
+		for _, id := range appsToDelete {
+			doDeleteApp(origin, id, t, os.Getenv("REPLICATED_API_TOKEN"))
+		}
+		return
+	}
+
 	idOrigin := os.Getenv("REPLICATED_ID_ORIGIN")
 	if idOrigin == "" {
 		idOrigin = "https://id.replicated.com"
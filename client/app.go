@@ -1,21 +1,27 @@
 package client
 
 import (
+	"context"
+
 	"github.com/replicatedhq/replicated/pkg/types"
 )
 
 func (c *Client) ListApps() ([]types.AppAndChannels, error) {
-	platformApps, err := c.PlatformClient.ListApps()
+	return c.ListAppsContext(context.Background())
+}
+
+func (c *Client) ListAppsContext(ctx context.Context) ([]types.AppAndChannels, error) {
+	platformApps, err := c.PlatformClient.ListAppsContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	shipApps, err := c.ShipClient.ListApps()
+	shipApps, err := c.ShipClient.ListAppsContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	kotsApps, err := c.KotsClient.ListApps()
+	kotsApps, err := c.KotsClient.ListAppsContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -53,13 +59,25 @@ func (c *Client) ListApps() ([]types.AppAndChannels, error) {
 }
 
 func (c *Client) GetApp(appID string) (interface{}, error) {
+	return c.GetAppContext(context.Background(), appID)
+}
+
+func (c *Client) GetAppContext(ctx context.Context, appID string) (interface{}, error) {
 	return nil, nil
 }
 
 func (c *Client) CreateApp(appOptions interface{}) (interface{}, error) {
+	return c.CreateAppContext(context.Background(), appOptions)
+}
+
+func (c *Client) CreateAppContext(ctx context.Context, appOptions interface{}) (interface{}, error) {
 	return nil, nil
 }
 
 func (c *Client) DeleteApp(appID string) error {
+	return c.DeleteAppContext(context.Background(), appID)
+}
+
+func (c *Client) DeleteAppContext(ctx context.Context, appID string) error {
 	return nil
 }
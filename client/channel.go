@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -11,9 +12,13 @@ import (
 )
 
 func (c *Client) ListChannels(appID string, appType string, appSlug string, channelName string) ([]types.Channel, error) {
+	return c.ListChannelsContext(context.Background(), appID, appType, appSlug, channelName)
+}
+
+func (c *Client) ListChannelsContext(ctx context.Context, appID string, appType string, appSlug string, channelName string) ([]types.Channel, error) {
 
 	if appType == "platform" {
-		platformChannels, err := c.PlatformClient.ListChannels(appID)
+		platformChannels, err := c.PlatformClient.ListChannelsContext(ctx, appID)
 		if err != nil {
 			return nil, err
 		}
@@ -33,66 +38,124 @@ func (c *Client) ListChannels(appID string, appType string, appSlug string, chan
 
 		return channels, nil
 	} else if appType == "ship" {
-		return c.ShipClient.ListChannels(appID)
+		return c.ShipClient.ListChannelsContext(ctx, appID)
 	} else if appType == "kots" {
-		return c.KotsClient.ListChannels(appID, appSlug, channelName)
+		return c.KotsClient.ListChannelsContext(ctx, appID, appSlug, channelName)
 	}
 
 	return nil, errors.New("unknown app type")
 }
 
 func (c *Client) GetChannel(appID string, appType string, channelID string) (*channels.AppChannel, []channels.ChannelRelease, error) {
+	return c.GetChannelContext(context.Background(), appID, appType, channelID)
+}
+
+func (c *Client) GetChannelContext(ctx context.Context, appID string, appType string, channelID string) (*channels.AppChannel, []channels.ChannelRelease, error) {
 
 	if appType == "platform" {
-		return c.PlatformClient.GetChannel(appID, channelID)
+		return c.PlatformClient.GetChannelContext(ctx, appID, channelID)
 	} else if appType == "ship" {
-		return c.ShipClient.GetChannel(appID, channelID)
+		return c.ShipClient.GetChannelContext(ctx, appID, channelID)
 	} else if appType == "kots" {
-		return c.KotsClient.GetChannel(appID, channelID)
+		return c.KotsClient.GetChannelContext(ctx, appID, channelID)
 	}
 	return nil, nil, errors.New("unknown app type")
 
 }
 
+func (c *Client) ListChannelReleases(appID string, appType string, channelID string) ([]channels.ChannelRelease, error) {
+	return c.ListChannelReleasesContext(context.Background(), appID, appType, channelID)
+}
+
+// ListChannelReleasesContext returns the promotion history of a channel,
+// newest first
+func (c *Client) ListChannelReleasesContext(ctx context.Context, appID string, appType string, channelID string) ([]channels.ChannelRelease, error) {
+	if appType == "platform" {
+		_, releases, err := c.PlatformClient.GetChannelContext(ctx, appID, channelID)
+		return releases, err
+	} else if appType == "ship" {
+		return nil, errors.New("This feature is not supported for Ship applications.")
+	} else if appType == "kots" {
+		kotsReleases, err := c.KotsClient.ListChannelReleasesContext(ctx, appID, channelID)
+		if err != nil {
+			return nil, err
+		}
+
+		releases := make([]channels.ChannelRelease, 0, len(kotsReleases))
+		for _, release := range kotsReleases {
+			// the time the release was promoted, not the time it was created
+			released := release.ReleasedAt
+			if released.IsZero() {
+				released = release.Created
+			}
+			releases = append(releases, channels.ChannelRelease{
+				AirgapBuildError:  release.AirgapBuildError,
+				AirgapBuildStatus: release.AirgapBuildStatus,
+				ChannelId:         release.ChannelId,
+				ChannelSequence:   int64(release.ChannelSequence),
+				Created:           released,
+				ReleaseNotes:      release.ReleaseNotes,
+				ReleaseSequence:   int64(release.Sequence),
+				Updated:           release.Updated,
+				Version:           release.Semver,
+			})
+		}
+		return releases, nil
+	}
+	return nil, errors.New("unknown app type")
+}
+
 func (c *Client) ArchiveChannel(appID string, appType string, channelID string) error {
+	return c.ArchiveChannelContext(context.Background(), appID, appType, channelID)
+}
+
+func (c *Client) ArchiveChannelContext(ctx context.Context, appID string, appType string, channelID string) error {
 
 	if appType == "platform" {
-		return c.PlatformClient.ArchiveChannel(appID, channelID)
+		return c.PlatformClient.ArchiveChannelContext(ctx, appID, channelID)
 	} else if appType == "ship" {
 		return errors.New("This feature is not currently supported for Ship applications.")
 	} else if appType == "kots" {
-		return c.KotsClient.ArchiveChannel(appID, channelID)
+		return c.KotsClient.ArchiveChannelContext(ctx, appID, channelID)
 	}
 	return errors.New("unknown app type")
 
 }
 
 func (c *Client) CreateChannel(appID string, appType string, appSlug string, name string, description string) ([]types.Channel, error) {
+	return c.CreateChannelContext(context.Background(), appID, appType, appSlug, name, description)
+}
+
+func (c *Client) CreateChannelContext(ctx context.Context, appID string, appType string, appSlug string, name string, description string) ([]types.Channel, error) {
 
 	if appType == "platform" {
-		if err := c.PlatformClient.CreateChannel(appID, name, description); err != nil {
+		if err := c.PlatformClient.CreateChannelContext(ctx, appID, name, description); err != nil {
 			return nil, err
 		}
-		return c.ListChannels(appID, appType, appSlug, name)
+		return c.ListChannelsContext(ctx, appID, appType, appSlug, name)
 	} else if appType == "ship" {
-		if _, err := c.ShipClient.CreateChannel(appID, name, description); err != nil {
+		if _, err := c.ShipClient.CreateChannelContext(ctx, appID, name, description); err != nil {
 			return nil, err
 		}
-		return c.ShipClient.ListChannels(appID)
+		return c.ShipClient.ListChannelsContext(ctx, appID)
 	} else if appType == "kots" {
-		if _, err := c.KotsClient.CreateChannel(appID, name, description); err != nil {
+		if _, err := c.KotsClient.CreateChannelContext(ctx, appID, name, description); err != nil {
 			return nil, err
 		}
-		return c.KotsClient.ListChannels(appID, appSlug, name)
+		return c.KotsClient.ListChannelsContext(ctx, appID, appSlug, name)
 	}
 
 	return nil, errors.New("unknown app type")
 }
 
 func (c *Client) GetOrCreateChannelByName(appID string, appType string, appSlug string, nameOrID string, description string, createIfAbsent bool) (*types.Channel, error) {
+	return c.GetOrCreateChannelByNameContext(context.Background(), appID, appType, appSlug, nameOrID, description, createIfAbsent)
+}
+
+func (c *Client) GetOrCreateChannelByNameContext(ctx context.Context, appID string, appType string, appSlug string, nameOrID string, description string, createIfAbsent bool) (*types.Channel, error) {
 
 	gqlNotFoundErr := fmt.Sprintf("channel %s not found", nameOrID)
-	channel, _, err := c.GetChannel(appID, appType, nameOrID)
+	channel, _, err := c.GetChannelContext(ctx, appID, appType, nameOrID)
 	if err == nil {
 		return &types.Channel{
 			ID:              channel.Id,
@@ -105,7 +168,7 @@ func (c *Client) GetOrCreateChannelByName(appID string, appType string, appSlug
 		return nil, errors.Wrap(err, "get channel")
 	}
 
-	allChannels, err := c.ListChannels(appID, appType, appSlug, nameOrID)
+	allChannels, err := c.ListChannelsContext(ctx, appID, appType, appSlug, nameOrID)
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +176,7 @@ func (c *Client) GetOrCreateChannelByName(appID string, appType string, appSlug
 	foundChannel, numMatching, err := c.findChannel(allChannels, nameOrID)
 
 	if numMatching == 0 && createIfAbsent {
-		updatedListOfChannels, err := c.CreateChannel(appID, appType, appSlug, nameOrID, description)
+		updatedListOfChannels, err := c.CreateChannelContext(ctx, appID, appType, appSlug, nameOrID, description)
 		if err != nil {
 			return nil, errors.Wrapf(err, "create channel %q ", nameOrID)
 		}
@@ -127,8 +190,12 @@ func (c *Client) GetOrCreateChannelByName(appID string, appType string, appSlug
 }
 
 func (c *Client) GetChannelByName(appID string, appType string, appSlug string, name string) (*types.Channel, error) {
+	return c.GetChannelByNameContext(context.Background(), appID, appType, appSlug, name)
+}
 
-	return c.GetOrCreateChannelByName(appID, appType, appSlug, name, "", false)
+func (c *Client) GetChannelByNameContext(ctx context.Context, appID string, appType string, appSlug string, name string) (*types.Channel, error) {
+
+	return c.GetOrCreateChannelByNameContext(ctx, appID, appType, appSlug, name, "", false)
 }
 
 func (c *Client) findChannel(channels []types.Channel, name string) (*types.Channel, int, error) {
@@ -150,17 +217,21 @@ func (c *Client) findChannel(channels []types.Channel, name string) (*types.Chan
 }
 
 func (c *Client) UpdateSemanticVersioningForChannel(appType string, appID string, chanID string, enableSemver bool) error {
+	return c.UpdateSemanticVersioningForChannelContext(context.Background(), appType, appID, chanID, enableSemver)
+}
+
+func (c *Client) UpdateSemanticVersioningForChannelContext(ctx context.Context, appType string, appID string, chanID string, enableSemver bool) error {
 
 	if appType == "platform" {
 		return errors.New("This feature is not currently supported for Platform applications.")
 	} else if appType == "ship" {
 		return errors.New("This feature is not currently supported for Ship applications.")
 	} else if appType == "kots" {
-		channel, _, err := c.KotsClient.GetChannel(appID, chanID)
+		channel, _, err := c.KotsClient.GetChannelContext(ctx, appID, chanID)
 		if err != nil {
 			return err
 		}
-		err = c.KotsClient.UpdateSemanticVersioning(appID, channel, enableSemver)
+		err = c.KotsClient.UpdateSemanticVersioningContext(ctx, appID, channel, enableSemver)
 		return err
 	}
 
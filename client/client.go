@@ -1,6 +1,9 @@
 package client
 
 import (
+	"context"
+
+	"github.com/pkg/errors"
 	"github.com/replicatedhq/replicated/pkg/kotsclient"
 	"github.com/replicatedhq/replicated/pkg/platformclient"
 	"github.com/replicatedhq/replicated/pkg/shipclient"
@@ -25,7 +28,11 @@ func NewClient(platformOrigin string, graphqlOrigin string, apiToken string, kur
 }
 
 func (c *Client) GetAppType(appID string) (*types.App, string, error) {
-	platformSwaggerApp, err := c.PlatformClient.GetApp(appID)
+	return c.GetAppTypeContext(context.Background(), appID)
+}
+
+func (c *Client) GetAppTypeContext(ctx context.Context, appID string) (*types.App, string, error) {
+	platformSwaggerApp, err := c.PlatformClient.GetAppContext(ctx, appID)
 	if err == nil && platformSwaggerApp != nil {
 		platformApp := &types.App{
 			ID:        platformSwaggerApp.Id,
@@ -36,15 +43,45 @@ func (c *Client) GetAppType(appID string) (*types.App, string, error) {
 		return platformApp, "platform", nil
 	}
 
-	shipApp, err := c.ShipClient.GetApp(appID)
+	shipApp, err := c.ShipClient.GetAppContext(ctx, appID)
 	if err == nil && shipApp != nil {
 		return shipApp, "ship", nil
 	}
 
-	kotsApp, err := c.KotsClient.GetApp(appID)
+	kotsApp, err := c.KotsClient.GetAppContext(ctx, appID)
 	if err == nil && kotsApp != nil {
 		return kotsApp, "kots", nil
 	}
 
 	return nil, "", err
 }
+
+// GetAppByType looks up the app with the API for appType only, for when the
+// type is already known
+func (c *Client) GetAppByType(appID string, appType string) (*types.App, error) {
+	return c.GetAppByTypeContext(context.Background(), appID, appType)
+}
+
+func (c *Client) GetAppByTypeContext(ctx context.Context, appID string, appType string) (*types.App, error) {
+	switch appType {
+	case "platform":
+		platformSwaggerApp, err := c.PlatformClient.GetAppContext(ctx, appID)
+		if err != nil {
+			return nil, err
+		}
+		if platformSwaggerApp == nil {
+			return nil, errors.Errorf("app %s not found", appID)
+		}
+		return &types.App{
+			ID:        platformSwaggerApp.Id,
+			Name:      platformSwaggerApp.Name,
+			Slug:      platformSwaggerApp.Slug,
+			Scheduler: platformSwaggerApp.Scheduler,
+		}, nil
+	case "ship":
+		return c.ShipClient.GetAppContext(ctx, appID)
+	case "kots":
+		return c.KotsClient.GetAppContext(ctx, appID)
+	}
+	return nil, errors.Errorf("unknown app type %q", appType)
+}
@@ -1,18 +1,24 @@
 package client
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 	collectors "github.com/replicatedhq/replicated/gen/go/v1"
 	"github.com/replicatedhq/replicated/pkg/types"
 )
 
 func (c *Client) ListCollectors(appID string, appType string) ([]types.CollectorInfo, error) {
+	return c.ListCollectorsContext(context.Background(), appID, appType)
+}
+
+func (c *Client) ListCollectorsContext(ctx context.Context, appID string, appType string) ([]types.CollectorInfo, error) {
 
 	if appType == "kots" {
-		return nil, errors.New("On a kots application, users must modify the support-bundle.yaml file in the release")
+		return nil, errors.New("On a kots application, users must modify the support-bundle.yaml file in the release. Use \"replicated release lint\" to validate it")
 	}
 
-	shipappCollectors, err := c.ShipClient.ListCollectors(appID, appType)
+	shipappCollectors, err := c.ShipClient.ListCollectorsContext(ctx, appID, appType)
 	if err != nil {
 		return nil, err
 	}
@@ -43,37 +49,57 @@ func (c *Client) ListCollectors(appID string, appType string) ([]types.Collector
 }
 
 func (c *Client) UpdateCollector(appID string, specID string, yaml string) (interface{}, error) {
+	return c.UpdateCollectorContext(context.Background(), appID, specID, yaml)
+}
 
-	return c.ShipClient.UpdateCollector(appID, specID, yaml)
+func (c *Client) UpdateCollectorContext(ctx context.Context, appID string, specID string, yaml string) (interface{}, error) {
+
+	return c.ShipClient.UpdateCollectorContext(ctx, appID, specID, yaml)
 }
 
 func (c *Client) UpdateCollectorName(appID string, specID string, name string) (interface{}, error) {
+	return c.UpdateCollectorNameContext(context.Background(), appID, specID, name)
+}
+
+func (c *Client) UpdateCollectorNameContext(ctx context.Context, appID string, specID string, name string) (interface{}, error) {
 
-	return c.ShipClient.UpdateCollectorName(appID, specID, name)
+	return c.ShipClient.UpdateCollectorNameContext(ctx, appID, specID, name)
 
 }
 
 // func (c *Client) CreateCollector(appID string, name string, yaml string) (*collectors.AppCollectorInfo, error) {
 func (c *Client) CreateCollector(appID string, appType string, name string, yaml string) (*collectors.AppCollectorInfo, error) {
+	return c.CreateCollectorContext(context.Background(), appID, appType, name, yaml)
+}
+
+func (c *Client) CreateCollectorContext(ctx context.Context, appID string, appType string, name string, yaml string) (*collectors.AppCollectorInfo, error) {
 
 	if appType == "kots" {
-		return nil, errors.New("On a kots application, users must modify the support-bundle.yaml file in the release")
+		return nil, errors.New("On a kots application, users must modify the support-bundle.yaml file in the release. Use \"replicated release lint\" to validate it")
 	}
-	return c.ShipClient.CreateCollector(appID, name, yaml)
+	return c.ShipClient.CreateCollectorContext(ctx, appID, name, yaml)
 
 }
 
 func (c *Client) GetCollector(appID string, specID string) (*collectors.AppCollectorInfo, error) {
-	return c.ShipClient.GetCollector(appID, specID)
+	return c.GetCollectorContext(context.Background(), appID, specID)
+}
+
+func (c *Client) GetCollectorContext(ctx context.Context, appID string, specID string) (*collectors.AppCollectorInfo, error) {
+	return c.ShipClient.GetCollectorContext(ctx, appID, specID)
 
 }
 
 func (c *Client) PromoteCollector(appID string, appType string, specID string, channelIDs ...string) error {
+	return c.PromoteCollectorContext(context.Background(), appID, appType, specID, channelIDs...)
+}
+
+func (c *Client) PromoteCollectorContext(ctx context.Context, appID string, appType string, specID string, channelIDs ...string) error {
 
 	if appType == "platform" {
-		return c.PlatformClient.PromoteCollector(appID, specID, channelIDs...)
+		return c.PlatformClient.PromoteCollectorContext(ctx, appID, specID, channelIDs...)
 	} else if appType == "ship" {
-		return c.ShipClient.PromoteCollector(appID, specID, channelIDs...)
+		return c.ShipClient.PromoteCollectorContext(ctx, appID, specID, channelIDs...)
 	}
 
 	return errors.New("unknown app type")
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"time"
 
 	"github.com/pkg/errors"
@@ -9,38 +10,82 @@ import (
 )
 
 func (c *Client) ListCustomers(appID string, appType string) ([]types.Customer, error) {
+	return c.ListCustomersContext(context.Background(), appID, appType)
+}
+
+func (c *Client) ListCustomersContext(ctx context.Context, appID string, appType string) ([]types.Customer, error) {
 
 	if appType == "platform" {
 		return nil, errors.New("listing customers is not supported for platform applications")
 	} else if appType == "ship" {
 		return nil, errors.New("listing customers is not supported for ship applications")
 	} else if appType == "kots" {
-		return c.KotsClient.ListCustomers(appID)
+		return c.KotsClient.ListCustomersContext(ctx, appID)
 	}
 
 	return nil, errors.Errorf("unknown app type %q", appType)
 }
 
 func (c *Client) CreateCustomer(appID, appType string, name string, channelID string, expiresIn time.Duration) (*types.Customer, error) {
+	return c.CreateCustomerContext(context.Background(), appID, appType, name, channelID, expiresIn)
+}
+
+func (c *Client) CreateCustomerContext(ctx context.Context, appID, appType string, name string, channelID string, expiresIn time.Duration) (*types.Customer, error) {
+	if appType == "platform" {
+		return nil, errors.New("creating customers is not supported for platform applications")
+	} else if appType == "ship" {
+		return nil, errors.New("creating customers is not supported for ship applications")
+	} else if appType == "kots" {
+		return c.KotsClient.CreateCustomerContext(ctx, name, appID, channelID, expiresIn)
+	}
+
+	return nil, errors.Errorf("unknown app type %q", appType)
+
+}
+
+func (c *Client) CreateCustomerWithOptions(appID string, appType string, opts types.CustomerOptions) (*types.Customer, error) {
+	return c.CreateCustomerWithOptionsContext(context.Background(), appID, appType, opts)
+}
+
+func (c *Client) CreateCustomerWithOptionsContext(ctx context.Context, appID string, appType string, opts types.CustomerOptions) (*types.Customer, error) {
 	if appType == "platform" {
 		return nil, errors.New("creating customers is not supported for platform applications")
 	} else if appType == "ship" {
 		return nil, errors.New("creating customers is not supported for ship applications")
 	} else if appType == "kots" {
-		return c.KotsClient.CreateCustomer(name, appID, channelID, expiresIn)
+		return c.KotsClient.CreateCustomerWithOptionsContext(ctx, appID, opts)
 	}
 
 	return nil, errors.Errorf("unknown app type %q", appType)
+}
+
+func (c *Client) UpdateCustomer(appID string, appType string, customerID string, opts types.CustomerOptions) (*types.Customer, error) {
+	return c.UpdateCustomerContext(context.Background(), appID, appType, customerID, opts)
+}
+
+func (c *Client) UpdateCustomerContext(ctx context.Context, appID string, appType string, customerID string, opts types.CustomerOptions) (*types.Customer, error) {
+	if appType == "platform" {
+		return nil, errors.New("updating customers is not supported for platform applications")
+	} else if appType == "ship" {
+		return nil, errors.New("updating customers is not supported for ship applications")
+	} else if appType == "kots" {
+		return c.KotsClient.UpdateCustomerContext(ctx, appID, customerID, opts)
+	}
 
+	return nil, errors.Errorf("unknown app type %q", appType)
 }
 
 func (c *Client) GetCustomerByName(appType string, appID, name string) (*types.Customer, error) {
+	return c.GetCustomerByNameContext(context.Background(), appType, appID, name)
+}
+
+func (c *Client) GetCustomerByNameContext(ctx context.Context, appType string, appID, name string) (*types.Customer, error) {
 	if appType == "platform" {
 		return nil, errors.New("listing customers is not supported for platform applications")
 	} else if appType == "ship" {
 		return nil, errors.New("listing customers is not supported for ship applications")
 	} else if appType == "kots" {
-		return c.KotsClient.GetCustomerByName(appID, name)
+		return c.KotsClient.GetCustomerByNameContext(ctx, appID, name)
 	}
 
 	return nil, errors.Errorf("unknown app type %q", appType)
@@ -48,12 +93,61 @@ func (c *Client) GetCustomerByName(appType string, appID, name string) (*types.C
 }
 
 func (c *Client) DownloadLicense(appType string, appID string, customerID string) ([]byte, error) {
+	return c.DownloadLicenseContext(context.Background(), appType, appID, customerID)
+}
+
+func (c *Client) DownloadLicenseContext(ctx context.Context, appType string, appID string, customerID string) ([]byte, error) {
 	if appType == "platform" {
 		return nil, errors.New("downloading customer licenses is not supported for platform applications")
 	} else if appType == "ship" {
 		return nil, errors.New("downloading  customer licenses is not supported for ship applications")
 	} else if appType == "kots" {
-		return c.KotsClient.DownloadLicense(appID, customerID)
+		return c.KotsClient.DownloadLicenseContext(ctx, appID, customerID)
+	}
+	return nil, errors.Errorf("unknown app type %q", appType)
+}
+
+func (c *Client) GetCustomer(appType string, appID string, customerID string) (*types.Customer, error) {
+	return c.GetCustomerContext(context.Background(), appType, appID, customerID)
+}
+
+func (c *Client) GetCustomerContext(ctx context.Context, appType string, appID string, customerID string) (*types.Customer, error) {
+	if appType == "platform" {
+		return nil, errors.New("getting customers is not supported for platform applications")
+	} else if appType == "ship" {
+		return nil, errors.New("getting customers is not supported for ship applications")
+	} else if appType == "kots" {
+		return c.KotsClient.GetCustomerContext(ctx, appID, customerID)
 	}
 	return nil, errors.Errorf("unknown app type %q", appType)
 }
+
+func (c *Client) ArchiveCustomer(appType string, customerID string) error {
+	return c.ArchiveCustomerContext(context.Background(), appType, customerID)
+}
+
+func (c *Client) ArchiveCustomerContext(ctx context.Context, appType string, customerID string) error {
+	if appType == "platform" {
+		return errors.New("archiving customers is not supported for platform applications")
+	} else if appType == "ship" {
+		return errors.New("archiving customers is not supported for ship applications")
+	} else if appType == "kots" {
+		return c.KotsClient.ArchiveCustomerContext(ctx, customerID)
+	}
+	return errors.Errorf("unknown app type %q", appType)
+}
+
+func (c *Client) UnarchiveCustomer(appType string, customerID string) error {
+	return c.UnarchiveCustomerContext(context.Background(), appType, customerID)
+}
+
+func (c *Client) UnarchiveCustomerContext(ctx context.Context, appType string, customerID string) error {
+	if appType == "platform" {
+		return errors.New("unarchiving customers is not supported for platform applications")
+	} else if appType == "ship" {
+		return errors.New("unarchiving customers is not supported for ship applications")
+	} else if appType == "kots" {
+		return c.KotsClient.UnarchiveCustomerContext(ctx, customerID)
+	}
+	return errors.Errorf("unknown app type %q", appType)
+}
@@ -1,17 +1,22 @@
 package client
 
 import (
+	"context"
 	"errors"
 
 	"github.com/replicatedhq/replicated/pkg/types"
 )
 
 func (c *Client) CreateEntitlementSpec(appID string, appType string, name string, spec string) (*types.EntitlementSpec, error) {
+	return c.CreateEntitlementSpecContext(context.Background(), appID, appType, name, spec)
+}
+
+func (c *Client) CreateEntitlementSpecContext(ctx context.Context, appID string, appType string, name string, spec string) (*types.EntitlementSpec, error) {
 
 	if appType == "platform" {
 		return nil, errors.New("This feature is not supported for platform applications.")
 	} else if appType == "ship" {
-		c.ShipClient.CreateEntitlementSpec(appID, name, spec)
+		c.ShipClient.CreateEntitlementSpecContext(ctx, appID, name, spec)
 	} else if appType == "kots" {
 		return nil, errors.New("This feature is not supported for kots applications.")
 	}
@@ -20,9 +25,17 @@ func (c *Client) CreateEntitlementSpec(appID string, appType string, name string
 }
 
 func (c *Client) SetDefaultEntitlementSpec(specID string) error {
-	return c.ShipClient.SetDefaultEntitlementSpec(specID)
+	return c.SetDefaultEntitlementSpecContext(context.Background(), specID)
+}
+
+func (c *Client) SetDefaultEntitlementSpecContext(ctx context.Context, specID string) error {
+	return c.ShipClient.SetDefaultEntitlementSpecContext(ctx, specID)
 }
 
 func (c *Client) SetEntitlementValue(customerID string, specID string, key string, value string, datatype string, appID string) (*types.EntitlementValue, error) {
-	return c.ShipClient.SetEntitlementValue(customerID, specID, key, value, datatype, appID)
+	return c.SetEntitlementValueContext(context.Background(), customerID, specID, key, value, datatype, appID)
+}
+
+func (c *Client) SetEntitlementValueContext(ctx context.Context, customerID string, specID string, key string, value string, datatype string, appID string) (*types.EntitlementValue, error) {
+	return c.ShipClient.SetEntitlementValueContext(ctx, customerID, specID, key, value, datatype, appID)
 }
@@ -1,30 +1,40 @@
 package client
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 	"github.com/replicatedhq/replicated/pkg/types"
 )
 
 func (c *Client) CreateInstaller(appId string, appType string, yaml string) (*types.InstallerSpec, error) {
+	return c.CreateInstallerContext(context.Background(), appId, appType, yaml)
+}
+
+func (c *Client) CreateInstallerContext(ctx context.Context, appId string, appType string, yaml string) (*types.InstallerSpec, error) {
 	if appType == "platform" {
 		return nil, errors.Errorf("Kubernetes Installers are not supported for platform applications")
 	} else if appType == "ship" {
 		return nil, errors.Errorf("Kubernetes Installers are not supported for ship applications")
 	} else if appType == "kots" {
-		return c.KotsClient.CreateInstaller(appId, yaml)
+		return c.KotsClient.CreateInstallerContext(ctx, appId, yaml)
 	}
 
 	return nil, errors.New("unknown app type")
 }
 
 func (c *Client) ListInstallers(appId string, appType string) ([]types.InstallerSpec, error) {
+	return c.ListInstallersContext(context.Background(), appId, appType)
+}
+
+func (c *Client) ListInstallersContext(ctx context.Context, appId string, appType string) ([]types.InstallerSpec, error) {
 
 	if appType == "platform" {
 		return nil, errors.Errorf("Kubernetes Installers are not supported for platform applications")
 	} else if appType == "ship" {
 		return nil, errors.Errorf("Kubernetes Installers are not supported for ship applications")
 	} else if appType == "kots" {
-		return c.KotsClient.ListInstallers(appId)
+		return c.KotsClient.ListInstallersContext(ctx, appId)
 	}
 
 	return nil, errors.New("unknown app type")
@@ -32,12 +42,16 @@ func (c *Client) ListInstallers(appId string, appType string) ([]types.Installer
 }
 
 func (c *Client) PromoteInstaller(appId string, appType string, sequence int64, channelID string, versionLabel string) error {
+	return c.PromoteInstallerContext(context.Background(), appId, appType, sequence, channelID, versionLabel)
+}
+
+func (c *Client) PromoteInstallerContext(ctx context.Context, appId string, appType string, sequence int64, channelID string, versionLabel string) error {
 	if appType == "platform" {
 		return errors.Errorf("Kubernetes Installers are not supported for platform applications")
 	} else if appType == "ship" {
 		return errors.Errorf("Kubernetes Installers are not supported for ship applications")
 	} else if appType == "kots" {
-		return c.KotsClient.PromoteInstaller(appId, sequence, channelID, versionLabel)
+		return c.KotsClient.PromoteInstallerContext(ctx, appId, sequence, channelID, versionLabel)
 	}
 
 	return errors.New("unknown app type")
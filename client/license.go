@@ -1,5 +1,11 @@
 package client
 
+import "context"
+
 func (c *Client) CreateLicense(license interface{}) (interface{}, error) {
+	return c.CreateLicenseContext(context.Background(), license)
+}
+
+func (c *Client) CreateLicenseContext(ctx context.Context, license interface{}) (interface{}, error) {
 	return nil, nil
 }
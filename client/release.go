@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 
 	releases "github.com/replicatedhq/replicated/gen/go/v1"
@@ -8,9 +9,13 @@ import (
 )
 
 func (c *Client) ListReleases(appID string, appType string) ([]types.ReleaseInfo, error) {
+	return c.ListReleasesContext(context.Background(), appID, appType)
+}
+
+func (c *Client) ListReleasesContext(ctx context.Context, appID string, appType string) ([]types.ReleaseInfo, error) {
 
 	if appType == "platform" {
-		platformReleases, err := c.PlatformClient.ListReleases(appID)
+		platformReleases, err := c.PlatformClient.ListReleasesContext(ctx, appID)
 		if err != nil {
 			return nil, err
 		}
@@ -43,7 +48,7 @@ func (c *Client) ListReleases(appID string, appType string) ([]types.ReleaseInfo
 		return releaseInfos, nil
 
 	} else if appType == "ship" {
-		shipReleases, err := c.ShipClient.ListReleases(appID)
+		shipReleases, err := c.ShipClient.ListReleasesContext(ctx, appID)
 		if err != nil {
 			return nil, err
 		}
@@ -76,16 +81,20 @@ func (c *Client) ListReleases(appID string, appType string) ([]types.ReleaseInfo
 		return releaseInfos, nil
 
 	} else if appType == "kots" {
-		return c.KotsClient.ListReleases(appID)
+		return c.KotsClient.ListReleasesContext(ctx, appID)
 	}
 
 	return nil, errors.New("unknown app type")
 }
 
 func (c *Client) CreateRelease(appID string, appType string, yaml string) (*types.ReleaseInfo, error) {
+	return c.CreateReleaseContext(context.Background(), appID, appType, yaml)
+}
+
+func (c *Client) CreateReleaseContext(ctx context.Context, appID string, appType string, yaml string) (*types.ReleaseInfo, error) {
 
 	if appType == "platform" {
-		platformReleaseInfo, err := c.PlatformClient.CreateRelease(appID, yaml)
+		platformReleaseInfo, err := c.PlatformClient.CreateReleaseContext(ctx, appID, yaml)
 		if err != nil {
 			return nil, err
 		}
@@ -111,46 +120,58 @@ func (c *Client) CreateRelease(appID string, appType string, yaml string) (*type
 			ActiveChannels: activeChannels,
 		}, nil
 	} else if appType == "ship" {
-		return c.ShipClient.CreateRelease(appID, yaml)
+		return c.ShipClient.CreateReleaseContext(ctx, appID, yaml)
 	} else if appType == "kots" {
-		return c.KotsClient.CreateRelease(appID, yaml)
+		return c.KotsClient.CreateReleaseContext(ctx, appID, yaml)
 	}
 
 	return nil, errors.New("unknown app type")
 }
 
 func (c *Client) UpdateRelease(appID string, appType string, sequence int64, yaml string) error {
+	return c.UpdateReleaseContext(context.Background(), appID, appType, sequence, yaml)
+}
+
+func (c *Client) UpdateReleaseContext(ctx context.Context, appID string, appType string, sequence int64, yaml string) error {
 
 	if appType == "platform" {
-		return c.PlatformClient.UpdateRelease(appID, sequence, yaml)
+		return c.PlatformClient.UpdateReleaseContext(ctx, appID, sequence, yaml)
 	} else if appType == "ship" {
-		return c.ShipClient.UpdateRelease(appID, sequence, yaml)
+		return c.ShipClient.UpdateReleaseContext(ctx, appID, sequence, yaml)
 	} else if appType == "kots" {
-		return c.KotsClient.UpdateRelease(appID, sequence, yaml)
+		return c.KotsClient.UpdateReleaseContext(ctx, appID, sequence, yaml)
 	}
 	return errors.New("unknown app type")
 }
 
 func (c *Client) GetRelease(appID string, appType string, sequence int64) (*releases.AppRelease, error) {
+	return c.GetReleaseContext(context.Background(), appID, appType, sequence)
+}
+
+func (c *Client) GetReleaseContext(ctx context.Context, appID string, appType string, sequence int64) (*releases.AppRelease, error) {
 
 	if appType == "platform" {
-		return c.PlatformClient.GetRelease(appID, sequence)
+		return c.PlatformClient.GetReleaseContext(ctx, appID, sequence)
 	} else if appType == "ship" {
 		return nil, errors.New("This feature is not supported for Ship applications.")
 	} else if appType == "kots" {
-		return c.KotsClient.GetRelease(appID, sequence)
+		return c.KotsClient.GetReleaseContext(ctx, appID, sequence)
 	}
 	return nil, errors.New("unknown app type")
 }
 
 func (c *Client) PromoteRelease(appID string, appType string, sequence int64, label string, notes string, required bool, channelIDs ...string) error {
+	return c.PromoteReleaseContext(context.Background(), appID, appType, sequence, label, notes, required, channelIDs...)
+}
+
+func (c *Client) PromoteReleaseContext(ctx context.Context, appID string, appType string, sequence int64, label string, notes string, required bool, channelIDs ...string) error {
 
 	if appType == "platform" {
-		return c.PlatformClient.PromoteRelease(appID, sequence, label, notes, required, channelIDs...)
+		return c.PlatformClient.PromoteReleaseContext(ctx, appID, sequence, label, notes, required, channelIDs...)
 	} else if appType == "ship" {
-		return c.ShipClient.PromoteRelease(appID, sequence, label, notes, channelIDs...)
+		return c.ShipClient.PromoteReleaseContext(ctx, appID, sequence, label, notes, channelIDs...)
 	} else if appType == "kots" {
-		return c.KotsClient.PromoteRelease(appID, label, notes, sequence, channelIDs...)
+		return c.KotsClient.PromoteReleaseContext(ctx, appID, label, notes, sequence, channelIDs...)
 	}
 	return errors.New("unknown app type")
 }
@@ -158,13 +179,17 @@ func (c *Client) PromoteRelease(appID string, appType string, sequence int64, la
 // data is a []byte describing a tarred yaml-dir, created by tarYAMLDir()
 // this Client abstraction continue to spring more leaks :)
 func (c *Client) LintRelease(appType string, data []byte) ([]types.LintMessage, error) {
+	return c.LintReleaseContext(context.Background(), appType, data)
+}
+
+func (c *Client) LintReleaseContext(ctx context.Context, appType string, data []byte) ([]types.LintMessage, error) {
 
 	if appType == "platform" {
 		return nil, errors.New("Linting is not yet supported in this CLI, please install github.com/replicatedhq/replicated-lint to lint this application")
 	} else if appType == "ship" {
 		return nil, errors.New("Linting is not supported for ship applications")
 	} else if appType == "kots" {
-		return c.KotsClient.LintRelease(data)
+		return c.KotsClient.LintReleaseContext(ctx, data)
 	}
 
 	return nil, errors.New("unknown app type")
@@ -0,0 +1,133 @@
+// Package appcache caches the type of each app, so commands don't have to
+// probe the platform, ship and kots APIs to find out which one an app uses.
+package appcache
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/types"
+)
+
+const DefaultTTL = 24 * time.Hour
+
+// DefaultPath is ~/.replicated/cache/app-types.json
+func DefaultPath(homeDir string) string {
+	return filepath.Join(homeDir, ".replicated", "cache", "app-types.json")
+}
+
+type entry struct {
+	App       types.App `json:"app"`
+	AppType   string    `json:"appType"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type cacheFile struct {
+	Entries map[string]entry `json:"entries"`
+}
+
+// Cache maps an API origin and app slug or id to the app and its type.
+// Entries expire after the TTL the cache was loaded with.
+type Cache struct {
+	path    string
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]entry
+}
+
+// Load reads the cache at path. A missing or unreadable cache is empty, it
+// is only an optimization.
+func Load(path string, ttl time.Duration) *Cache {
+	c := &Cache{
+		path:    path,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]entry{},
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return c
+	}
+	file := cacheFile{}
+	if err := json.Unmarshal(b, &file); err == nil && file.Entries != nil {
+		c.entries = file.Entries
+	}
+	return c
+}
+
+func key(origin string, appSlugOrID string) string {
+	return origin + " " + appSlugOrID
+}
+
+// Get returns the cached app and type for appSlugOrID on origin
+func (c *Cache) Get(origin string, appSlugOrID string) (*types.App, string, bool) {
+	e, ok := c.entries[key(origin, appSlugOrID)]
+	if !ok || !c.now().Before(e.ExpiresAt) {
+		return nil, "", false
+	}
+	app := e.App
+	return &app, e.AppType, true
+}
+
+// Set caches the app under both its slug and its id, so that either can be
+// used to find it
+func (c *Cache) Set(origin string, app types.App, appType string) {
+	e := entry{
+		App:       app,
+		AppType:   appType,
+		ExpiresAt: c.now().Add(c.ttl),
+	}
+	if app.ID != "" {
+		c.entries[key(origin, app.ID)] = e
+	}
+	if app.Slug != "" {
+		c.entries[key(origin, app.Slug)] = e
+	}
+}
+
+// Delete drops the app from the cache, under both its slug and its id
+func (c *Cache) Delete(origin string, app types.App) {
+	delete(c.entries, key(origin, app.ID))
+	delete(c.entries, key(origin, app.Slug))
+}
+
+// Save drops expired entries and writes the cache
+func (c *Cache) Save() error {
+	now := c.now()
+	for k, e := range c.entries {
+		if !now.Before(e.ExpiresAt) {
+			delete(c.entries, k)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return errors.Wrap(err, "create cache dir")
+	}
+
+	b, err := json.Marshal(cacheFile{Entries: c.entries})
+	if err != nil {
+		return errors.Wrap(err, "marshal cache")
+	}
+
+	// write to a temp file and rename, so concurrent invocations never read a partial file
+	tmp, err := ioutil.TempFile(filepath.Dir(c.path), ".app-types-*.json")
+	if err != nil {
+		return errors.Wrap(err, "create temp file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "write cache")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "close cache")
+	}
+
+	return errors.Wrap(os.Rename(tmp.Name(), c.path), "replace cache")
+}
@@ -0,0 +1,82 @@
+package appcache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	req := require.New(t)
+
+	dir, err := ioutil.TempDir("", "replicated-appcache")
+	req.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cache", "app-types.json")
+
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	origin := "https://api.replicated.com/vendor"
+	app := types.App{ID: "1abc", Name: "My App", Slug: "my-app", Scheduler: "kots"}
+
+	cache := Load(path, time.Hour)
+	cache.now = func() time.Time { return now }
+	_, _, ok := cache.Get(origin, "my-app")
+	req.False(ok)
+
+	cache.Set(origin, app, "kots")
+	req.NoError(cache.Save())
+
+	cache = Load(path, time.Hour)
+	cache.now = func() time.Time { return now.Add(30 * time.Minute) }
+
+	for _, slugOrID := range []string{"my-app", "1abc"} {
+		got, appType, ok := cache.Get(origin, slugOrID)
+		req.True(ok, slugOrID)
+		req.Equal("kots", appType)
+		req.Equal(app, *got)
+	}
+
+	_, _, ok = cache.Get("https://api.staging.replicated.com/vendor", "my-app")
+	req.False(ok, "other origins are cached separately")
+
+	cache.now = func() time.Time { return now.Add(time.Hour) }
+	_, _, ok = cache.Get(origin, "my-app")
+	req.False(ok, "expired")
+
+	req.NoError(cache.Save())
+	req.Empty(Load(path, time.Hour).entries)
+}
+
+func TestLoadCorrupt(t *testing.T) {
+	req := require.New(t)
+
+	dir, err := ioutil.TempDir("", "replicated-appcache")
+	req.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "app-types.json")
+	req.NoError(ioutil.WriteFile(path, []byte("{not json"), 0600))
+
+	cache := Load(path, time.Hour)
+	req.Empty(cache.entries)
+	cache.Set("origin", types.App{ID: "1", Slug: "a"}, "ship")
+	req.NoError(cache.Save())
+}
+
+func TestDelete(t *testing.T) {
+	req := require.New(t)
+
+	cache := Load(filepath.Join(os.TempDir(), "replicated-appcache-missing.json"), time.Hour)
+	app := types.App{ID: "1abc", Slug: "my-app"}
+	cache.Set("origin", app, "kots")
+	cache.Delete("origin", app)
+
+	for _, slugOrID := range []string{"my-app", "1abc"} {
+		_, _, ok := cache.Get("origin", slugOrID)
+		req.False(ok, slugOrID)
+	}
+}
@@ -0,0 +1,182 @@
+// Package collectormigrate converts the collector specs of ship and platform
+// applications into troubleshoot.sh/v1beta2 specs that can be added to a KOTS
+// release.
+package collectormigrate
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const apiVersion = "troubleshoot.sh/v1beta2"
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// A Result is a converted collector spec
+type Result struct {
+	// Filename is the name the spec should have in the release
+	Filename string
+	Content  []byte
+	// Unconverted lists the collectors that have no troubleshoot.sh
+	// equivalent and were left out
+	Unconverted []string
+}
+
+// Migrate converts a collector spec. Both troubleshoot specs, of any version,
+// and the legacy "collect: v1" format are supported.
+func Migrate(name string, config string) (*Result, error) {
+	doc := yaml.MapSlice{}
+	if err := yaml.Unmarshal([]byte(config), &doc); err != nil {
+		return nil, errors.Wrap(err, "parse collector spec")
+	}
+
+	if version, ok := get(doc, "apiVersion").(string); ok && strings.HasPrefix(version, "troubleshoot.") {
+		return migrateTroubleshoot(name, doc)
+	}
+	if collect, ok := get(doc, "collect").(yaml.MapSlice); ok {
+		if v1, ok := get(collect, "v1").([]interface{}); ok {
+			return migrateLegacy(name, v1)
+		}
+	}
+
+	return nil, errors.New("unrecognized collector spec, expected a troubleshoot spec or a \"collect: v1\" spec")
+}
+
+// migrateTroubleshoot moves a troubleshoot spec to the current version. Older
+// Collector specs list the collectors directly under spec.
+func migrateTroubleshoot(name string, doc yaml.MapSlice) (*Result, error) {
+	kind := "SupportBundle"
+	filename := "support-bundle.yaml"
+	switch k := get(doc, "kind"); k {
+	case "Collector", "SupportBundle":
+	case "Preflight":
+		kind = "Preflight"
+		filename = "preflight.yaml"
+	default:
+		return nil, errors.Errorf("unsupported troubleshoot kind %v", k)
+	}
+
+	spec := yaml.MapSlice{}
+	switch s := get(doc, "spec").(type) {
+	case []interface{}:
+		spec = append(spec, yaml.MapItem{Key: "collectors", Value: s})
+	case yaml.MapSlice:
+		spec = s
+	case nil:
+	default:
+		return nil, errors.Errorf("unexpected spec of type %T", s)
+	}
+
+	if metadataName, ok := get(get(doc, "metadata"), "name").(string); ok && metadataName != "" {
+		name = metadataName
+	}
+
+	content, err := marshal(kind, name, spec)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Filename: filename, Content: content}, nil
+}
+
+// migrateLegacy converts the collectors of a "collect: v1" spec that have a
+// troubleshoot equivalent
+func migrateLegacy(name string, v1 []interface{}) (*Result, error) {
+	var collectors []interface{}
+	var unconverted []string
+	added := map[string]bool{}
+	addOnce := func(collector string) {
+		if !added[collector] {
+			added[collector] = true
+			collectors = append(collectors, yaml.MapSlice{{Key: collector, Value: yaml.MapSlice{}}})
+		}
+	}
+
+	for _, item := range v1 {
+		entry, ok := item.(yaml.MapSlice)
+		if !ok {
+			return nil, errors.Errorf("unexpected collector of type %T", item)
+		}
+		for _, field := range entry {
+			legacyName := fmt.Sprint(field.Key)
+			options, _ := field.Value.(yaml.MapSlice)
+
+			switch legacyName {
+			case "kubernetes.version", "kubernetes.cluster-info":
+				addOnce("clusterInfo")
+			case "kubernetes.resource-list", "kubernetes.api-versions":
+				addOnce("clusterResources")
+			case "kubernetes.logs":
+				collectors = append(collectors, yaml.MapSlice{{Key: "logs", Value: legacyLogs(options)}})
+			default:
+				unconverted = append(unconverted, legacyName)
+			}
+		}
+	}
+
+	spec := yaml.MapSlice{{Key: "collectors", Value: collectors}}
+	content, err := marshal("SupportBundle", name, spec)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Filename: "support-bundle.yaml", Content: content, Unconverted: unconverted}, nil
+}
+
+func legacyLogs(options yaml.MapSlice) yaml.MapSlice {
+	logs := yaml.MapSlice{}
+	if outputDir, ok := get(options, "output_dir").(string); ok && strings.Trim(outputDir, "/") != "" {
+		logs = append(logs, yaml.MapItem{Key: "name", Value: path.Base(strings.Trim(outputDir, "/"))})
+	}
+	if namespace, ok := get(options, "namespace").(string); ok && namespace != "" {
+		logs = append(logs, yaml.MapItem{Key: "namespace", Value: namespace})
+	}
+	if labelSelector, ok := get(get(options, "list_options"), "labelSelector").(string); ok && labelSelector != "" {
+		var selector []string
+		for _, label := range strings.Split(labelSelector, ",") {
+			selector = append(selector, strings.TrimSpace(label))
+		}
+		logs = append(logs, yaml.MapItem{Key: "selector", Value: selector})
+	}
+	return logs
+}
+
+func marshal(kind string, name string, spec yaml.MapSlice) ([]byte, error) {
+	doc := yaml.MapSlice{
+		{Key: "apiVersion", Value: apiVersion},
+		{Key: "kind", Value: kind},
+		{Key: "metadata", Value: yaml.MapSlice{{Key: "name", Value: resourceName(name)}}},
+		{Key: "spec", Value: spec},
+	}
+	content, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal spec")
+	}
+	return content, nil
+}
+
+// resourceName turns a collector name into a valid kubernetes resource name
+func resourceName(name string) string {
+	name = strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if name == "" {
+		return "support-bundle"
+	}
+	return name
+}
+
+// get returns the value of key in a yaml map, or nil
+func get(m interface{}, key string) interface{} {
+	slice, ok := m.(yaml.MapSlice)
+	if !ok {
+		return nil
+	}
+	for _, item := range slice {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return nil
+}
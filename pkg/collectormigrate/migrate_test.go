@@ -0,0 +1,135 @@
+package collectormigrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name            string
+		collectorName   string
+		config          string
+		wantFilename    string
+		wantContent     string
+		wantUnconverted []string
+		wantErr         string
+	}{
+		{
+			name:          "v1beta1 collector",
+			collectorName: "Default Collector",
+			config: `apiVersion: troubleshoot.replicated.com/v1beta1
+kind: Collector
+spec:
+  - clusterInfo: {}
+  - logs:
+      selector:
+        - app=web
+`,
+			wantFilename: "support-bundle.yaml",
+			wantContent: `apiVersion: troubleshoot.sh/v1beta2
+kind: SupportBundle
+metadata:
+  name: default-collector
+spec:
+  collectors:
+  - clusterInfo: {}
+  - logs:
+      selector:
+      - app=web
+`,
+		},
+		{
+			name:          "preflight keeps its analyzers",
+			collectorName: "ignored",
+			config: `apiVersion: troubleshoot.replicated.com/v1beta1
+kind: Preflight
+metadata:
+  name: checks
+spec:
+  analyzers:
+    - clusterVersion:
+        outcomes:
+          - fail:
+              when: "< 1.16.0"
+`,
+			wantFilename: "preflight.yaml",
+			wantContent: `apiVersion: troubleshoot.sh/v1beta2
+kind: Preflight
+metadata:
+  name: checks
+spec:
+  analyzers:
+  - clusterVersion:
+      outcomes:
+      - fail:
+          when: < 1.16.0
+`,
+		},
+		{
+			name:          "legacy collect v1",
+			collectorName: "legacy",
+			config: `collect:
+  v1:
+    - os.hostname:
+        output_dir: /os/hostname
+    - kubernetes.version:
+        output_dir: /kubernetes/version
+    - kubernetes.cluster-info:
+        output_dir: /kubernetes/cluster-info
+    - kubernetes.logs:
+        output_dir: /kubernetes/logs/web
+        namespace: default
+        list_options:
+          labelSelector: app=web,tier=frontend
+    - kubernetes.resource-list:
+        kind: pods
+`,
+			wantFilename: "support-bundle.yaml",
+			wantContent: `apiVersion: troubleshoot.sh/v1beta2
+kind: SupportBundle
+metadata:
+  name: legacy
+spec:
+  collectors:
+  - clusterInfo: {}
+  - logs:
+      name: web
+      namespace: default
+      selector:
+      - app=web
+      - tier=frontend
+  - clusterResources: {}
+`,
+			wantUnconverted: []string{"os.hostname"},
+		},
+		{
+			name:    "unrecognized",
+			config:  "hello: world\n",
+			wantErr: "unrecognized collector spec",
+		},
+		{
+			name:    "unsupported kind",
+			config:  "apiVersion: troubleshoot.sh/v1beta2\nkind: Redactor\n",
+			wantErr: "unsupported troubleshoot kind Redactor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+
+			result, err := Migrate(tt.collectorName, tt.config)
+			if tt.wantErr != "" {
+				req.Error(err)
+				req.Contains(err.Error(), tt.wantErr)
+				return
+			}
+			req.NoError(err)
+			req.Equal(tt.wantFilename, result.Filename)
+			req.Equal(tt.wantContent, string(result.Content))
+			req.Equal(tt.wantUnconverted, result.Unconverted)
+		})
+	}
+}
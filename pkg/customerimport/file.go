@@ -0,0 +1,277 @@
+// Package customerimport reads and writes customer lists as CSV or YAML, for
+// bulk importing and exporting customers.
+package customerimport
+
+import (
+	"encoding/csv"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatYAML = "yaml"
+)
+
+// CSV columns. Any other column is an entitlement value, named by its header.
+const (
+	columnName      = "name"
+	columnChannel   = "channel"
+	columnType      = "type"
+	columnExpiresAt = "expires_at"
+)
+
+// A Record is one customer in an import or export file
+type Record struct {
+	// Row is the 1-based position of the record in the file, the header row
+	// of a CSV file not included
+	Row int `yaml:"-"`
+
+	Name         string            `yaml:"name"`
+	Channel      string            `yaml:"channel"`
+	Type         string            `yaml:"type,omitempty"`
+	ExpiresAt    string            `yaml:"expiresAt,omitempty"`
+	Entitlements map[string]string `yaml:"entitlements,omitempty"`
+}
+
+type yamlFile struct {
+	Customers []Record `yaml:"customers"`
+}
+
+// FormatForPath picks the format from the file extension
+func FormatForPath(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	}
+	return "", errors.Errorf("cannot tell the format of %s, use a .csv, .yaml or .yml file", path)
+}
+
+// Read parses the records in r. Only the structure of the file is checked,
+// each record is validated with Validate.
+func Read(r io.Reader, format string) ([]Record, error) {
+	switch format {
+	case FormatCSV:
+		return readCSV(r)
+	case FormatYAML:
+		b, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, errors.Wrap(err, "read yaml")
+		}
+		file := yamlFile{}
+		if err := yaml.UnmarshalStrict(b, &file); err != nil {
+			return nil, errors.Wrap(err, "parse yaml")
+		}
+		for i := range file.Customers {
+			file.Customers[i].Row = i + 1
+		}
+		return file.Customers, nil
+	}
+	return nil, errors.Errorf("format %q not supported, supported values are [csv, yaml]", format)
+}
+
+func readCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "read csv header")
+	}
+	for i, column := range header {
+		header[i] = strings.TrimSpace(column)
+	}
+
+	hasName, hasChannel := false, false
+	for _, column := range header {
+		hasName = hasName || column == columnName
+		hasChannel = hasChannel || column == columnChannel
+	}
+	if !hasName || !hasChannel {
+		return nil, errors.Errorf("csv header must include the %q and %q columns", columnName, columnChannel)
+	}
+
+	records := []Record{}
+	for row := 1; ; row++ {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "read csv row %d", row)
+		}
+
+		record := Record{Row: row}
+		for i, value := range values {
+			value = strings.TrimSpace(value)
+			switch header[i] {
+			case columnName:
+				record.Name = value
+			case columnChannel:
+				record.Channel = value
+			case columnType:
+				record.Type = value
+			case columnExpiresAt:
+				record.ExpiresAt = value
+			default:
+				if value == "" {
+					continue
+				}
+				if record.Entitlements == nil {
+					record.Entitlements = map[string]string{}
+				}
+				record.Entitlements[header[i]] = value
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// Write writes the records in the given format. Entitlement columns are
+// sorted by name.
+func Write(w io.Writer, format string, records []Record) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, records)
+	case FormatYAML:
+		b, err := yaml.Marshal(yamlFile{Customers: records})
+		if err != nil {
+			return errors.Wrap(err, "marshal yaml")
+		}
+		_, err = w.Write(b)
+		return err
+	}
+	return errors.Errorf("format %q not supported, supported values are [csv, yaml]", format)
+}
+
+func writeCSV(w io.Writer, records []Record) error {
+	entitlementNames := map[string]bool{}
+	for _, record := range records {
+		for name := range record.Entitlements {
+			entitlementNames[name] = true
+		}
+	}
+	entitlementColumns := []string{}
+	for name := range entitlementNames {
+		entitlementColumns = append(entitlementColumns, name)
+	}
+	sort.Strings(entitlementColumns)
+
+	writer := csv.NewWriter(w)
+	header := append([]string{columnName, columnChannel, columnType, columnExpiresAt}, entitlementColumns...)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, record := range records {
+		row := []string{record.Name, record.Channel, record.Type, record.ExpiresAt}
+		for _, name := range entitlementColumns {
+			row = append(row, record.Entitlements[name])
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Validate checks the record's fields and returns its expiry, nil when it has none
+func (r Record) Validate() (*time.Time, error) {
+	if r.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	if r.Channel == "" {
+		return nil, errors.New("channel is required")
+	}
+	if r.Type != "" {
+		valid := false
+		for _, customerType := range types.CustomerTypes {
+			valid = valid || r.Type == customerType
+		}
+		if !valid {
+			return nil, errors.Errorf("type %q not supported, supported values are [%s]", r.Type, strings.Join(types.CustomerTypes, ", "))
+		}
+	}
+	if r.ExpiresAt == "" {
+		return nil, nil
+	}
+
+	expiresAt, err := parseExpiry(r.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &expiresAt, nil
+}
+
+// parseExpiry accepts an RFC 3339 timestamp or a date
+func parseExpiry(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, errors.Errorf("expires_at %q is not a date (2006-01-02) or RFC 3339 timestamp", value)
+}
+
+// EntitlementValues returns the record's entitlements sorted by name
+func (r Record) EntitlementValues() []types.EntitlementValue {
+	names := make([]string, 0, len(r.Entitlements))
+	for name := range r.Entitlements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make([]types.EntitlementValue, 0, len(names))
+	for _, name := range names {
+		values = append(values, types.EntitlementValue{Name: name, Value: r.Entitlements[name]})
+	}
+	return values
+}
+
+// FromCustomer converts a customer from the Vendor API to a record. The API
+// does not return entitlement values with customers, so they are not included.
+func FromCustomer(customer types.Customer) Record {
+	record := Record{
+		Name: customer.Name,
+		Type: customer.Type,
+	}
+	if len(customer.Channels) > 0 {
+		record.Channel = customer.Channels[0].Name
+	}
+	if customer.Expires != nil && !customer.Expires.IsZero() {
+		record.ExpiresAt = customer.Expires.UTC().Format(time.RFC3339)
+	}
+	return record
+}
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+)
+
+// A Result is the outcome of importing one record
+type Result struct {
+	Row    int    `json:"row"`
+	Name   string `json:"name"`
+	Action Action `json:"action,omitempty"`
+	DryRun bool   `json:"dryRun"`
+	Error  string `json:"error,omitempty"`
+}
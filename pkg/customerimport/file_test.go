@@ -0,0 +1,142 @@
+package customerimport
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		input   string
+		want    []Record
+		wantErr string
+	}{
+		{
+			name:   "csv with entitlements",
+			format: FormatCSV,
+			input: `name,channel,type,expires_at,seats,region
+acme,Stable,paid,2030-01-01,10,us
+ globex , Beta ,,,,
+`,
+			want: []Record{
+				{Row: 1, Name: "acme", Channel: "Stable", Type: "paid", ExpiresAt: "2030-01-01", Entitlements: map[string]string{"seats": "10", "region": "us"}},
+				{Row: 2, Name: "globex", Channel: "Beta"},
+			},
+		},
+		{
+			name:    "csv without channel column",
+			format:  FormatCSV,
+			input:   "name,type\nacme,paid\n",
+			wantErr: `csv header must include the "name" and "channel" columns`,
+		},
+		{
+			name:   "yaml",
+			format: FormatYAML,
+			input: `customers:
+  - name: acme
+    channel: Stable
+    type: trial
+    entitlements:
+      seats: "5"
+`,
+			want: []Record{
+				{Row: 1, Name: "acme", Channel: "Stable", Type: "trial", Entitlements: map[string]string{"seats": "5"}},
+			},
+		},
+		{
+			name:    "yaml unknown field",
+			format:  FormatYAML,
+			input:   "customers:\n  - name: acme\n    chanel: Stable\n",
+			wantErr: "field chanel not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			got, err := Read(strings.NewReader(tt.input), tt.format)
+			if tt.wantErr != "" {
+				req.Error(err)
+				req.Contains(err.Error(), tt.wantErr)
+				return
+			}
+			req.NoError(err)
+			req.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		record  Record
+		want    *time.Time
+		wantErr string
+	}{
+		{
+			name:   "no expiry",
+			record: Record{Name: "acme", Channel: "Stable"},
+		},
+		{
+			name:   "date",
+			record: Record{Name: "acme", Channel: "Stable", Type: "community", ExpiresAt: "2030-01-01"},
+			want:   timePtr(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:    "missing channel",
+			record:  Record{Name: "acme"},
+			wantErr: "channel is required",
+		},
+		{
+			name:    "bad type",
+			record:  Record{Name: "acme", Channel: "Stable", Type: "enterprise"},
+			wantErr: `type "enterprise" not supported`,
+		},
+		{
+			name:    "bad expiry",
+			record:  Record{Name: "acme", Channel: "Stable", ExpiresAt: "next year"},
+			wantErr: `expires_at "next year" is not a date`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			got, err := tt.record.Validate()
+			if tt.wantErr != "" {
+				req.Error(err)
+				req.Contains(err.Error(), tt.wantErr)
+				return
+			}
+			req.NoError(err)
+			req.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	records := []Record{
+		{Row: 1, Name: "acme", Channel: "Stable", Type: "paid", ExpiresAt: "2030-01-01T00:00:00Z", Entitlements: map[string]string{"seats": "10"}},
+		{Row: 2, Name: "globex, inc", Channel: "Beta", Type: "dev"},
+	}
+
+	for _, format := range []string{FormatCSV, FormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			req := require.New(t)
+			var buf bytes.Buffer
+			req.NoError(Write(&buf, format, records))
+
+			got, err := Read(&buf, format)
+			req.NoError(err)
+			req.Equal(records, got)
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,194 @@
+// Package dirwatch polls a directory tree for changes. Polling is used rather
+// than filesystem events so that it behaves the same on every platform and
+// on network and container mounts.
+package dirwatch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// A Filter reports whether a file is watched. It is only called for files.
+type Filter func(path string, info os.FileInfo) bool
+
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// A Snapshot is the state of the watched files in a tree, keyed by path
+// relative to the root
+type Snapshot map[string]fileState
+
+// Changes are the differences between two snapshots
+type Changes struct {
+	Added    []string
+	Modified []string
+	Removed  []string
+}
+
+// Empty reports whether there are no changes
+func (c Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Modified) == 0 && len(c.Removed) == 0
+}
+
+// Merge adds later changes to c. A file added and then removed is dropped,
+// and a file added and then modified is still added.
+func (c Changes) Merge(later Changes) Changes {
+	status := map[string]string{}
+	for _, path := range c.Added {
+		status[path] = "added"
+	}
+	for _, path := range c.Modified {
+		status[path] = "modified"
+	}
+	for _, path := range c.Removed {
+		status[path] = "removed"
+	}
+
+	for _, path := range later.Added {
+		if status[path] == "removed" {
+			status[path] = "modified"
+		} else {
+			status[path] = "added"
+		}
+	}
+	for _, path := range later.Modified {
+		if status[path] != "added" {
+			status[path] = "modified"
+		}
+	}
+	for _, path := range later.Removed {
+		if status[path] == "added" {
+			delete(status, path)
+		} else {
+			status[path] = "removed"
+		}
+	}
+
+	merged := Changes{}
+	for path, s := range status {
+		switch s {
+		case "added":
+			merged.Added = append(merged.Added, path)
+		case "modified":
+			merged.Modified = append(merged.Modified, path)
+		case "removed":
+			merged.Removed = append(merged.Removed, path)
+		}
+	}
+	merged.sort()
+	return merged
+}
+
+func (c *Changes) sort() {
+	sort.Strings(c.Added)
+	sort.Strings(c.Modified)
+	sort.Strings(c.Removed)
+}
+
+// Take returns the snapshot of the files under root that pass filter. Files
+// and directories that are removed while the tree is walked are left out, it
+// is only an error if root itself does not exist.
+func Take(root string, filter Filter) (Snapshot, error) {
+	snapshot := Snapshot{}
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path != root {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() || !filter(path, info) {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		snapshot[filepath.ToSlash(rel)] = fileState{size: info.Size(), modTime: info.ModTime()}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "walk %s", root)
+	}
+	return snapshot, nil
+}
+
+// Diff returns the changes from s to next
+func (s Snapshot) Diff(next Snapshot) Changes {
+	changes := Changes{}
+	for path, state := range next {
+		prev, ok := s[path]
+		if !ok {
+			changes.Added = append(changes.Added, path)
+		} else if prev.size != state.size || !prev.modTime.Equal(state.modTime) {
+			changes.Modified = append(changes.Modified, path)
+		}
+	}
+	for path := range s {
+		if _, ok := next[path]; !ok {
+			changes.Removed = append(changes.Removed, path)
+		}
+	}
+	changes.sort()
+	return changes
+}
+
+// Watch polls root every interval and calls onChange once the tree has not
+// changed for the debounce period, with everything that changed since the
+// last call. newFilter is called before each poll, so that a filter that
+// depends on files in the tree can be rebuilt. It returns when ctx is done,
+// when root is removed, or with the first error from newFilter or onChange.
+func Watch(ctx context.Context, root string, newFilter func() (Filter, error), interval time.Duration, debounce time.Duration, onChange func(Changes) error) error {
+	take := func() (Snapshot, error) {
+		filter, err := newFilter()
+		if err != nil {
+			return nil, err
+		}
+		return Take(root, filter)
+	}
+
+	last, err := take()
+	if err != nil {
+		return err
+	}
+
+	pending := Changes{}
+	var lastChange time.Time
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			next, err := take()
+			if err != nil {
+				return err
+			}
+			if changes := last.Diff(next); !changes.Empty() {
+				pending = pending.Merge(changes)
+				lastChange = now
+			}
+			last = next
+
+			if !lastChange.IsZero() && now.Sub(lastChange) >= debounce {
+				lastChange = time.Time{}
+				if pending.Empty() {
+					continue
+				}
+				if err := onChange(pending); err != nil {
+					return err
+				}
+				pending = Changes{}
+			}
+		}
+	}
+}
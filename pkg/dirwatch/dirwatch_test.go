@@ -0,0 +1,173 @@
+package dirwatch
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func yamlOnly(path string, info os.FileInfo) bool {
+	return strings.HasSuffix(path, ".yaml")
+}
+
+func TestDiff(t *testing.T) {
+	req := require.New(t)
+
+	dir, err := ioutil.TempDir("", "dirwatch")
+	req.NoError(err)
+	defer os.RemoveAll(dir)
+
+	write := func(name string, content string) {
+		req.NoError(os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		req.NoError(ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	write("a.yaml", "a")
+	write("b.yaml", "b")
+	write("ignored.txt", "x")
+
+	before, err := Take(dir, yamlOnly)
+	req.NoError(err)
+
+	write("a.yaml", "aa")
+	write("sub/c.yaml", "c")
+	write("ignored.txt", "xx")
+	req.NoError(os.Remove(filepath.Join(dir, "b.yaml")))
+
+	after, err := Take(dir, yamlOnly)
+	req.NoError(err)
+
+	req.Equal(Changes{
+		Added:    []string{"sub/c.yaml"},
+		Modified: []string{"a.yaml"},
+		Removed:  []string{"b.yaml"},
+	}, before.Diff(after))
+	req.True(after.Diff(after).Empty())
+}
+
+func TestTakeRemoved(t *testing.T) {
+	req := require.New(t)
+
+	dir, err := ioutil.TempDir("", "dirwatch")
+	req.NoError(err)
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"a.yaml", "b.yaml", "sub/c.yaml"} {
+		req.NoError(os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		req.NoError(ioutil.WriteFile(filepath.Join(dir, name), []byte(name), 0644))
+	}
+
+	// b.yaml and sub are removed after the walk has listed them
+	removeOthers := func(path string, info os.FileInfo) bool {
+		if info.Name() == "a.yaml" {
+			os.Remove(filepath.Join(dir, "b.yaml"))
+			os.RemoveAll(filepath.Join(dir, "sub"))
+		}
+		return true
+	}
+	snapshot, err := Take(dir, removeOthers)
+	req.NoError(err)
+	req.Equal(Changes{Added: []string{"a.yaml"}}, Snapshot{}.Diff(snapshot))
+
+	req.NoError(os.RemoveAll(dir))
+	_, err = Take(dir, yamlOnly)
+	req.Error(err)
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name  string
+		first Changes
+		later Changes
+		want  Changes
+	}{
+		{
+			name:  "added then removed",
+			first: Changes{Added: []string{"a.yaml"}},
+			later: Changes{Removed: []string{"a.yaml"}},
+			want:  Changes{},
+		},
+		{
+			name:  "added then modified",
+			first: Changes{Added: []string{"a.yaml"}},
+			later: Changes{Modified: []string{"a.yaml", "b.yaml"}},
+			want:  Changes{Added: []string{"a.yaml"}, Modified: []string{"b.yaml"}},
+		},
+		{
+			name:  "removed then added",
+			first: Changes{Removed: []string{"a.yaml"}},
+			later: Changes{Added: []string{"a.yaml"}},
+			want:  Changes{Modified: []string{"a.yaml"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.first.Merge(tt.later))
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	req := require.New(t)
+
+	dir, err := ioutil.TempDir("", "dirwatch")
+	req.NoError(err)
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var calls []Changes
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		ioutil.WriteFile(filepath.Join(dir, "a.yaml"), []byte("a"), 0644)
+		time.Sleep(20 * time.Millisecond)
+		ioutil.WriteFile(filepath.Join(dir, "b.yaml"), []byte("b"), 0644)
+	}()
+
+	err = Watch(ctx, dir, func() (Filter, error) { return yamlOnly, nil }, 10*time.Millisecond, 100*time.Millisecond, func(changes Changes) error {
+		calls = append(calls, changes)
+		cancel()
+		return nil
+	})
+	req.NoError(err)
+	req.Equal([]Changes{{Added: []string{"a.yaml", "b.yaml"}}}, calls)
+}
+
+func TestWatchNewFilter(t *testing.T) {
+	req := require.New(t)
+
+	dir, err := ioutil.TempDir("", "dirwatch")
+	req.NoError(err)
+	defer os.RemoveAll(dir)
+	req.NoError(ioutil.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// txt files are watched from the third poll on, as if an ignore file
+	// that excluded them had changed
+	polls := 0
+	newFilter := func() (Filter, error) {
+		polls++
+		if polls < 3 {
+			return yamlOnly, nil
+		}
+		return func(path string, info os.FileInfo) bool { return true }, nil
+	}
+
+	var calls []Changes
+	err = Watch(ctx, dir, newFilter, 10*time.Millisecond, 50*time.Millisecond, func(changes Changes) error {
+		calls = append(calls, changes)
+		cancel()
+		return nil
+	})
+	req.NoError(err)
+	req.Equal([]Changes{{Added: []string{"a.txt"}}}, calls)
+}
@@ -1,17 +1,16 @@
 package enterpriseclient
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
-	"crypto/sha512"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
-	"math/big"
 	"os"
 	"path/filepath"
 	"strings"
@@ -21,48 +20,48 @@ import (
 	"golang.org/x/crypto/ssh"
 )
 
-func (c HTTPClient) AuthInit(organizationName string) error {
-	// by default, we store the key in ~/.replicated/enterprise
-	_, err := os.Stat(filepath.Join(homeDir(), ".replicated", "enterprise"))
-	if err != nil && !os.IsNotExist(err) {
-		return errors.Wrap(err, "failed to check for directory")
-	}
-	if os.IsNotExist(err) {
-		if err := os.MkdirAll(filepath.Join(homeDir(), ".replicated", "enterprise"), 0755); err != nil {
-			return errors.Wrap(err, "failed to mkdir")
-		}
-	}
-	pubKeyPath := filepath.Join(homeDir(), ".replicated", "enterprise", "ecdsa.pub")
-	privKeyPath := filepath.Join(homeDir(), ".replicated", "enterprise", "ecdsa")
-
-	_, pubKeyErr := os.Stat(pubKeyPath)
-	_, privKeyErr := os.Stat(privKeyPath)
-
-	if pubKeyErr != nil && !os.IsNotExist(pubKeyErr) {
-		return errors.Wrap(pubKeyErr, "failed to read public key")
-	}
-	if privKeyErr != nil && !os.IsNotExist(privKeyErr) {
-		return errors.Wrap(privKeyErr, "failed to read private key")
-	}
+// AuthInitOptions control how the key used to sign requests is set up
+type AuthInitOptions struct {
+	// OrganizationName creates a new organization when set, otherwise the
+	// key must be approved by Replicated or an existing organization
+	OrganizationName string
+	// Signer is an existing key to register, such as one in ssh-agent. When
+	// it is nil a new key is generated and written to ~/.replicated/enterprise.
+	Signer Signer
+	// Passphrase encrypts the generated key when set
+	Passphrase []byte
+}
 
-	missingPublicKey := os.IsNotExist(pubKeyErr)
-	missingPrivateKey := os.IsNotExist(privKeyErr)
+func (c HTTPClient) AuthInit(organizationName string) error {
+	return c.AuthInitContext(context.Background(), organizationName)
+}
 
-	if !missingPrivateKey && !missingPublicKey {
-		return errors.New("already authenticated")
-	}
+func (c HTTPClient) AuthInitContext(ctx context.Context, organizationName string) error {
+	return c.AuthInitWithOptionsContext(ctx, AuthInitOptions{OrganizationName: organizationName})
+}
 
-	privateKey, err := generatePrivateKey()
-	if err != nil {
-		return errors.Wrap(err, "failed to generate private key")
-	}
-	if err := ioutil.WriteFile(privKeyPath, encodePrivateKeyToPEM(privateKey), 0600); err != nil {
-		return errors.Wrap(err, "failed to write private key to file")
-	}
+func (c HTTPClient) AuthInitWithOptions(opts AuthInitOptions) error {
+	return c.AuthInitWithOptionsContext(context.Background(), opts)
+}
 
-	if err := ioutil.WriteFile(pubKeyPath, encodePublicKey(&privateKey.PublicKey), 0600); err != nil {
-		return errors.Wrap(err, "failed to write public key to file")
+func (c HTTPClient) AuthInitWithOptionsContext(ctx context.Context, opts AuthInitOptions) error {
+	var publicKey ssh.PublicKey
+	if opts.Signer != nil {
+		publicKey = opts.Signer.PublicKey()
+		if !isECDSAKey(publicKey) {
+			return errors.Errorf("key is %s, only ECDSA keys are supported", publicKey.Type())
+		}
+	} else {
+		privateKey, err := writeNewPrivateKey(opts.Passphrase)
+		if err != nil {
+			return err
+		}
+		publicKey, err = ssh.NewPublicKey(&privateKey.PublicKey)
+		if err != nil {
+			return errors.Wrap(err, "create ssh pubkey")
+		}
 	}
+	organizationName := opts.OrganizationName
 
 	if organizationName != "" {
 		// --create-org flag is provided, create the organization
@@ -72,7 +71,7 @@ func (c HTTPClient) AuthInit(organizationName string) error {
 			OrganizationName string `json:"organizationName"`
 		}
 		createOrgRequest := CreateOrgRequest{
-			PublicKeyBytes:   encodePublicKey(&privateKey.PublicKey),
+			PublicKeyBytes:   publicKey.Marshal(),
 			OrganizationName: organizationName,
 		}
 
@@ -81,7 +80,7 @@ func (c HTTPClient) AuthInit(organizationName string) error {
 		}
 		createOrgResponse := CreateOrgResponse{}
 
-		err = c.doJSON("POST", "/v1/organization", 201, createOrgRequest, &createOrgResponse)
+		err := c.doJSON(ctx, "POST", "/v1/organization", 201, createOrgRequest, &createOrgResponse)
 		if err != nil {
 			return errors.Wrap(err, "failed to create organization")
 		}
@@ -94,7 +93,7 @@ func (c HTTPClient) AuthInit(organizationName string) error {
 			PublicKeyBytes []byte `json:"publicKey"`
 		}
 		authRequest := AuthRequest{
-			PublicKeyBytes: encodePublicKey(&privateKey.PublicKey),
+			PublicKeyBytes: publicKey.Marshal(),
 		}
 
 		type AuthInitResponse struct {
@@ -102,7 +101,7 @@ func (c HTTPClient) AuthInit(organizationName string) error {
 		}
 		authInitResponse := AuthInitResponse{}
 
-		err = c.doJSON("POST", "/v1/auth", 201, authRequest, &authInitResponse)
+		err := c.doJSON(ctx, "POST", "/v1/auth", 201, authRequest, &authInitResponse)
 		if err != nil {
 			return errors.Wrap(err, "failed to init auth with server")
 		}
@@ -113,7 +112,83 @@ func (c HTTPClient) AuthInit(organizationName string) error {
 	return nil
 }
 
+// writeNewPrivateKey generates a key and writes it, and its public key, to
+// ~/.replicated/enterprise
+func writeNewPrivateKey(passphrase []byte) (*ecdsa.PrivateKey, error) {
+	// by default, we store the key in ~/.replicated/enterprise
+	_, err := os.Stat(filepath.Join(homeDir(), ".replicated", "enterprise"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "failed to check for directory")
+	}
+	if os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Join(homeDir(), ".replicated", "enterprise"), 0755); err != nil {
+			return nil, errors.Wrap(err, "failed to mkdir")
+		}
+	}
+	pubKeyPath := filepath.Join(homeDir(), ".replicated", "enterprise", "ecdsa.pub")
+	privKeyPath := filepath.Join(homeDir(), ".replicated", "enterprise", "ecdsa")
+
+	_, pubKeyErr := os.Stat(pubKeyPath)
+	_, privKeyErr := os.Stat(privKeyPath)
+
+	if pubKeyErr != nil && !os.IsNotExist(pubKeyErr) {
+		return nil, errors.Wrap(pubKeyErr, "failed to read public key")
+	}
+	if privKeyErr != nil && !os.IsNotExist(privKeyErr) {
+		return nil, errors.Wrap(privKeyErr, "failed to read private key")
+	}
+
+	missingPublicKey := os.IsNotExist(pubKeyErr)
+	missingPrivateKey := os.IsNotExist(privKeyErr)
+
+	if !missingPrivateKey && !missingPublicKey {
+		return nil, errors.New("already authenticated")
+	}
+
+	return writePrivateKey(privKeyPath, pubKeyPath, passphrase)
+}
+
+// writePrivateKey generates a key and writes it to privKeyPath, encrypted when
+// a passphrase is given, and its public key to pubKeyPath
+func writePrivateKey(privKeyPath string, pubKeyPath string, passphrase []byte) (*ecdsa.PrivateKey, error) {
+	privateKey, err := generatePrivateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate private key")
+	}
+	privatePEM := encodePrivateKeyToPEM(privateKey)
+	if len(passphrase) > 0 {
+		privatePEM, err = encryptPrivateKeyPEM(privatePEM, passphrase)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := ioutil.WriteFile(privKeyPath, privatePEM, 0600); err != nil {
+		return nil, errors.Wrap(err, "failed to write private key to file")
+	}
+
+	if err := ioutil.WriteFile(pubKeyPath, encodePublicKey(&privateKey.PublicKey), 0600); err != nil {
+		return nil, errors.Wrap(err, "failed to write public key to file")
+	}
+
+	return privateKey, nil
+}
+
+// encryptPrivateKeyPEM encrypts a PEM encoded key with a passphrase, in the
+// format "openssl ec -aes256" writes
+func encryptPrivateKeyPEM(privatePEM []byte, passphrase []byte) ([]byte, error) {
+	block, _ := pem.Decode(privatePEM)
+	encrypted, err := x509.EncryptPEMBlock(rand.Reader, block.Type, block.Bytes, passphrase, x509.PEMCipherAES256)
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypt private key")
+	}
+	return pem.EncodeToMemory(encrypted), nil
+}
+
 func (c HTTPClient) AuthApprove(fingerprint string) error {
+	return c.AuthApproveContext(context.Background(), fingerprint)
+}
+
+func (c HTTPClient) AuthApproveContext(ctx context.Context, fingerprint string) error {
 	type AuthApproveRequest struct {
 		Fingerprint string `json:"fingerprint"`
 	}
@@ -121,7 +196,7 @@ func (c HTTPClient) AuthApprove(fingerprint string) error {
 		Fingerprint: fingerprint,
 	}
 
-	err := c.doJSON("PUT", "/v1/auth/approve", 204, authApproveRequest, nil)
+	err := c.doJSON(ctx, "PUT", "/v1/auth/approve", 204, authApproveRequest, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to approve auth request")
 	}
@@ -156,20 +231,6 @@ func encodePrivateKeyToPEM(privateKey *ecdsa.PrivateKey) []byte {
 	return privatePEM
 }
 
-func decodePrivateKeyPEM(privateBytes []byte) (*ecdsa.PrivateKey, error) {
-	privBlock, _ := pem.Decode(privateBytes)
-
-	if privBlock.Type != "EC PRIVATE KEY" {
-		return nil, fmt.Errorf("private key type is %s, not 'EC PRIVATE KEY'", privBlock.Type)
-	}
-
-	key, err := x509.ParseECPrivateKey(privBlock.Bytes)
-	if err != nil {
-		return nil, errors.Wrap(err, "decode ec private key")
-	}
-	return key, nil
-}
-
 func encodePublicKey(publicKey *ecdsa.PublicKey) []byte {
 	pubKey, err := ssh.NewPublicKey(publicKey)
 	if err != nil {
@@ -179,12 +240,8 @@ func encodePublicKey(publicKey *ecdsa.PublicKey) []byte {
 	return pubKey.Marshal()
 }
 
-func getFingerprint(publicKey *ecdsa.PublicKey) (string, error) {
-	pubKey, err := ssh.NewPublicKey(publicKey)
-	if err != nil {
-		return "", errors.Wrap(err, "create ssh pubkey")
-	}
-	return ssh.FingerprintSHA256(pubKey), nil
+func getFingerprint(publicKey ssh.PublicKey) string {
+	return ssh.FingerprintSHA256(publicKey)
 }
 
 func homeDir() string {
@@ -202,7 +259,7 @@ type SigBlock struct {
 
 // gets the (base64 encoded) signature and fingerprint for a given key and data
 // returns the signature with a nonce and timestamp, the signature of the data alone, the fingerprint, and error
-func sigAndFingerprint(privateKey *ecdsa.PrivateKey, data []byte) (string, string, string, error) {
+func sigAndFingerprint(signer Signer, data []byte) (string, string, string, error) {
 	// generate a timestamp and nonce
 	nonce := make([]byte, 512/8)        // 512 bits
 	_, _ = rand.Read(nonce)             // returns len, nil - neither of which we need
@@ -211,37 +268,27 @@ func sigAndFingerprint(privateKey *ecdsa.PrivateKey, data []byte) (string, strin
 		return "", "", "", errors.Wrap(err, "failed to get timestamp")
 	}
 
-	// hash the body and sign the hash
-	// store the signature in an ecSig struct and marshal it with the ssh wire format
-	contentSha := sha512.Sum512(data)
-	var ecSig struct {
-		R *big.Int
-		S *big.Int
-	}
-	ecSig.R, ecSig.S, err = ecdsa.Sign(rand.Reader, privateKey, contentSha[:])
+	// sign the body, the signature blob is the ssh wire format of the ecdsa
+	// signature of the body's hash
+	sig, err := signer.Sign(rand.Reader, data)
 	if err != nil {
-		return "", "", "", errors.Wrap(err, "failed to sign content sha")
+		return "", "", "", errors.Wrap(err, "failed to sign content")
 	}
-	signatureString := base64.StdEncoding.EncodeToString(ssh.Marshal(ecSig))
+	signatureString := base64.StdEncoding.EncodeToString(sig.Blob)
 
 	// do the same for the data combined with the timestamp and nonce
-	contentSha = sha512.Sum512(combineTsNonceData(ts, nonce, data))
-	ecSig.R, ecSig.S, err = ecdsa.Sign(rand.Reader, privateKey, contentSha[:])
+	tsSig, err := signer.Sign(rand.Reader, combineTsNonceData(ts, nonce, data))
 	if err != nil {
-		return "", "", "", errors.Wrap(err, "failed to sign content sha")
+		return "", "", "", errors.Wrap(err, "failed to sign content")
 	}
-	tsSig := ssh.Marshal(ecSig)
 
 	// include the public key fingerprint as a hint to the server
-	fingerprint, err := getFingerprint(&privateKey.PublicKey)
-	if err != nil {
-		return "", "", "", errors.Wrap(err, "failed to get public key fingerprint")
-	}
+	fingerprint := getFingerprint(signer.PublicKey())
 
 	sigBlock := SigBlock{
 		Timestamp: ts,
 		Nonce:     nonce,
-		Signature: tsSig,
+		Signature: tsSig.Blob,
 	}
 	sigBlockBytes, err := json.Marshal(sigBlock)
 	if err != nil {
@@ -0,0 +1,203 @@
+package enterpriseclient
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/enterprisetypes"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	AuthRotationPending = "pending"
+	AuthRotationDone    = "rotated"
+)
+
+// AuthRotation is the state of a key rotation started by AuthRotate
+type AuthRotation struct {
+	Status         string
+	OldFingerprint string
+	NewFingerprint string
+	// Code is the approval code, only set when the rotation is started
+	Code string
+	// OldKeyPath is where the replaced key was moved once rotated
+	OldKeyPath string
+}
+
+func (c HTTPClient) ListAuthKeys() ([]*enterprisetypes.AuthKey, error) {
+	return c.ListAuthKeysContext(context.Background())
+}
+
+func (c HTTPClient) ListAuthKeysContext(ctx context.Context) ([]*enterprisetypes.AuthKey, error) {
+	authKeys := []*enterprisetypes.AuthKey{}
+	err := c.doJSON(ctx, "GET", "/v1/auth/keys", 200, nil, &authKeys)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list auth keys")
+	}
+
+	return authKeys, nil
+}
+
+func (c HTTPClient) RevokeAuthKey(fingerprint string) error {
+	return c.RevokeAuthKeyContext(context.Background(), fingerprint)
+}
+
+func (c HTTPClient) RevokeAuthKeyContext(ctx context.Context, fingerprint string) error {
+	type AuthRevokeRequest struct {
+		Fingerprint string `json:"fingerprint"`
+	}
+	authRevokeRequest := AuthRevokeRequest{
+		Fingerprint: fingerprint,
+	}
+
+	err := c.doJSON(ctx, "PUT", "/v1/auth/revoke", 204, authRevokeRequest, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to revoke auth key")
+	}
+
+	return nil
+}
+
+func (c HTTPClient) AuthWhoami() (*enterprisetypes.AuthIdentity, error) {
+	return c.AuthWhoamiContext(context.Background())
+}
+
+// AuthWhoamiContext describes the key requests are signed with, and its status
+// on the server. The server rejects a revoked key, so when the status can't be
+// looked up it stays unknown and the reason is kept in StatusError.
+func (c HTTPClient) AuthWhoamiContext(ctx context.Context) (*enterprisetypes.AuthIdentity, error) {
+	if c.signer == nil {
+		return nil, errors.New("no private key is configured")
+	}
+
+	identity := enterprisetypes.AuthIdentity{
+		Fingerprint: getFingerprint(c.signer.PublicKey()),
+		KeyType:     c.signer.PublicKey().Type(),
+		Status:      "unknown",
+	}
+
+	authKeys, err := c.ListAuthKeysContext(ctx)
+	if err != nil {
+		identity.StatusError = err.Error()
+		return &identity, nil
+	}
+	for _, authKey := range authKeys {
+		if authKey.Fingerprint == identity.Fingerprint {
+			identity.Status = authKey.Status
+		}
+	}
+
+	return &identity, nil
+}
+
+// PendingKeyPath is where AuthRotate writes the new key until it is approved
+func PendingKeyPath(keyPath string) string {
+	return keyPath + ".next"
+}
+
+func (c HTTPClient) AuthRotate(keyPath string, passphrase []byte) (*AuthRotation, error) {
+	return c.AuthRotateContext(context.Background(), keyPath, passphrase)
+}
+
+// AuthRotateContext replaces the key file at keyPath, which the client signs
+// requests with. The first call generates a new key next to it and submits it
+// for approval, signed by the current key, which stays valid. Once the new key
+// is approved, the next call moves it into place and keeps the replaced key
+// at keyPath.old. The new key is encrypted when a passphrase is given.
+func (c HTTPClient) AuthRotateContext(ctx context.Context, keyPath string, passphrase []byte) (*AuthRotation, error) {
+	if c.signer == nil {
+		return nil, errors.New("no private key is configured")
+	}
+
+	pendingPath := PendingKeyPath(keyPath)
+	rotation := AuthRotation{
+		OldFingerprint: getFingerprint(c.signer.PublicKey()),
+	}
+
+	_, err := os.Stat(pendingPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "failed to check for pending key")
+	}
+	if os.IsNotExist(err) {
+		privateKey, err := writePrivateKey(pendingPath, pendingPath+".pub", passphrase)
+		if err != nil {
+			return nil, err
+		}
+		publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "create ssh pubkey")
+		}
+
+		type AuthRotateRequest struct {
+			PublicKeyBytes []byte `json:"publicKey"`
+		}
+		authRotateRequest := AuthRotateRequest{
+			PublicKeyBytes: publicKey.Marshal(),
+		}
+
+		type AuthRotateResponse struct {
+			Code string `json:"code"`
+		}
+		authRotateResponse := AuthRotateResponse{}
+
+		err = c.doJSON(ctx, "POST", "/v1/auth/rotate", 201, authRotateRequest, &authRotateResponse)
+		if err != nil {
+			// without the request the pending key is useless, so start over next time
+			_ = os.Remove(pendingPath)
+			_ = os.Remove(pendingPath + ".pub")
+			return nil, errors.Wrap(err, "failed to submit new key")
+		}
+
+		rotation.Status = AuthRotationPending
+		rotation.NewFingerprint = getFingerprint(publicKey)
+		rotation.Code = authRotateResponse.Code
+		return &rotation, nil
+	}
+
+	pubKeyBytes, err := ioutil.ReadFile(pendingPath + ".pub")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read pending public key")
+	}
+	publicKey, err := ssh.ParsePublicKey(pubKeyBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse pending public key")
+	}
+	rotation.NewFingerprint = getFingerprint(publicKey)
+
+	authKeys, err := c.ListAuthKeysContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pendingKey *enterprisetypes.AuthKey
+	for _, authKey := range authKeys {
+		if authKey.Fingerprint == rotation.NewFingerprint {
+			pendingKey = authKey
+		}
+	}
+	if pendingKey == nil || pendingKey.Status == enterprisetypes.AuthKeyStatusRevoked {
+		return nil, errors.Errorf("new key %s was not approved, remove %s and %s.pub to start over", rotation.NewFingerprint, pendingPath, pendingPath)
+	}
+	if pendingKey.Status != enterprisetypes.AuthKeyStatusApproved {
+		rotation.Status = AuthRotationPending
+		return &rotation, nil
+	}
+
+	rotation.OldKeyPath = keyPath + ".old"
+	if err := os.Rename(keyPath, rotation.OldKeyPath); err != nil {
+		return nil, errors.Wrap(err, "failed to move old private key")
+	}
+	if err := os.Rename(keyPath+".pub", rotation.OldKeyPath+".pub"); err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "failed to move old public key")
+	}
+	if err := os.Rename(pendingPath, keyPath); err != nil {
+		return nil, errors.Wrap(err, "failed to move new private key")
+	}
+	if err := os.Rename(pendingPath+".pub", keyPath+".pub"); err != nil {
+		return nil, errors.Wrap(err, "failed to move new public key")
+	}
+
+	rotation.Status = AuthRotationDone
+	return &rotation, nil
+}
@@ -0,0 +1,121 @@
+package enterpriseclient
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/replicatedhq/replicated/pkg/enterprisetypes"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestAuthRotate(t *testing.T) {
+	req := require.New(t)
+
+	dir, err := ioutil.TempDir("", "enterpriseclient")
+	req.NoError(err)
+	defer os.RemoveAll(dir)
+
+	keyPath := filepath.Join(dir, "ecdsa")
+	oldKey, err := writePrivateKey(keyPath, keyPath+".pub", nil)
+	req.NoError(err)
+	oldPublicKey, err := ssh.NewPublicKey(&oldKey.PublicKey)
+	req.NoError(err)
+
+	authKeys := []*enterprisetypes.AuthKey{
+		{Fingerprint: getFingerprint(oldPublicKey), Status: enterprisetypes.AuthKeyStatusApproved},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(getFingerprint(oldPublicKey), r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/v1/auth/rotate":
+			body := struct {
+				PublicKeyBytes []byte `json:"publicKey"`
+			}{}
+			req.NoError(json.NewDecoder(r.Body).Decode(&body))
+			publicKey, err := ssh.ParsePublicKey(body.PublicKeyBytes)
+			req.NoError(err)
+			authKeys = append(authKeys, &enterprisetypes.AuthKey{Fingerprint: getFingerprint(publicKey), Status: enterprisetypes.AuthKeyStatusPending})
+			w.WriteHeader(201)
+			w.Write([]byte(`{"code":"abc123"}`))
+		case "/v1/auth/keys":
+			json.NewEncoder(w).Encode(authKeys)
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer server.Close()
+
+	signer, err := NewKeyFileSigner(keyPath, nil)
+	req.NoError(err)
+	client := NewHTTPClientWithSigner(server.URL, signer)
+
+	// the first call submits a new key
+	rotation, err := client.AuthRotate(keyPath, []byte("hunter2"))
+	req.NoError(err)
+	req.Equal(AuthRotationPending, rotation.Status)
+	req.Equal("abc123", rotation.Code)
+	req.Equal(getFingerprint(oldPublicKey), rotation.OldFingerprint)
+	req.Equal(authKeys[1].Fingerprint, rotation.NewFingerprint)
+	req.FileExists(PendingKeyPath(keyPath))
+
+	// it stays pending until approved
+	rotation, err = client.AuthRotate(keyPath, nil)
+	req.NoError(err)
+	req.Equal(AuthRotationPending, rotation.Status)
+	req.Empty(rotation.Code)
+	req.Equal(authKeys[1].Fingerprint, rotation.NewFingerprint)
+
+	// then the new key replaces the old one
+	authKeys[1].Status = enterprisetypes.AuthKeyStatusApproved
+	rotation, err = client.AuthRotate(keyPath, nil)
+	req.NoError(err)
+	req.Equal(AuthRotationDone, rotation.Status)
+	req.Equal(keyPath+".old", rotation.OldKeyPath)
+	req.NoFileExists(PendingKeyPath(keyPath))
+
+	oldSigner, err := NewKeyFileSigner(rotation.OldKeyPath, nil)
+	req.NoError(err)
+	req.Equal(getFingerprint(oldPublicKey), getFingerprint(oldSigner.PublicKey()))
+
+	newSigner, err := NewKeyFileSigner(keyPath, func() ([]byte, error) { return []byte("hunter2"), nil })
+	req.NoError(err)
+	req.Equal(authKeys[1].Fingerprint, getFingerprint(newSigner.PublicKey()))
+
+	identity, err := NewHTTPClientWithSigner(server.URL, oldSigner).AuthWhoami()
+	req.NoError(err)
+	req.Equal(&enterprisetypes.AuthIdentity{
+		Fingerprint: getFingerprint(oldPublicKey),
+		KeyType:     "ecdsa-sha2-nistp521",
+		Status:      enterprisetypes.AuthKeyStatusApproved,
+	}, identity)
+}
+
+func TestAuthWhoamiRejectedKey(t *testing.T) {
+	req := require.New(t)
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "ecdsa")
+	_, err := writePrivateKey(keyPath, keyPath+".pub", nil)
+	req.NoError(err)
+	signer, err := NewKeyFileSigner(keyPath, nil)
+	req.NoError(err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("key revoked"))
+	}))
+	defer server.Close()
+
+	identity, err := NewHTTPClientWithSigner(server.URL, signer).AuthWhoami()
+	req.NoError(err)
+	req.Equal(getFingerprint(signer.PublicKey()), identity.Fingerprint)
+	req.Equal("ecdsa-sha2-nistp521", identity.KeyType)
+	req.Equal("unknown", identity.Status)
+	req.Contains(identity.StatusError, "401: key revoked")
+}
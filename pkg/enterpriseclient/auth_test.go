@@ -25,10 +25,10 @@ func Test_sigAndFingerprint(t *testing.T) {
 	// encode that private key to bytes
 	privateKeyBytes := encodePrivateKeyToPEM(privateKey)
 	// decode private key bytes
-	privateKey, err = decodePrivateKeyPEM(privateKeyBytes)
+	signer, err := NewKeySigner(privateKeyBytes, nil)
 	req.NoError(err)
 
-	_, sig, fingerprint, err := sigAndFingerprint(privateKey, []byte(testData))
+	_, sig, fingerprint, err := sigAndFingerprint(signer, []byte(testData))
 	req.NoError(err)
 
 	pubKey := encodePublicKey(&privateKey.PublicKey)
@@ -71,10 +71,10 @@ func Test_sigblockAndFingerprint(t *testing.T) {
 	// encode that private key to bytes
 	privateKeyBytes := encodePrivateKeyToPEM(privateKey)
 	// decode private key bytes
-	privateKey, err = decodePrivateKeyPEM(privateKeyBytes)
+	signer, err := NewKeySigner(privateKeyBytes, nil)
 	req.NoError(err)
 
-	sigBlock, _, fingerprint, err := sigAndFingerprint(privateKey, []byte(testData))
+	sigBlock, _, fingerprint, err := sigAndFingerprint(signer, []byte(testData))
 	req.NoError(err)
 
 	pubKey := encodePublicKey(&privateKey.PublicKey)
@@ -1,6 +1,7 @@
 package enterpriseclient
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -8,8 +9,12 @@ import (
 )
 
 func (c HTTPClient) ListChannels() ([]*enterprisetypes.Channel, error) {
+	return c.ListChannelsContext(context.Background())
+}
+
+func (c HTTPClient) ListChannelsContext(ctx context.Context) ([]*enterprisetypes.Channel, error) {
 	enterpriseChannels := []*enterprisetypes.Channel{}
-	err := c.doJSON("GET", "/v1/channels", 200, nil, &enterpriseChannels)
+	err := c.doJSON(ctx, "GET", "/v1/channels", 200, nil, &enterpriseChannels)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get channels")
 	}
@@ -18,6 +23,10 @@ func (c HTTPClient) ListChannels() ([]*enterprisetypes.Channel, error) {
 }
 
 func (c HTTPClient) CreateChannel(name string, description string) (*enterprisetypes.Channel, error) {
+	return c.CreateChannelContext(context.Background(), name, description)
+}
+
+func (c HTTPClient) CreateChannelContext(ctx context.Context, name string, description string) (*enterprisetypes.Channel, error) {
 	type CreateChannelRequest struct {
 		Name        string `json:"name"`
 		Description string `json:"description"`
@@ -28,7 +37,7 @@ func (c HTTPClient) CreateChannel(name string, description string) (*enterpriset
 	}
 
 	enterpriseChannel := enterprisetypes.Channel{}
-	err := c.doJSON("POST", "/v1/channel", 201, createChannelRequest, &enterpriseChannel)
+	err := c.doJSON(ctx, "POST", "/v1/channel", 201, createChannelRequest, &enterpriseChannel)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create channel")
 	}
@@ -37,6 +46,10 @@ func (c HTTPClient) CreateChannel(name string, description string) (*enterpriset
 }
 
 func (c HTTPClient) UpdateChannel(id string, name string, description string) (*enterprisetypes.Channel, error) {
+	return c.UpdateChannelContext(context.Background(), id, name, description)
+}
+
+func (c HTTPClient) UpdateChannelContext(ctx context.Context, id string, name string, description string) (*enterprisetypes.Channel, error) {
 	type UpdateChannelRequest struct {
 		Name        string `json:"name"`
 		Description string `json:"description"`
@@ -48,7 +61,7 @@ func (c HTTPClient) UpdateChannel(id string, name string, description string) (*
 
 	enterpriseChannel := enterprisetypes.Channel{}
 
-	err := c.doJSON("PUT", fmt.Sprintf("/v1/channel/%s", id), 200, updateChannelRequest, &enterpriseChannel)
+	err := c.doJSON(ctx, "PUT", fmt.Sprintf("/v1/channel/%s", id), 200, updateChannelRequest, &enterpriseChannel)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to update channel")
 	}
@@ -57,7 +70,11 @@ func (c HTTPClient) UpdateChannel(id string, name string, description string) (*
 }
 
 func (c HTTPClient) RemoveChannel(id string) error {
-	err := c.doJSON("DELETE", fmt.Sprintf("/v1/channel/%s", id), 204, nil, nil)
+	return c.RemoveChannelContext(context.Background(), id)
+}
+
+func (c HTTPClient) RemoveChannelContext(ctx context.Context, id string) error {
+	err := c.doJSON(ctx, "DELETE", fmt.Sprintf("/v1/channel/%s", id), 204, nil, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to delete channel")
 	}
@@ -66,7 +83,11 @@ func (c HTTPClient) RemoveChannel(id string) error {
 }
 
 func (c HTTPClient) AssignChannel(channelID string, teamID string) error {
-	err := c.doJSON("POST", fmt.Sprintf("/v1/teamchannel/%s/%s", channelID, teamID), 204, nil, nil)
+	return c.AssignChannelContext(context.Background(), channelID, teamID)
+}
+
+func (c HTTPClient) AssignChannelContext(ctx context.Context, channelID string, teamID string) error {
+	err := c.doJSON(ctx, "POST", fmt.Sprintf("/v1/teamchannel/%s/%s", channelID, teamID), 204, nil, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to assign channel")
 	}
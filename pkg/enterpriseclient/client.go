@@ -2,21 +2,23 @@ package enterpriseclient
 
 import (
 	"bytes"
-	"crypto/ecdsa"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 
 	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/transport"
 )
 
 const apiOrigin = "https://api.replicated.com/enterprise"
 
 // An HTTPClient communicates with the Replicated Enterprise HTTP API.
 type HTTPClient struct {
-	privateKey *ecdsa.PrivateKey
+	signer     Signer
 	apiOrigin  string
+	httpClient *http.Client
 }
 
 // New returns a new  HTTP client.
@@ -29,17 +31,31 @@ func NewHTTPClient(origin string, privateKeyContents []byte) *HTTPClient {
 		apiOrigin: origin,
 	}
 	if privateKeyContents != nil {
-		privateKey, err := decodePrivateKeyPEM(privateKeyContents)
-		if err != nil {
-			privateKey = nil
+		signer, err := NewKeySigner(privateKeyContents, nil)
+		if err == nil {
+			c.signer = signer
 		}
-		c.privateKey = privateKey
 	}
 
 	return c
 }
 
-func (c *HTTPClient) doJSON(method, path string, successStatus int, reqBody interface{}, respBody interface{}) error {
+// NewHTTPClientWithSigner returns a client that signs requests with signer,
+// which may be nil for requests that are not signed
+func NewHTTPClientWithSigner(origin string, signer Signer) *HTTPClient {
+	return &HTTPClient{
+		signer:    signer,
+		apiOrigin: origin,
+	}
+}
+
+// SetHTTPClient makes the client send requests with httpClient instead of
+// transport.DefaultClient
+func (c *HTTPClient) SetHTTPClient(httpClient *http.Client) {
+	c.httpClient = httpClient
+}
+
+func (c *HTTPClient) doJSON(ctx context.Context, method, path string, successStatus int, reqBody interface{}, respBody interface{}) error {
 	endpoint := fmt.Sprintf("%s%s", c.apiOrigin, path)
 	var bodyBytes []byte
 	if reqBody != nil {
@@ -50,24 +66,33 @@ func (c *HTTPClient) doJSON(method, path string, successStatus int, reqBody inte
 		}
 	}
 
-	req, err := http.NewRequest(method, endpoint, bytes.NewBuffer(bodyBytes))
+	if c.signer != nil {
+		// retries are signed again, a server that rejects replays would
+		// reject the same timestamp and nonce
+		sign := func(req *http.Request) error {
+			return c.sign(req, bodyBytes)
+		}
+		ctx = transport.WithRetryHook(ctx, sign)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewBuffer(bodyBytes))
 	if err != nil {
 		return err
 	}
 
-	if c.privateKey != nil {
-		sigWithNonce, sig, fingerprint, err := sigAndFingerprint(c.privateKey, bodyBytes)
-		if err != nil {
+	if c.signer != nil {
+		if err := c.sign(req, bodyBytes); err != nil {
 			return err
 		}
-		req.Header.Set("Signature", sig)
-		req.Header.Set("Authorization", fingerprint)
-		req.Header.Set("SignatureNonce", sigWithNonce)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
-	resp, err := http.DefaultClient.Do(req)
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = transport.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return errors.Wrap(err, "failed to do request")
 	}
@@ -87,3 +112,15 @@ func (c *HTTPClient) doJSON(method, path string, successStatus int, reqBody inte
 
 	return nil
 }
+
+// sign sets the signature headers of a request, with a new timestamp and nonce
+func (c *HTTPClient) sign(req *http.Request, body []byte) error {
+	sigWithNonce, sig, fingerprint, err := sigAndFingerprint(c.signer, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Signature", sig)
+	req.Header.Set("Authorization", fingerprint)
+	req.Header.Set("SignatureNonce", sigWithNonce)
+	return nil
+}
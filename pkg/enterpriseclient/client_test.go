@@ -1,16 +1,63 @@
 package enterpriseclient
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/replicatedhq/replicated/pkg/transport"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
 )
 
 func TestNewNilHTTPClient(t *testing.T) {
 	req := require.New(t)
 	client := NewHTTPClient("origin", nil)
 	req.Equal(&HTTPClient{
-		privateKey: nil,
-		apiOrigin:  "origin",
+		signer:    nil,
+		apiOrigin: "origin",
 	}, client)
 }
+
+func TestRetriesAreSignedAgain(t *testing.T) {
+	req := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	req.NoError(err)
+	signer, err := ssh.NewSignerFromKey(key)
+	req.NoError(err)
+
+	var mu sync.Mutex
+	var nonces []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		nonces = append(nonces, r.Header.Get("SignatureNonce"))
+		if len(nonces) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClientWithSigner(server.URL, signer)
+	client.SetHTTPClient(transport.NewClient(time.Second, 2))
+	_, err = client.ListChannelsContext(context.Background())
+	req.NoError(err)
+
+	req.Len(nonces, 3)
+	seen := map[string]bool{}
+	for _, nonce := range nonces {
+		req.NotEmpty(nonce)
+		req.False(seen[nonce], "nonce sent again")
+		seen[nonce] = true
+	}
+}
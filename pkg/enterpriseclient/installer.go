@@ -1,6 +1,7 @@
 package enterpriseclient
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -8,8 +9,12 @@ import (
 )
 
 func (c HTTPClient) ListInstallers() ([]*enterprisetypes.Installer, error) {
+	return c.ListInstallersContext(context.Background())
+}
+
+func (c HTTPClient) ListInstallersContext(ctx context.Context) ([]*enterprisetypes.Installer, error) {
 	enterpriseInstallers := []*enterprisetypes.Installer{}
-	err := c.doJSON("GET", "/v1/installers", 200, nil, &enterpriseInstallers)
+	err := c.doJSON(ctx, "GET", "/v1/installers", 200, nil, &enterpriseInstallers)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get installers")
 	}
@@ -18,6 +23,10 @@ func (c HTTPClient) ListInstallers() ([]*enterprisetypes.Installer, error) {
 }
 
 func (c HTTPClient) CreateInstaller(yaml string) (*enterprisetypes.Installer, error) {
+	return c.CreateInstallerContext(context.Background(), yaml)
+}
+
+func (c HTTPClient) CreateInstallerContext(ctx context.Context, yaml string) (*enterprisetypes.Installer, error) {
 	type CreateInstallerRequest struct {
 		Yaml string `json:"yaml"`
 	}
@@ -26,7 +35,7 @@ func (c HTTPClient) CreateInstaller(yaml string) (*enterprisetypes.Installer, er
 	}
 
 	enterpriseInstaller := enterprisetypes.Installer{}
-	err := c.doJSON("POST", "/v1/installer", 201, createInstallerRequest, &enterpriseInstaller)
+	err := c.doJSON(ctx, "POST", "/v1/installer", 201, createInstallerRequest, &enterpriseInstaller)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create installer")
 	}
@@ -35,6 +44,10 @@ func (c HTTPClient) CreateInstaller(yaml string) (*enterprisetypes.Installer, er
 }
 
 func (c HTTPClient) UpdateInstaller(id string, yaml string) (*enterprisetypes.Installer, error) {
+	return c.UpdateInstallerContext(context.Background(), id, yaml)
+}
+
+func (c HTTPClient) UpdateInstallerContext(ctx context.Context, id string, yaml string) (*enterprisetypes.Installer, error) {
 	type UpdateInstallerRequest struct {
 		ID   string `json:"id"`
 		Yaml string `json:"yaml"`
@@ -46,7 +59,7 @@ func (c HTTPClient) UpdateInstaller(id string, yaml string) (*enterprisetypes.In
 
 	enterpriseInstaller := enterprisetypes.Installer{}
 
-	err := c.doJSON("PUT", fmt.Sprintf("/v1/installer/%s", id), 200, updateInstallerRequest, &enterpriseInstaller)
+	err := c.doJSON(ctx, "PUT", fmt.Sprintf("/v1/installer/%s", id), 200, updateInstallerRequest, &enterpriseInstaller)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to update installer")
 	}
@@ -55,7 +68,11 @@ func (c HTTPClient) UpdateInstaller(id string, yaml string) (*enterprisetypes.In
 }
 
 func (c HTTPClient) RemoveInstaller(id string) error {
-	err := c.doJSON("DELETE", fmt.Sprintf("/v1/installer/%s", id), 204, nil, nil)
+	return c.RemoveInstallerContext(context.Background(), id)
+}
+
+func (c HTTPClient) RemoveInstallerContext(ctx context.Context, id string) error {
+	err := c.doJSON(ctx, "DELETE", fmt.Sprintf("/v1/installer/%s", id), 204, nil, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to delete installer")
 	}
@@ -64,7 +81,11 @@ func (c HTTPClient) RemoveInstaller(id string) error {
 }
 
 func (c HTTPClient) AssignInstaller(installerID string, channelID string) error {
-	err := c.doJSON("POST", fmt.Sprintf("/v1/channelinstaller/%s/%s", installerID, channelID), 204, nil, nil)
+	return c.AssignInstallerContext(context.Background(), installerID, channelID)
+}
+
+func (c HTTPClient) AssignInstallerContext(ctx context.Context, installerID string, channelID string) error {
+	err := c.doJSON(ctx, "POST", fmt.Sprintf("/v1/channelinstaller/%s/%s", installerID, channelID), 204, nil, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to assign installer")
 	}
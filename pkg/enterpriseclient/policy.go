@@ -1,6 +1,7 @@
 package enterpriseclient
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -8,8 +9,12 @@ import (
 )
 
 func (c HTTPClient) ListPolicies() ([]*enterprisetypes.Policy, error) {
+	return c.ListPoliciesContext(context.Background())
+}
+
+func (c HTTPClient) ListPoliciesContext(ctx context.Context) ([]*enterprisetypes.Policy, error) {
 	enterprisePolicies := []*enterprisetypes.Policy{}
-	err := c.doJSON("GET", "/v1/policies", 200, nil, &enterprisePolicies)
+	err := c.doJSON(ctx, "GET", "/v1/policies", 200, nil, &enterprisePolicies)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get policies")
 	}
@@ -18,6 +23,10 @@ func (c HTTPClient) ListPolicies() ([]*enterprisetypes.Policy, error) {
 }
 
 func (c HTTPClient) CreatePolicy(name string, description string, policy string) (*enterprisetypes.Policy, error) {
+	return c.CreatePolicyContext(context.Background(), name, description, policy)
+}
+
+func (c HTTPClient) CreatePolicyContext(ctx context.Context, name string, description string, policy string) (*enterprisetypes.Policy, error) {
 	type CreatePolicyRequest struct {
 		Name        string `json:"name"`
 		Description string `json:"description"`
@@ -30,7 +39,7 @@ func (c HTTPClient) CreatePolicy(name string, description string, policy string)
 	}
 
 	enterprisePolicy := enterprisetypes.Policy{}
-	err := c.doJSON("POST", "/v1/policy", 201, createPolicyRequest, &enterprisePolicy)
+	err := c.doJSON(ctx, "POST", "/v1/policy", 201, createPolicyRequest, &enterprisePolicy)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create policy")
 	}
@@ -39,6 +48,10 @@ func (c HTTPClient) CreatePolicy(name string, description string, policy string)
 }
 
 func (c HTTPClient) UpdatePolicy(id string, name string, description string, policy string) (*enterprisetypes.Policy, error) {
+	return c.UpdatePolicyContext(context.Background(), id, name, description, policy)
+}
+
+func (c HTTPClient) UpdatePolicyContext(ctx context.Context, id string, name string, description string, policy string) (*enterprisetypes.Policy, error) {
 	type UpdatePolicyRequest struct {
 		Name        string `json:"name"`
 		Description string `json:"description"`
@@ -52,7 +65,7 @@ func (c HTTPClient) UpdatePolicy(id string, name string, description string, pol
 
 	enterprisePolicy := enterprisetypes.Policy{}
 
-	err := c.doJSON("PUT", fmt.Sprintf("/v1/policy/%s", id), 200, updatePolicyRequest, &enterprisePolicy)
+	err := c.doJSON(ctx, "PUT", fmt.Sprintf("/v1/policy/%s", id), 200, updatePolicyRequest, &enterprisePolicy)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to update policy")
 	}
@@ -61,7 +74,11 @@ func (c HTTPClient) UpdatePolicy(id string, name string, description string, pol
 }
 
 func (c HTTPClient) RemovePolicy(id string) error {
-	err := c.doJSON("DELETE", fmt.Sprintf("/v1/policy/%s", id), 204, nil, nil)
+	return c.RemovePolicyContext(context.Background(), id)
+}
+
+func (c HTTPClient) RemovePolicyContext(ctx context.Context, id string) error {
+	err := c.doJSON(ctx, "DELETE", fmt.Sprintf("/v1/policy/%s", id), 204, nil, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to delete policy")
 	}
@@ -70,7 +87,11 @@ func (c HTTPClient) RemovePolicy(id string) error {
 }
 
 func (c HTTPClient) AssignPolicy(policyID string, channelID string) error {
-	err := c.doJSON("POST", fmt.Sprintf("/v1/channelpolicy/%s/%s", policyID, channelID), 204, nil, nil)
+	return c.AssignPolicyContext(context.Background(), policyID, channelID)
+}
+
+func (c HTTPClient) AssignPolicyContext(ctx context.Context, policyID string, channelID string) error {
+	err := c.doJSON(ctx, "POST", fmt.Sprintf("/v1/channelpolicy/%s/%s", policyID, channelID), 204, nil, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to assign policy")
 	}
@@ -79,7 +100,11 @@ func (c HTTPClient) AssignPolicy(policyID string, channelID string) error {
 }
 
 func (c HTTPClient) UnassignPolicy(policyID string, channelID string) error {
-	err := c.doJSON("DELETE", fmt.Sprintf("/v1/channelpolicy/%s/%s", policyID, channelID), 204, nil, nil)
+	return c.UnassignPolicyContext(context.Background(), policyID, channelID)
+}
+
+func (c HTTPClient) UnassignPolicyContext(ctx context.Context, policyID string, channelID string) error {
+	err := c.doJSON(ctx, "DELETE", fmt.Sprintf("/v1/channelpolicy/%s/%s", policyID, channelID), 204, nil, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to unassign policy")
 	}
@@ -0,0 +1,221 @@
+package enterpriseclient
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// A Signer signs enterprise API requests. It has the same methods as
+// ssh.Signer, so keys held by ssh-agent can be used directly. The API only
+// accepts ECDSA keys.
+type Signer interface {
+	PublicKey() ssh.PublicKey
+	Sign(rand io.Reader, data []byte) (*ssh.Signature, error)
+}
+
+// A PassphraseFunc is called for the passphrase of an encrypted private key,
+// only when one is needed
+type PassphraseFunc func() ([]byte, error)
+
+// NewSigner returns the signer selected by a --private-key URI:
+//
+//	/path/to/key, file:///path/to/key   a PEM encoded key, which may be passphrase encrypted
+//	ssh-agent://                        the first ECDSA key in the agent at $SSH_AUTH_SOCK
+//	ssh-agent:///path/to/socket         the first ECDSA key in the agent listening on the socket
+//	exec:///path/to/command?arg=a       an external signer command, see NewCommandSigner
+//
+// ssh-agent URIs accept a fingerprint query parameter, such as
+// ?fingerprint=SHA256:..., to choose a key other than the first.
+func NewSigner(uri string, passphrase PassphraseFunc) (Signer, error) {
+	if filename, ok := KeyFilePath(uri); ok {
+		return NewKeyFileSigner(filename, passphrase)
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse private key URI")
+	}
+	switch u.Scheme {
+	case "ssh-agent":
+		socket := u.Path
+		if socket == "" {
+			socket = os.Getenv("SSH_AUTH_SOCK")
+			if socket == "" {
+				return nil, errors.New("SSH_AUTH_SOCK is not set, use ssh-agent:///path/to/socket")
+			}
+		}
+		// base64 fingerprints contain '+', which query parsing turns into a space
+		fingerprint := strings.ReplaceAll(u.Query().Get("fingerprint"), " ", "+")
+		return NewAgentSigner(socket, fingerprint)
+	case "exec":
+		if u.Path == "" {
+			return nil, errors.New("exec signer URI must include the command path, such as exec:///usr/local/bin/signer")
+		}
+		return NewCommandSigner(append([]string{u.Path}, u.Query()["arg"]...))
+	default:
+		return nil, errors.Errorf("unsupported private key URI scheme %q", u.Scheme)
+	}
+}
+
+// KeyFilePath returns the path of the key file a --private-key URI refers
+// to, and false for keys held in ssh-agent or by a signer command
+func KeyFilePath(uri string) (string, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || (u.Scheme != "ssh-agent" && u.Scheme != "exec" && u.Scheme != "file") {
+		// anything else, including Windows paths, is a key file
+		return uri, true
+	}
+	if u.Scheme == "file" {
+		return u.Path, true
+	}
+	return "", false
+}
+
+// NewKeyFileSigner reads a PEM encoded private key from a file
+func NewKeyFileSigner(filename string, passphrase PassphraseFunc) (Signer, error) {
+	pemBytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Errorf("file %s does not exist", filename)
+		}
+		return nil, err
+	}
+	return NewKeySigner(pemBytes, passphrase)
+}
+
+// NewKeySigner returns a signer for a PEM encoded ECDSA private key. Keys
+// encrypted with a passphrase, either legacy encrypted PEM or the OpenSSH
+// format, call passphrase to decrypt them.
+func NewKeySigner(pemBytes []byte, passphrase PassphraseFunc) (Signer, error) {
+	key, err := ssh.ParseRawPrivateKey(pemBytes)
+	if _, ok := err.(*ssh.PassphraseMissingError); ok {
+		if passphrase == nil {
+			return nil, errors.New("private key is encrypted and no passphrase was provided")
+		}
+		p, err := passphrase()
+		if err != nil {
+			return nil, errors.Wrap(err, "get private key passphrase")
+		}
+		key, err = ssh.ParseRawPrivateKeyWithPassphrase(pemBytes, p)
+		if err != nil {
+			return nil, errors.Wrap(err, "decrypt private key")
+		}
+	} else if err != nil {
+		return nil, errors.Wrap(err, "parse private key")
+	}
+
+	ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.Errorf("private key is a %T, not an ECDSA key", key)
+	}
+	return ssh.NewSignerFromKey(ecdsaKey)
+}
+
+// NewAgentSigner returns a signer for an ECDSA key held by the ssh-agent
+// listening on socket. With an empty fingerprint the first ECDSA key is used.
+func NewAgentSigner(socket string, fingerprint string) (Signer, error) {
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, errors.Wrapf(err, "connect to ssh-agent at %s", socket)
+	}
+
+	// the connection stays open for the signer to use
+	signers, err := agent.NewClient(conn).Signers()
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "list ssh-agent keys")
+	}
+
+	for _, signer := range signers {
+		if !isECDSAKey(signer.PublicKey()) {
+			continue
+		}
+		if fingerprint == "" || ssh.FingerprintSHA256(signer.PublicKey()) == fingerprint {
+			return signer, nil
+		}
+	}
+
+	conn.Close()
+	if fingerprint != "" {
+		return nil, errors.Errorf("ssh-agent has no ECDSA key with fingerprint %s", fingerprint)
+	}
+	return nil, errors.New("ssh-agent has no ECDSA keys")
+}
+
+// commandSigner signs with an external command, for keys in hardware tokens
+// and key management services
+type commandSigner struct {
+	command   []string
+	publicKey ssh.PublicKey
+}
+
+// NewCommandSigner returns a signer that runs an external command. The
+// command is run with the extra argument "public-key" to print its ECDSA
+// public key in authorized_keys format, and with "sign" to sign the data
+// on stdin, printing the base64 encoded ssh wire format signature.
+func NewCommandSigner(command []string) (Signer, error) {
+	out, err := runSignerCommand(command, "public-key", nil)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey(out)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse signer command public key")
+	}
+	if !isECDSAKey(publicKey) {
+		return nil, errors.Errorf("signer command key is %s, not an ECDSA key", publicKey.Type())
+	}
+
+	return &commandSigner{
+		command:   command,
+		publicKey: publicKey,
+	}, nil
+}
+
+func (s *commandSigner) PublicKey() ssh.PublicKey {
+	return s.publicKey
+}
+
+func (s *commandSigner) Sign(_ io.Reader, data []byte) (*ssh.Signature, error) {
+	out, err := runSignerCommand(s.command, "sign", data)
+	if err != nil {
+		return nil, err
+	}
+	wire, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(out)))
+	if err != nil {
+		return nil, errors.Wrap(err, "decode signer command signature")
+	}
+	signature := &ssh.Signature{}
+	if err := ssh.Unmarshal(wire, signature); err != nil {
+		return nil, errors.Wrap(err, "parse signer command signature")
+	}
+	return signature, nil
+}
+
+func runSignerCommand(command []string, action string, stdin []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(command[0], append(command[1:], action)...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "run signer command %s %s: %s", command[0], action, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+func isECDSAKey(publicKey ssh.PublicKey) bool {
+	return strings.HasPrefix(publicKey.Type(), "ecdsa-sha2-")
+}
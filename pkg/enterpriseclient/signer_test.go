@@ -0,0 +1,230 @@
+package enterpriseclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// requireSigns checks that signer produces signatures the API accepts
+func requireSigns(t *testing.T, signer Signer) {
+	req := require.New(t)
+	data := []byte(`{"name":"test"}`)
+
+	sigBlock, sig, fingerprint, err := sigAndFingerprint(signer, data)
+	req.NoError(err)
+	req.Equal(ssh.FingerprintSHA256(signer.PublicKey()), fingerprint)
+
+	valid, _, err := ValidatePayload(signer.PublicKey(), sig, "", data)
+	req.NoError(err)
+	req.True(valid)
+
+	valid, nonce, err := ValidatePayload(signer.PublicKey(), "", sigBlock, data)
+	req.NoError(err)
+	req.True(valid)
+	req.NotEmpty(nonce)
+}
+
+func TestKeySigner(t *testing.T) {
+	privateKey, err := generatePrivateKey()
+	require.NoError(t, err)
+	privatePEM := encodePrivateKeyToPEM(privateKey)
+	encryptedPEM, err := encryptPrivateKeyPEM(privatePEM, []byte("hunter2"))
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+
+	passphrase := func(p string) PassphraseFunc {
+		return func() ([]byte, error) {
+			return []byte(p), nil
+		}
+	}
+
+	tests := []struct {
+		name       string
+		pem        []byte
+		passphrase PassphraseFunc
+		wantErr    string
+	}{
+		{
+			name: "plain",
+			pem:  privatePEM,
+		},
+		{
+			name:       "plain ignores passphrase",
+			pem:        privatePEM,
+			passphrase: passphrase("unused"),
+		},
+		{
+			name:       "encrypted",
+			pem:        encryptedPEM,
+			passphrase: passphrase("hunter2"),
+		},
+		{
+			name:    "encrypted without passphrase",
+			pem:     encryptedPEM,
+			wantErr: "private key is encrypted and no passphrase was provided",
+		},
+		{
+			name:       "encrypted with wrong passphrase",
+			pem:        encryptedPEM,
+			passphrase: passphrase("wrong"),
+			wantErr:    "decrypt private key: x509: decryption password incorrect",
+		},
+		{
+			name:    "rsa",
+			pem:     rsaPEM,
+			wantErr: "private key is a *rsa.PrivateKey, not an ECDSA key",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signer, err := NewKeySigner(test.pem, test.passphrase)
+			if test.wantErr != "" {
+				require.EqualError(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			requireSigns(t, signer)
+		})
+	}
+}
+
+func TestAgentSigner(t *testing.T) {
+	req := require.New(t)
+
+	dir, err := ioutil.TempDir("", "enterpriseclient")
+	req.NoError(err)
+	defer os.RemoveAll(dir)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	req.NoError(err)
+	first, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	req.NoError(err)
+	second, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	req.NoError(err)
+
+	keyring := agent.NewKeyring()
+	for _, key := range []interface{}{rsaKey, first, second} {
+		req.NoError(keyring.Add(agent.AddedKey{PrivateKey: key}))
+	}
+
+	socket := filepath.Join(dir, "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	req.NoError(err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go agent.ServeAgent(keyring, conn)
+		}
+	}()
+
+	// the first ECDSA key is used by default
+	signer, err := NewSigner("ssh-agent://"+socket, nil)
+	req.NoError(err)
+	firstPublicKey, err := ssh.NewPublicKey(&first.PublicKey)
+	req.NoError(err)
+	req.Equal(firstPublicKey.Marshal(), signer.PublicKey().Marshal())
+	requireSigns(t, signer)
+
+	secondPublicKey, err := ssh.NewPublicKey(&second.PublicKey)
+	req.NoError(err)
+	signer, err = NewSigner("ssh-agent://"+socket+"?fingerprint="+ssh.FingerprintSHA256(secondPublicKey), nil)
+	req.NoError(err)
+	req.Equal(secondPublicKey.Marshal(), signer.PublicKey().Marshal())
+	requireSigns(t, signer)
+
+	_, err = NewSigner("ssh-agent://"+socket+"?fingerprint=SHA256:missing", nil)
+	req.EqualError(err, "ssh-agent has no ECDSA key with fingerprint SHA256:missing")
+}
+
+// TestSignerCommandProcess is not a real test, it is the external signer
+// command run by TestCommandSigner
+func TestSignerCommandProcess(t *testing.T) {
+	keyPEM := os.Getenv("TEST_SIGNER_KEY")
+	if keyPEM == "" {
+		return
+	}
+	signer, err := NewKeySigner([]byte(keyPEM), nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	switch os.Args[len(os.Args)-1] {
+	case "public-key":
+		os.Stdout.Write(ssh.MarshalAuthorizedKey(signer.PublicKey()))
+	case "sign":
+		data, _ := ioutil.ReadAll(os.Stdin)
+		sig, err := signer.Sign(rand.Reader, data)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println(base64.StdEncoding.EncodeToString(ssh.Marshal(sig)))
+	default:
+		fmt.Fprintln(os.Stderr, "unknown action")
+		os.Exit(2)
+	}
+	os.Exit(0)
+}
+
+func TestCommandSigner(t *testing.T) {
+	req := require.New(t)
+
+	privateKey, err := generatePrivateKey()
+	req.NoError(err)
+	os.Setenv("TEST_SIGNER_KEY", string(encodePrivateKeyToPEM(privateKey)))
+	defer os.Unsetenv("TEST_SIGNER_KEY")
+
+	signer, err := NewSigner("exec://"+os.Args[0]+"?arg=-test.run=TestSignerCommandProcess&arg=--", nil)
+	req.NoError(err)
+
+	publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	req.NoError(err)
+	req.Equal(publicKey.Marshal(), signer.PublicKey().Marshal())
+	requireSigns(t, signer)
+}
+
+func TestNewSignerFile(t *testing.T) {
+	req := require.New(t)
+
+	dir, err := ioutil.TempDir("", "enterpriseclient")
+	req.NoError(err)
+	defer os.RemoveAll(dir)
+
+	privateKey, err := generatePrivateKey()
+	req.NoError(err)
+	keyPath := filepath.Join(dir, "ecdsa")
+	req.NoError(ioutil.WriteFile(keyPath, encodePrivateKeyToPEM(privateKey), 0600))
+
+	for _, uri := range []string{keyPath, "file://" + keyPath} {
+		signer, err := NewSigner(uri, nil)
+		req.NoError(err)
+		requireSigns(t, signer)
+	}
+
+	_, err = NewSigner(filepath.Join(dir, "missing"), nil)
+	req.EqualError(err, fmt.Sprintf("file %s does not exist", filepath.Join(dir, "missing")))
+}
@@ -0,0 +1,27 @@
+package enterprisetypes
+
+import "time"
+
+const (
+	AuthKeyStatusPending  = "pending"
+	AuthKeyStatusApproved = "approved"
+	AuthKeyStatusRevoked  = "revoked"
+)
+
+type AuthKey struct {
+	Fingerprint string     `json:"fingerprint"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// AuthIdentity is the key the CLI signs requests with
+type AuthIdentity struct {
+	Fingerprint string `json:"fingerprint"`
+	KeyType     string `json:"keyType"`
+	// Status is the key's status on the server, or unknown if the server
+	// does not list it
+	Status string `json:"status"`
+	// StatusError is why the status could not be looked up on the server
+	StatusError string `json:"statusError,omitempty"`
+}
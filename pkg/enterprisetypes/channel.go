@@ -1,7 +1,7 @@
 package enterprisetypes
 
 type Channel struct {
-	ID          string
-	Name        string
-	Description string
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
 }
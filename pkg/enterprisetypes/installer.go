@@ -1,6 +1,6 @@
 package enterprisetypes
 
 type Installer struct {
-	ID   string
-	Yaml string
+	ID   string `json:"id"`
+	Yaml string `json:"yaml"`
 }
@@ -1,8 +1,8 @@
 package enterprisetypes
 
 type Policy struct {
-	ID          string
-	Name        string
-	Description string
-	Policy      string
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Policy      string `json:"policy"`
 }
@@ -0,0 +1,368 @@
+// Package fakeapi is an HTTP server that fakes the parts of the Vendor API
+// the CLI uses, so the CLI and the e2e tests can run without a Replicated
+// account:
+//
+//	server, _ := fakeapi.NewServer(fakeapi.Options{Token: "test"})
+//	ts := httptest.NewServer(server)
+//	os.Setenv("REPLICATED_API_ORIGIN", ts.URL)
+//	os.Setenv("REPLICATED_GRAPHQL_ORIGIN", ts.URL+"/graphql")
+//
+// It serves the v3 KOTS endpoints for apps, channels, releases, customers,
+// licenses and installers, the v1 endpoints for platform apps' channels and
+// releases, and the GraphQL operations for ship apps' channels and releases.
+//
+// State is kept in memory and, when Options.StatePath is set, written to a
+// JSON file after every change and loaded from it on start.
+package fakeapi
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	AppTypeKots     = "kots"
+	AppTypePlatform = "platform"
+	AppTypeShip     = "ship"
+)
+
+type Options struct {
+	// StatePath is a JSON file the state is loaded from and saved to. The
+	// state is only kept in memory when it is empty.
+	StatePath string
+	// Token is the API token requests must send. Any token is accepted when
+	// it is empty.
+	Token string
+}
+
+// Server is an http.Handler serving the fake API. It is safe for concurrent
+// use.
+type Server struct {
+	opts   Options
+	routes []route
+
+	mu      sync.Mutex
+	state   State
+	uploads map[string][]byte
+	changed bool
+	now     func() time.Time
+}
+
+func NewServer(opts Options) (*Server, error) {
+	s := &Server{
+		opts:    opts,
+		uploads: map[string][]byte{},
+		now:     time.Now,
+	}
+	s.routes = append(s.kotsRoutes(), s.platformRoutes()...)
+
+	if opts.StatePath != "" {
+		data, err := ioutil.ReadFile(opts.StatePath)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, errors.Wrap(err, "read state file")
+		}
+		if err == nil {
+			if err := json.Unmarshal(data, &s.state); err != nil {
+				return nil, errors.Wrapf(err, "parse state file %s", opts.StatePath)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// SetNow sets the clock used for created timestamps
+func (s *Server) SetNow(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// AddApp creates an app of type AppTypeKots, AppTypePlatform or AppTypeShip
+// with the default Stable, Beta and Unstable channels
+func (s *Server) AddApp(name string, appType string) (*App, error) {
+	if appType != AppTypeKots && appType != AppTypePlatform && appType != AppTypeShip {
+		return nil, errors.Errorf("unknown app type %q", appType)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	app := s.addApp(name, appType)
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	copied := *app
+	return &copied, nil
+}
+
+// State returns a copy of everything the server stores
+func (s *Server) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var state State
+	data, _ := json.Marshal(s.state)
+	_ = json.Unmarshal(data, &state)
+	return state
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// uploads go to a pre-signed URL and are not authenticated, like S3
+	if r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, uploadPath) {
+		s.handleUpload(w, r)
+		return
+	}
+
+	if s.opts.Token != "" && r.Header.Get("Authorization") != s.opts.Token {
+		writeResponse(w, fail(http.StatusUnauthorized, "invalid token"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.changed = false
+	resp := s.dispatch(r)
+	if s.changed {
+		if err := s.save(); err != nil {
+			resp = fail(http.StatusInternalServerError, err.Error())
+		}
+	}
+	writeResponse(w, resp)
+}
+
+func (s *Server) dispatch(r *http.Request) response {
+	if r.Method == http.MethodPost && r.URL.Path == "/graphql" {
+		return s.handleGraphQL(r)
+	}
+
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	methodAllowed := false
+	for _, route := range s.routes {
+		params, ok := route.match(path)
+		if !ok {
+			continue
+		}
+		if route.method != r.Method {
+			methodAllowed = true
+			continue
+		}
+		return route.handler(r, params)
+	}
+	if methodAllowed {
+		return fail(http.StatusMethodNotAllowed, "%s %s is not supported", r.Method, r.URL.Path)
+	}
+	return fail(http.StatusNotFound, "%s %s is not supported by the fake API", r.Method, r.URL.Path)
+}
+
+// save writes the state file, s.mu must be held
+func (s *Server) save() error {
+	if s.opts.StatePath == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal state")
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.opts.StatePath), ".fakeapi-state-")
+	if err != nil {
+		return errors.Wrap(err, "create temp state file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "write temp state file")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "close temp state file")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), s.opts.StatePath), "rename state file")
+}
+
+// addApp creates an app and its default channels, s.mu must be held
+func (s *Server) addApp(name string, appType string) *App {
+	app := &App{
+		ID:      id(),
+		Name:    name,
+		Slug:    s.appSlug(name),
+		Type:    appType,
+		Created: s.now().UTC(),
+	}
+	for _, channelName := range []string{"Stable", "Beta", "Unstable"} {
+		s.addChannel(app, channelName, "")
+	}
+	if appType == AppTypeKots {
+		// like the Vendor API, KOTS apps start with a default installer
+		s.addInstaller(app, defaultInstallerYAML)
+	}
+	s.state.Apps = append(s.state.Apps, app)
+	return app
+}
+
+// addInstaller creates an installer with the next sequence, s.mu must be held
+func (s *Server) addInstaller(app *App, yaml string) *Installer {
+	installer := &Installer{
+		Sequence: int64(len(app.Installers) + 1),
+		YAML:     yaml,
+		Created:  s.now().UTC(),
+	}
+	app.Installers = append(app.Installers, installer)
+	return installer
+}
+
+// addChannel creates a channel, s.mu must be held
+func (s *Server) addChannel(app *App, name string, description string) *Channel {
+	channel := &Channel{
+		ID:          id(),
+		Name:        name,
+		Description: description,
+		Slug:        slug(name),
+		Created:     s.now().UTC(),
+	}
+	app.Channels = append(app.Channels, channel)
+	return channel
+}
+
+const idChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// id returns a new random ID. Like the Vendor API's IDs they are 27
+// alphanumeric characters, which the e2e tests expect, and they are not
+// reused after a restart, so IDs the CLI cached from an earlier run are not
+// found.
+func id() string {
+	b := make([]byte, 27)
+	if _, err := rand.Read(b); err != nil {
+		panic(errors.Wrap(err, "read random bytes"))
+	}
+	for i := range b {
+		b[i] = idChars[int(b[i])%len(idChars)]
+	}
+	return string(b)
+}
+
+// app finds an app of the type by ID or slug, s.mu must be held
+func (s *Server) app(appIDOrSlug string, appType string) *App {
+	for _, app := range s.state.Apps {
+		if app.Type == appType && (app.ID == appIDOrSlug || app.Slug == appIDOrSlug) {
+			return app
+		}
+	}
+	return nil
+}
+
+// appSlug returns a slug for the name that no other app has, s.mu must be held
+func (s *Server) appSlug(name string) string {
+	base := slug(name)
+	candidate := base
+	for i := 2; ; i++ {
+		taken := false
+		for _, app := range s.state.Apps {
+			if app.Slug == candidate {
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+const defaultInstallerYAML = `apiVersion: kurl.sh/v1beta1
+kind: Installer
+metadata:
+  name: latest
+spec:
+  kubernetes:
+    version: latest
+`
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slug(name string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+var semverPattern = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+// response is what a handler returns; the body is written as JSON unless it
+// is a []byte
+type response struct {
+	status int
+	body   interface{}
+}
+
+type errorBody struct {
+	Message string `json:"message"`
+}
+
+func fail(status int, format string, args ...interface{}) response {
+	return response{status: status, body: errorBody{Message: fmt.Sprintf(format, args...)}}
+}
+
+func writeResponse(w http.ResponseWriter, resp response) {
+	if raw, ok := resp.body.([]byte); ok {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(resp.status)
+		_, _ = w.Write(raw)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.status)
+	if resp.body != nil && resp.status != http.StatusNoContent {
+		_ = json.NewEncoder(w).Encode(resp.body)
+	}
+}
+
+type route struct {
+	method string
+	// pattern is the path's segments, those starting with ':' are parameters
+	pattern []string
+	handler func(r *http.Request, params map[string]string) response
+}
+
+func newRoute(method string, pattern string, handler func(r *http.Request, params map[string]string) response) route {
+	return route{
+		method:  method,
+		pattern: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handler: handler,
+	}
+}
+
+func (rt route) match(path []string) (map[string]string, bool) {
+	if len(path) != len(rt.pattern) {
+		return nil, false
+	}
+	params := map[string]string{}
+	for i, segment := range rt.pattern {
+		if strings.HasPrefix(segment, ":") {
+			params[segment[1:]] = path[i]
+			continue
+		}
+		if segment != path[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func decodeBody(r *http.Request, v interface{}) error {
+	return errors.Wrap(json.NewDecoder(r.Body).Decode(v), "decode request body")
+}
@@ -0,0 +1,307 @@
+package fakeapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	channels "github.com/replicatedhq/replicated/gen/go/v1"
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
+	"github.com/replicatedhq/replicated/pkg/platformclient"
+	"github.com/replicatedhq/replicated/pkg/sdk"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, opts Options) (*Server, *sdk.Client) {
+	server, err := NewServer(opts)
+	require.NoError(t, err)
+	server.SetNow(func() time.Time { return time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC) })
+
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	client, err := sdk.New(
+		sdk.WithAPIToken("token"),
+		sdk.WithAPIOrigin(ts.URL),
+		sdk.WithGraphQLOrigin(ts.URL+"/graphql"),
+	)
+	require.NoError(t, err)
+	return server, client
+}
+
+func TestKotsApp(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server, client := newTestServer(t, Options{Token: "token"})
+	_, err := server.AddApp("My App", AppTypeKots)
+	req.NoError(err)
+
+	app, err := client.Apps.Get(ctx, "my-app")
+	req.NoError(err)
+	req.Equal("kots", app.Type)
+
+	channels, err := app.Channels.List(ctx)
+	req.NoError(err)
+	req.Len(channels, 3)
+
+	beta, err := app.Channels.Create(ctx, "Beta 2", "second beta")
+	req.NoError(err)
+
+	spec := `[{"name":"config.yaml","path":"config.yaml","content":"kind: Config"}]`
+	release, err := app.Releases.Create(ctx, spec)
+	req.NoError(err)
+	req.EqualValues(1, release.Sequence)
+
+	req.NoError(app.Releases.Update(ctx, 1, spec+"\n"))
+	req.NoError(app.Releases.Promote(ctx, 1, sdk.PromoteOptions{
+		ChannelIDs:   []string{beta.ID},
+		VersionLabel: "1.0.0",
+		ReleaseNotes: "first",
+	}))
+	req.Error(app.Releases.Update(ctx, 1, spec), "promoted releases are not editable")
+
+	releases, err := app.Releases.List(ctx)
+	req.NoError(err)
+	req.Len(releases, 1)
+	req.False(releases[0].Editable)
+	req.Len(releases[0].ActiveChannels, 1)
+	req.Equal("Beta 2", releases[0].ActiveChannels[0].Name)
+
+	beta, err = app.Channels.Find(ctx, "Beta 2")
+	req.NoError(err)
+	req.EqualValues(1, beta.ReleaseSequence)
+	req.Equal("1.0.0", beta.ReleaseLabel)
+
+	customer, err := app.Customers.Create(ctx, types.CustomerOptions{
+		Name:            "Acme",
+		ChannelID:       beta.ID,
+		IsAirgapEnabled: true,
+	})
+	req.NoError(err)
+	req.Equal("dev", customer.Type)
+	req.Equal("Beta 2", customer.Channels[0].Name)
+
+	req.Error(app.Channels.Archive(ctx, beta.ID), "channels with customers cannot be archived")
+	req.NoError(app.Customers.Archive(ctx, customer.ID))
+	customer, err = app.Customers.Get(ctx, customer.ID)
+	req.NoError(err)
+	req.True(customer.IsArchived)
+
+	license, err := app.Customers.DownloadLicense(ctx, customer.ID)
+	req.NoError(err)
+	req.Contains(string(license), `customerName: "Acme"`)
+	req.Contains(string(license), "isAirgapSupported: true")
+
+	// new apps have a default installer
+	installer, err := app.Installers.Create(ctx, "kind: Installer")
+	req.NoError(err)
+	req.EqualValues(2, installer.Sequence)
+	req.NoError(app.Installers.Promote(ctx, installer.Sequence, beta.ID, "1.0.0"))
+	installers, err := app.Installers.List(ctx)
+	req.NoError(err)
+	req.Len(installers, 2)
+	req.False(installers[0].Immutable)
+	req.True(installers[1].Immutable)
+	req.Equal(beta.ID, installers[1].ActiveChannels[0].ID)
+
+	req.NoError(app.Channels.Archive(ctx, channels[2].ID))
+	channels, err = app.Channels.List(ctx)
+	req.NoError(err)
+	req.Len(channels, 3)
+}
+
+func TestShipApp(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server, client := newTestServer(t, Options{})
+	_, err := server.AddApp("Ship App", AppTypeShip)
+	req.NoError(err)
+
+	app, err := client.Apps.Get(ctx, "ship-app")
+	req.NoError(err)
+	req.Equal("ship", app.Type)
+
+	channel, err := app.Channels.Create(ctx, "Nightly", "")
+	req.NoError(err)
+
+	release, err := app.Releases.Create(ctx, "assets: {}")
+	req.NoError(err)
+	req.EqualValues(1, release.Sequence)
+	req.NoError(app.Releases.Promote(ctx, 1, sdk.PromoteOptions{
+		ChannelIDs:   []string{channel.ID},
+		VersionLabel: "nightly-1",
+	}))
+
+	releases, err := app.Releases.List(ctx)
+	req.NoError(err)
+	req.Len(releases, 1)
+	req.Equal("Nightly", releases[0].ActiveChannels[0].Name)
+
+	channel, err = app.Channels.Find(ctx, "Nightly")
+	req.NoError(err)
+	req.Equal("nightly-1", channel.ReleaseLabel)
+
+	req.Equal("assets: {}", server.State().Apps[0].Releases[0].Spec)
+
+	_, err = app.Customers.List(ctx)
+	req.Error(err)
+}
+
+func TestPlatformApp(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	server, client := newTestServer(t, Options{})
+	_, err := server.AddApp("Platform App", AppTypePlatform)
+	req.NoError(err)
+
+	app, err := client.Apps.Get(ctx, "platform-app")
+	req.NoError(err)
+	req.Equal("platform", app.Type)
+
+	channel, err := app.Channels.Create(ctx, "Nightly", "")
+	req.NoError(err)
+
+	release, err := app.Releases.Create(ctx, "components: []")
+	req.NoError(err)
+	req.EqualValues(1, release.Sequence)
+	req.NoError(app.Releases.Promote(ctx, 1, sdk.PromoteOptions{
+		ChannelIDs:   []string{channel.ID},
+		VersionLabel: "nightly-1",
+		Required:     true,
+	}))
+	req.Error(app.Releases.Update(ctx, 1, "components: [{}]"))
+
+	releases, err := app.Releases.List(ctx)
+	req.NoError(err)
+	req.Len(releases, 1)
+	req.Equal("Nightly", releases[0].ActiveChannels[0].Name)
+
+	channel, err = app.Channels.Find(ctx, "Nightly")
+	req.NoError(err)
+	req.Equal("nightly-1", channel.ReleaseLabel)
+
+	stored := server.State().Apps[0]
+	req.Equal("components: []", stored.Releases[0].Spec)
+	req.True(stored.Channels[3].Releases[0].Required)
+
+	// the default channel stays, archived channels are not listed
+	req.Error(app.Channels.Archive(ctx, stored.Channels[0].ID))
+	req.NoError(app.Channels.Archive(ctx, channel.ID))
+	channels, err := app.Channels.List(ctx)
+	req.NoError(err)
+	req.Len(channels, 3)
+
+	_, err = app.Customers.List(ctx)
+	req.Error(err)
+}
+
+func TestSemverRequired(t *testing.T) {
+	req := require.New(t)
+
+	server, err := NewServer(Options{})
+	req.NoError(err)
+	app, err := server.AddApp("My App", AppTypeKots)
+	req.NoError(err)
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	kots := &kotsclient.VendorV3Client{HTTPClient: *platformclient.NewHTTPClient(ts.URL, "")}
+	stable := app.Channels[0]
+	err = kots.UpdateSemanticVersioning(app.ID, &channels.AppChannel{Id: stable.ID, Name: stable.Name}, true)
+	req.NoError(err)
+
+	_, err = kots.CreateRelease(app.ID, "[]")
+	req.NoError(err)
+
+	tests := []struct {
+		label   string
+		wantErr bool
+	}{
+		{label: "not-semver", wantErr: true},
+		{label: "1.0", wantErr: true},
+		{label: "v1.2.3-beta.1", wantErr: false},
+		{label: "1.2.3", wantErr: false},
+	}
+	for _, test := range tests {
+		err := kots.PromoteRelease(app.ID, test.label, "", 1, stable.ID)
+		if test.wantErr {
+			req.Error(err, test.label)
+			req.Contains(err.Error(), "requires a semantic version")
+		} else {
+			req.NoError(err, test.label)
+		}
+	}
+}
+
+func TestToken(t *testing.T) {
+	req := require.New(t)
+
+	server, err := NewServer(Options{Token: "token"})
+	req.NoError(err)
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	for token, status := range map[string]int{"": http.StatusUnauthorized, "other": http.StatusUnauthorized, "token": http.StatusOK} {
+		request, err := http.NewRequest("GET", ts.URL+"/v3/apps", nil)
+		req.NoError(err)
+		request.Header.Set("Authorization", token)
+		resp, err := http.DefaultClient.Do(request)
+		req.NoError(err)
+		resp.Body.Close()
+		req.Equal(status, resp.StatusCode, "token %q", token)
+	}
+}
+
+func TestStatePath(t *testing.T) {
+	req := require.New(t)
+	statePath := filepath.Join(t.TempDir(), "state.json")
+
+	server, client := newTestServer(t, Options{StatePath: statePath})
+	_, err := server.AddApp("My App", AppTypeKots)
+	req.NoError(err)
+
+	app, err := client.Apps.Get(context.Background(), "my-app")
+	req.NoError(err)
+	_, err = app.Releases.Create(context.Background(), "[]")
+	req.NoError(err)
+
+	reloaded, err := NewServer(Options{StatePath: statePath})
+	req.NoError(err)
+	state := reloaded.State()
+	req.Len(state.Apps, 1)
+	req.Len(state.Apps[0].Releases, 1)
+
+	// new IDs must not collide with the ones in the file
+	another, err := reloaded.AddApp("My App", AppTypeKots)
+	req.NoError(err)
+	req.Equal("my-app-2", another.Slug)
+	req.NotEqual(state.Apps[0].ID, another.ID)
+}
+
+func TestIDs(t *testing.T) {
+	req := require.New(t)
+
+	// a server without a state file starts over on restart, its IDs must
+	// still be new
+	first, err := NewServer(Options{})
+	req.NoError(err)
+	app, err := first.AddApp("My App", AppTypeKots)
+	req.NoError(err)
+	restarted, err := NewServer(Options{})
+	req.NoError(err)
+	again, err := restarted.AddApp("My App", AppTypeKots)
+	req.NoError(err)
+
+	req.Equal(app.Slug, again.Slug)
+	req.NotEqual(app.ID, again.ID)
+	for _, id := range []string{app.ID, again.ID, app.Channels[0].ID} {
+		req.Regexp(`^[0-9A-Za-z]{27}$`, id)
+	}
+}
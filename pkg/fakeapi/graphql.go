@@ -0,0 +1,320 @@
+package fakeapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/replicatedhq/replicated/pkg/graphql"
+)
+
+// uploadPath is where ship releases are uploaded before they are finalized
+const uploadPath = "/fakeapi/upload/"
+
+// operationField matches the first field of a query, which names the
+// operation whether or not the query itself is named
+var operationField = regexp.MustCompile(`\{\s*(\w+)`)
+
+type graphqlResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []graphql.Error        `json:"errors,omitempty"`
+}
+
+// gqlChannel is the channel fields the ship client reads
+type gqlChannel struct {
+	ID              string `json:"id"`
+	AppID           string `json:"appId"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	CurrentSequence int64  `json:"currentSequence"`
+	CurrentVersion  string `json:"currentVersion"`
+	NumReleases     int    `json:"numReleases"`
+	Created         string `json:"created"`
+	IsArchived      bool   `json:"isArchived"`
+}
+
+type gqlRelease struct {
+	ID           string       `json:"id"`
+	Sequence     int64        `json:"sequence"`
+	Spec         string       `json:"spec"`
+	Created      string       `json:"created"`
+	ReleaseNotes string       `json:"releaseNotes"`
+	Channels     []gqlChannel `json:"channels"`
+}
+
+// handleGraphQL serves the ship operations of the GraphQL API. Other
+// operations get a GraphQL error, like a field missing from the schema.
+func (s *Server) handleGraphQL(r *http.Request) response {
+	var request graphql.Request
+	if err := decodeBody(r, &request); err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+
+	operation := request.OperationName
+	if match := operationField.FindStringSubmatch(request.Query); operation == "" && match != nil {
+		operation = match[1]
+	}
+	vars := request.Variables
+
+	var data interface{}
+	var err error
+	switch operation {
+	case "ship":
+		data = s.gqlShipApps()
+	case "getAppChannels":
+		data, err = s.gqlAppChannels(stringVar(vars, "appId"))
+	case "getChannel":
+		data, err = s.gqlGetChannel(stringVar(vars, "channelId"))
+	case "createChannel":
+		data, err = s.gqlCreateChannel(stringVar(vars, "appId"), stringVar(vars, "channelName"), stringVar(vars, "description"))
+	case "allReleases":
+		data, err = s.gqlAllReleases(stringVar(vars, "appId"))
+	case "uploadRelease":
+		data, err = s.gqlUploadRelease(r, stringVar(vars, "appId"))
+	case "finalizeUploadedRelease":
+		data, err = s.gqlFinalizeUploadedRelease(stringVar(vars, "appId"), stringVar(vars, "uploadId"))
+	case "updateRelease":
+		data, err = s.gqlUpdateRelease(stringVar(vars, "appId"), intVar(vars, "sequence"), stringVar(vars, "spec"))
+	case "promoteShipRelease":
+		data, err = s.gqlPromoteRelease(stringVar(vars, "appId"), intVar(vars, "sequence"),
+			stringsVar(vars, "channelIds"), stringVar(vars, "versionLabel"), stringVar(vars, "releaseNotes"))
+	default:
+		err = fmt.Errorf("operation %q is not supported by the fake API", operation)
+	}
+
+	if err != nil {
+		return response{status: http.StatusOK, body: graphqlResponse{
+			Errors: []graphql.Error{{Message: err.Error(), Code: "BAD_REQUEST"}},
+		}}
+	}
+	return response{status: http.StatusOK, body: graphqlResponse{Data: map[string]interface{}{operation: data}}}
+}
+
+func (s *Server) gqlShipApps() interface{} {
+	type gqlApp struct {
+		ID       string       `json:"id"`
+		Name     string       `json:"name"`
+		Slug     string       `json:"slug"`
+		Created  string       `json:"created"`
+		Channels []gqlChannel `json:"channels"`
+	}
+
+	apps := []gqlApp{}
+	for _, app := range s.state.Apps {
+		if app.Type != AppTypeShip {
+			continue
+		}
+		apps = append(apps, gqlApp{
+			ID:       app.ID,
+			Name:     app.Name,
+			Slug:     app.Slug,
+			Created:  app.Created.Format(time.RFC3339),
+			Channels: shipChannels(app),
+		})
+	}
+	return map[string]interface{}{"apps": apps}
+}
+
+func (s *Server) gqlAppChannels(appID string) (interface{}, error) {
+	app := s.app(appID, AppTypeShip)
+	if app == nil {
+		return nil, fmt.Errorf("app %s not found", appID)
+	}
+	return shipChannels(app), nil
+}
+
+func (s *Server) gqlGetChannel(channelID string) (interface{}, error) {
+	for _, app := range s.state.Apps {
+		if app.Type != AppTypeShip {
+			continue
+		}
+		if channel := app.channel(channelID); channel != nil {
+			return shipChannel(app, channel), nil
+		}
+	}
+	return nil, fmt.Errorf("channel %s not found", channelID)
+}
+
+func (s *Server) gqlCreateChannel(appID string, name string, description string) (interface{}, error) {
+	app := s.app(appID, AppTypeShip)
+	if app == nil {
+		return nil, fmt.Errorf("app %s not found", appID)
+	}
+	if name == "" {
+		return nil, fmt.Errorf("channel name is required")
+	}
+
+	channel := s.addChannel(app, name, description)
+	s.changed = true
+	return shipChannel(app, channel), nil
+}
+
+func (s *Server) gqlAllReleases(appID string) (interface{}, error) {
+	app := s.app(appID, AppTypeShip)
+	if app == nil {
+		return nil, fmt.Errorf("app %s not found", appID)
+	}
+
+	releases := []gqlRelease{}
+	for i := len(app.Releases) - 1; i >= 0; i-- {
+		releases = append(releases, shipRelease(app, app.Releases[i]))
+	}
+	return releases, nil
+}
+
+func (s *Server) gqlUploadRelease(r *http.Request, appID string) (interface{}, error) {
+	if s.app(appID, AppTypeShip) == nil {
+		return nil, fmt.Errorf("app %s not found", appID)
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	uploadID := id()
+	s.uploads[uploadID] = nil
+	return map[string]string{
+		"id":        uploadID,
+		"uploadUri": fmt.Sprintf("%s://%s%s%s", scheme, r.Host, uploadPath, uploadID),
+	}, nil
+}
+
+func (s *Server) gqlFinalizeUploadedRelease(appID string, uploadID string) (interface{}, error) {
+	app := s.app(appID, AppTypeShip)
+	if app == nil {
+		return nil, fmt.Errorf("app %s not found", appID)
+	}
+	spec, ok := s.uploads[uploadID]
+	if !ok || spec == nil {
+		return nil, fmt.Errorf("upload %s not found", uploadID)
+	}
+	delete(s.uploads, uploadID)
+
+	release := s.addRelease(app, string(spec))
+	return shipRelease(app, release), nil
+}
+
+func (s *Server) gqlUpdateRelease(appID string, sequence int64, spec string) (interface{}, error) {
+	app := s.app(appID, AppTypeShip)
+	if app == nil {
+		return nil, fmt.Errorf("app %s not found", appID)
+	}
+	release := app.release(sequence)
+	if release == nil {
+		return nil, fmt.Errorf("release %d not found", sequence)
+	}
+
+	release.Spec = spec
+	release.Edited = s.now().UTC()
+	s.changed = true
+	return map[string]string{"id": shipReleaseID(app, release)}, nil
+}
+
+func (s *Server) gqlPromoteRelease(appID string, sequence int64, channelIDs []string, label string, notes string) (interface{}, error) {
+	app := s.app(appID, AppTypeShip)
+	if app == nil {
+		return nil, fmt.Errorf("app %s not found", appID)
+	}
+	release := app.release(sequence)
+	if release == nil {
+		return nil, fmt.Errorf("release %d not found", sequence)
+	}
+
+	if resp, ok := s.promote(app, release, channelIDs, label, notes, false); !ok {
+		return nil, fmt.Errorf("%s", resp.body.(errorBody).Message)
+	}
+	return map[string]string{"id": shipReleaseID(app, release)}, nil
+}
+
+// handleUpload stores the body of an upload started by uploadRelease
+func (s *Server) handleUpload(w http.ResponseWriter, r *http.Request) {
+	uploadID := strings.TrimPrefix(r.URL.Path, uploadPath)
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeResponse(w, fail(http.StatusBadRequest, "read upload: %v", err))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.uploads[uploadID]; !ok {
+		writeResponse(w, fail(http.StatusNotFound, "upload %s not found", uploadID))
+		return
+	}
+	s.uploads[uploadID] = body
+	w.WriteHeader(http.StatusOK)
+}
+
+func shipChannels(app *App) []gqlChannel {
+	channels := []gqlChannel{}
+	for _, channel := range app.Channels {
+		if !channel.IsArchived {
+			channels = append(channels, shipChannel(app, channel))
+		}
+	}
+	return channels
+}
+
+func shipChannel(app *App, channel *Channel) gqlChannel {
+	gc := gqlChannel{
+		ID:          channel.ID,
+		AppID:       app.ID,
+		Name:        channel.Name,
+		Description: channel.Description,
+		NumReleases: len(channel.Releases),
+		Created:     channel.Created.Format(time.RFC3339),
+		IsArchived:  channel.IsArchived,
+	}
+	if current := channel.current(); current != nil {
+		gc.CurrentSequence = current.Sequence
+		gc.CurrentVersion = current.VersionLabel
+	}
+	return gc
+}
+
+func shipRelease(app *App, release *Release) gqlRelease {
+	channels := []gqlChannel{}
+	for _, channel := range app.Channels {
+		if current := channel.current(); !channel.IsArchived && current != nil && current.Sequence == release.Sequence {
+			channels = append(channels, shipChannel(app, channel))
+		}
+	}
+	return gqlRelease{
+		ID:           shipReleaseID(app, release),
+		Sequence:     release.Sequence,
+		Spec:         release.Spec,
+		Created:      release.Created.Format(time.RFC3339),
+		ReleaseNotes: release.ReleaseNotes,
+		Channels:     channels,
+	}
+}
+
+func shipReleaseID(app *App, release *Release) string {
+	return fmt.Sprintf("%s-release-%d", app.ID, release.Sequence)
+}
+
+func stringVar(vars map[string]interface{}, name string) string {
+	s, _ := vars[name].(string)
+	return s
+}
+
+// intVar reads a number variable, which JSON decodes as a float64
+func intVar(vars map[string]interface{}, name string) int64 {
+	f, _ := vars[name].(float64)
+	return int64(f)
+}
+
+func stringsVar(vars map[string]interface{}, name string) []string {
+	values, _ := vars[name].([]interface{})
+	strs := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
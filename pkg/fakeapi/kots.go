@@ -0,0 +1,735 @@
+package fakeapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/replicatedhq/replicated/pkg/util"
+)
+
+const (
+	releasesPageSize  = 20
+	customersPageSize = 100
+)
+
+func (s *Server) kotsRoutes() []route {
+	return []route{
+		newRoute("GET", "/v3/apps", s.listApps),
+		newRoute("POST", "/v3/app", s.createApp),
+		newRoute("DELETE", "/v3/app/:app", s.deleteApp),
+
+		newRoute("GET", "/v3/app/:app/channels", s.listChannels),
+		newRoute("POST", "/v3/app/:app/channel", s.createChannel),
+		newRoute("GET", "/v3/app/:app/channel/:channel", s.getChannel),
+		newRoute("PUT", "/v3/app/:app/channel/:channel", s.updateChannel),
+		newRoute("DELETE", "/v3/app/:app/channel/:channel", s.archiveChannel),
+		newRoute("GET", "/v3/app/:app/channel/:channel/releases", s.listChannelReleases),
+
+		newRoute("GET", "/v3/app/:app/releases", s.listReleases),
+		newRoute("POST", "/v3/app/:app/release", s.createRelease),
+		newRoute("GET", "/v3/app/:app/release/:sequence", s.getRelease),
+		newRoute("PUT", "/v3/app/:app/release/:sequence", s.updateRelease),
+		newRoute("POST", "/v3/app/:app/release/:sequence/promote", s.promoteRelease),
+
+		newRoute("GET", "/v3/app/:app/customers", s.listCustomers),
+		newRoute("GET", "/v3/app/:app/customer/:customer", s.getCustomer),
+		newRoute("GET", "/v3/app/:app/customer/:customer/license-download", s.downloadLicense),
+		newRoute("POST", "/v3/customer", s.createCustomer),
+		newRoute("PUT", "/v3/customer/:customer", s.updateCustomer),
+		newRoute("POST", "/v3/customer/:customer/archive", s.archiveCustomer),
+		newRoute("POST", "/v3/customer/:customer/unarchive", s.unarchiveCustomer),
+
+		newRoute("GET", "/v3/app/:app/installers", s.listInstallers),
+		newRoute("POST", "/v3/app/:app/installer", s.createInstaller),
+		newRoute("POST", "/v3/app/:app/installer/promote", s.promoteInstaller),
+	}
+}
+
+func (s *Server) listApps(r *http.Request, params map[string]string) response {
+	apps := []types.KotsAppWithChannels{}
+	for _, app := range s.state.Apps {
+		if app.Type == AppTypeKots {
+			apps = append(apps, kotsApp(app))
+		}
+	}
+	return response{status: http.StatusOK, body: map[string]interface{}{"apps": apps}}
+}
+
+func (s *Server) createApp(r *http.Request, params map[string]string) response {
+	var request kotsclient.CreateKOTSAppRequest
+	if err := decodeBody(r, &request); err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+	if request.Name == "" {
+		return fail(http.StatusBadRequest, "app name is required")
+	}
+
+	app := s.addApp(request.Name, AppTypeKots)
+	s.changed = true
+	return response{status: http.StatusCreated, body: kotsclient.CreateKOTSAppResponse{App: kotsAppPtr(app)}}
+}
+
+func (s *Server) deleteApp(r *http.Request, params map[string]string) response {
+	for i, app := range s.state.Apps {
+		if app.Type == AppTypeKots && (app.ID == params["app"] || app.Slug == params["app"]) {
+			s.state.Apps = append(s.state.Apps[:i], s.state.Apps[i+1:]...)
+			s.changed = true
+			return response{status: http.StatusOK}
+		}
+	}
+	return fail(http.StatusNotFound, "app %s not found", params["app"])
+}
+
+func (s *Server) listChannels(r *http.Request, params map[string]string) response {
+	app := s.app(params["app"], AppTypeKots)
+	if app == nil {
+		return fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+
+	name := r.URL.Query().Get("channelName")
+	channels := []types.KotsChannel{}
+	for _, channel := range app.Channels {
+		if channel.IsArchived || (name != "" && channel.Name != name) {
+			continue
+		}
+		channels = append(channels, kotsChannel(app, channel))
+	}
+	return response{status: http.StatusOK, body: map[string]interface{}{"channels": channels}}
+}
+
+func (s *Server) createChannel(r *http.Request, params map[string]string) response {
+	app := s.app(params["app"], AppTypeKots)
+	if app == nil {
+		return fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+
+	var request types.CreateChannelRequest
+	if err := decodeBody(r, &request); err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+	if request.Name == "" {
+		return fail(http.StatusBadRequest, "channel name is required")
+	}
+
+	channel := s.addChannel(app, request.Name, request.Description)
+	s.changed = true
+	return response{status: http.StatusCreated, body: map[string]interface{}{"channel": kotsChannel(app, channel)}}
+}
+
+func (s *Server) getChannel(r *http.Request, params map[string]string) response {
+	app, channel, resp := s.appChannel(params)
+	if channel == nil {
+		return resp
+	}
+
+	kc := kotsChannel(app, channel)
+	kc.Releases = channelReleases(channel)
+	return response{status: http.StatusOK, body: map[string]interface{}{"channel": kc}}
+}
+
+func (s *Server) updateChannel(r *http.Request, params map[string]string) response {
+	app, channel, resp := s.appChannel(params)
+	if channel == nil {
+		return resp
+	}
+
+	var request types.UpdateChannelRequest
+	if err := decodeBody(r, &request); err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+	if request.Name != "" {
+		channel.Name = request.Name
+		channel.Slug = slug(request.Name)
+	}
+	channel.SemverRequired = request.SemverRequired
+	s.changed = true
+	return response{status: http.StatusOK, body: map[string]interface{}{"channel": kotsChannel(app, channel)}}
+}
+
+func (s *Server) archiveChannel(r *http.Request, params map[string]string) response {
+	app, channel, resp := s.appChannel(params)
+	if channel == nil {
+		return resp
+	}
+
+	for _, customer := range app.Customers {
+		if customer.ChannelID == channel.ID && !customer.IsArchived {
+			return fail(http.StatusBadRequest, "channel %s has active customers", channel.Name)
+		}
+	}
+	channel.IsArchived = true
+	s.changed = true
+	return response{status: http.StatusOK}
+}
+
+func (s *Server) listChannelReleases(r *http.Request, params map[string]string) response {
+	_, channel, resp := s.appChannel(params)
+	if channel == nil {
+		return resp
+	}
+	return response{status: http.StatusOK, body: map[string]interface{}{"releases": channelReleases(channel)}}
+}
+
+func (s *Server) appChannel(params map[string]string) (*App, *Channel, response) {
+	app := s.app(params["app"], AppTypeKots)
+	if app == nil {
+		return nil, nil, fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+	channel := app.channel(params["channel"])
+	if channel == nil {
+		return nil, nil, fail(http.StatusNotFound, "channel %s not found", params["channel"])
+	}
+	return app, channel, response{}
+}
+
+func (s *Server) listReleases(r *http.Request, params map[string]string) response {
+	app := s.app(params["app"], AppTypeKots)
+	if app == nil {
+		return fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+
+	page, pageSize := paging(r, releasesPageSize)
+	releases := []*types.KotsAppRelease{}
+	// newest first, like the real API
+	for i := len(app.Releases) - 1 - page*pageSize; i >= 0 && len(releases) < pageSize; i-- {
+		releases = append(releases, kotsRelease(app, app.Releases[i]))
+	}
+	return response{status: http.StatusOK, body: types.KotsListReleasesResponse{Releases: releases}}
+}
+
+func (s *Server) createRelease(r *http.Request, params map[string]string) response {
+	app := s.app(params["app"], AppTypeKots)
+	if app == nil {
+		return fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+
+	spec, err := specFromGzipRequest(r)
+	if err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+
+	release := s.addRelease(app, spec)
+	return response{status: http.StatusCreated, body: types.KotsGetReleaseResponse{Release: *kotsRelease(app, release)}}
+}
+
+func (s *Server) getRelease(r *http.Request, params map[string]string) response {
+	app, release, resp := s.appRelease(params)
+	if release == nil {
+		return resp
+	}
+	return response{status: http.StatusOK, body: types.KotsGetReleaseResponse{Release: *kotsRelease(app, release)}}
+}
+
+func (s *Server) updateRelease(r *http.Request, params map[string]string) response {
+	_, release, resp := s.appRelease(params)
+	if release == nil {
+		return resp
+	}
+	if release.Promoted {
+		return fail(http.StatusBadRequest, "release %d has been promoted and cannot be edited", release.Sequence)
+	}
+
+	spec, err := specFromGzipRequest(r)
+	if err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+	release.Spec = spec
+	release.Edited = s.now().UTC()
+	s.changed = true
+	return response{status: http.StatusOK, body: map[string]interface{}{}}
+}
+
+func (s *Server) promoteRelease(r *http.Request, params map[string]string) response {
+	app, release, resp := s.appRelease(params)
+	if release == nil {
+		return resp
+	}
+
+	var request types.KotsPromoteReleaseRequest
+	if err := decodeBody(r, &request); err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+	if resp, ok := s.promote(app, release, request.ChannelIDs, request.VersionLabel, request.ReleaseNotes, false); !ok {
+		return resp
+	}
+	return response{status: http.StatusOK, body: map[string]interface{}{}}
+}
+
+func (s *Server) appRelease(params map[string]string) (*App, *Release, response) {
+	app := s.app(params["app"], AppTypeKots)
+	if app == nil {
+		return nil, nil, fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+	sequence, err := strconv.ParseInt(params["sequence"], 10, 64)
+	if err != nil {
+		return nil, nil, fail(http.StatusBadRequest, "invalid sequence %q", params["sequence"])
+	}
+	release := app.release(sequence)
+	if release == nil {
+		return nil, nil, fail(http.StatusNotFound, "release %d not found", sequence)
+	}
+	return app, release, response{}
+}
+
+// addRelease creates a release with the next sequence, s.mu must be held
+func (s *Server) addRelease(app *App, spec string) *Release {
+	release := &Release{
+		Sequence: int64(len(app.Releases) + 1),
+		Spec:     spec,
+		Created:  s.now().UTC(),
+		Edited:   s.now().UTC(),
+	}
+	app.Releases = append(app.Releases, release)
+	s.changed = true
+	return release
+}
+
+// promote checks every channel before promoting the release to any of them,
+// s.mu must be held
+func (s *Server) promote(app *App, release *Release, channelIDs []string, label string, notes string, required bool) (response, bool) {
+	if len(channelIDs) == 0 {
+		return fail(http.StatusBadRequest, "at least one channel is required"), false
+	}
+
+	channels := make([]*Channel, 0, len(channelIDs))
+	for _, channelID := range channelIDs {
+		channel := app.channel(channelID)
+		if channel == nil {
+			return fail(http.StatusBadRequest, "channel %s not found", channelID), false
+		}
+		if channel.SemverRequired && !semverPattern.MatchString(label) {
+			return fail(http.StatusBadRequest, "channel %s requires a semantic version, got %q", channel.Name, label), false
+		}
+		channels = append(channels, channel)
+	}
+
+	for _, channel := range channels {
+		channel.Releases = append(channel.Releases, &ChannelRelease{
+			Sequence:     release.Sequence,
+			VersionLabel: label,
+			ReleaseNotes: notes,
+			ReleasedAt:   s.now().UTC(),
+			Required:     required,
+		})
+	}
+	release.Promoted = true
+	release.ReleaseNotes = notes
+	s.changed = true
+	return response{}, true
+}
+
+func (s *Server) listCustomers(r *http.Request, params map[string]string) response {
+	app := s.app(params["app"], AppTypeKots)
+	if app == nil {
+		return fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+
+	page, _ := paging(r, customersPageSize)
+	customers := []types.Customer{}
+	for i := page * customersPageSize; i < len(app.Customers) && len(customers) < customersPageSize; i++ {
+		customers = append(customers, kotsCustomer(app, app.Customers[i]))
+	}
+	return response{status: http.StatusOK, body: kotsclient.CustomerListResponse{
+		Customers:      customers,
+		TotalCustomers: len(app.Customers),
+	}}
+}
+
+func (s *Server) getCustomer(r *http.Request, params map[string]string) response {
+	app := s.app(params["app"], AppTypeKots)
+	if app == nil {
+		return fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+	customer := app.customer(params["customer"])
+	if customer == nil {
+		return fail(http.StatusNotFound, "customer %s not found", params["customer"])
+	}
+
+	kc := kotsCustomer(app, customer)
+	return response{status: http.StatusOK, body: kotsclient.GetCustomerResponse{Customer: &kc}}
+}
+
+// downloadLicense returns an unsigned license with the customer's fields
+func (s *Server) downloadLicense(r *http.Request, params map[string]string) response {
+	app := s.app(params["app"], AppTypeKots)
+	if app == nil {
+		return fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+	customer := app.customer(params["customer"])
+	if customer == nil {
+		return fail(http.StatusNotFound, "customer %s not found", params["customer"])
+	}
+
+	channelName := ""
+	for _, channel := range app.Channels {
+		if channel.ID == customer.ChannelID {
+			channelName = channel.Name
+		}
+	}
+	license := fmt.Sprintf(`apiVersion: kots.io/v1beta1
+kind: License
+metadata:
+  name: %s
+spec:
+  licenseID: %s
+  licenseType: %s
+  customerName: %q
+  appSlug: %s
+  channelID: %s
+  channelName: %q
+  isAirgapSupported: %t
+  isGitOpsSupported: %t
+  isSnapshotSupported: %t
+`, slug(customer.Name), customer.ID, customer.Type, customer.Name, app.Slug, customer.ChannelID, channelName,
+		customer.IsAirgapEnabled, customer.IsGitopsSupported, customer.IsSnapshotSupported)
+	return response{status: http.StatusOK, body: []byte(license)}
+}
+
+func (s *Server) createCustomer(r *http.Request, params map[string]string) response {
+	var request kotsclient.CreateCustomerRequest
+	if err := decodeBody(r, &request); err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+	app := s.app(request.AppID, AppTypeKots)
+	if app == nil {
+		return fail(http.StatusNotFound, "app %s not found", request.AppID)
+	}
+
+	customer := &Customer{
+		ID:      id(),
+		Created: s.now().UTC(),
+	}
+	if resp, ok := applyCustomerRequest(app, customer, request); !ok {
+		return resp
+	}
+	app.Customers = append(app.Customers, customer)
+	s.changed = true
+
+	kc := kotsCustomer(app, customer)
+	return response{status: http.StatusCreated, body: kotsclient.CreateCustomerResponse{Customer: &kc}}
+}
+
+func (s *Server) updateCustomer(r *http.Request, params map[string]string) response {
+	var request kotsclient.UpdateCustomerRequest
+	if err := decodeBody(r, &request); err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+	app, customer := s.customer(params["customer"])
+	if customer == nil {
+		return fail(http.StatusNotFound, "customer %s not found", params["customer"])
+	}
+
+	updated := *customer
+	if resp, ok := applyCustomerRequest(app, &updated, request); !ok {
+		return resp
+	}
+	*customer = updated
+	s.changed = true
+
+	kc := kotsCustomer(app, customer)
+	return response{status: http.StatusOK, body: kotsclient.CreateCustomerResponse{Customer: &kc}}
+}
+
+func (s *Server) archiveCustomer(r *http.Request, params map[string]string) response {
+	return s.setCustomerArchived(params["customer"], true)
+}
+
+func (s *Server) unarchiveCustomer(r *http.Request, params map[string]string) response {
+	return s.setCustomerArchived(params["customer"], false)
+}
+
+func (s *Server) setCustomerArchived(customerID string, archived bool) response {
+	_, customer := s.customer(customerID)
+	if customer == nil {
+		return fail(http.StatusNotFound, "customer %s not found", customerID)
+	}
+	customer.IsArchived = archived
+	s.changed = true
+	return response{status: http.StatusNoContent}
+}
+
+// customer finds a customer of any KOTS app, s.mu must be held
+func (s *Server) customer(customerID string) (*App, *Customer) {
+	for _, app := range s.state.Apps {
+		if app.Type != AppTypeKots {
+			continue
+		}
+		if customer := app.customer(customerID); customer != nil {
+			return app, customer
+		}
+	}
+	return nil, nil
+}
+
+func applyCustomerRequest(app *App, customer *Customer, request kotsclient.CreateCustomerRequest) (response, bool) {
+	if request.Name == "" {
+		return fail(http.StatusBadRequest, "customer name is required"), false
+	}
+	if app.channel(request.ChannelID) == nil {
+		return fail(http.StatusBadRequest, "channel %s not found", request.ChannelID), false
+	}
+
+	customerType := request.Type
+	if customerType == "" {
+		customerType = "dev"
+	}
+	validType := false
+	for _, t := range types.CustomerTypes {
+		validType = validType || t == customerType
+	}
+	if !validType {
+		return fail(http.StatusBadRequest, "invalid customer type %q", customerType), false
+	}
+
+	customer.ExpiresAt = nil
+	if request.ExpiresAt != "" {
+		expiresAt, err := util.ParseTime(request.ExpiresAt)
+		if err != nil {
+			return fail(http.StatusBadRequest, "invalid expires_at %q", request.ExpiresAt), false
+		}
+		customer.ExpiresAt = &expiresAt
+	}
+
+	customer.Name = request.Name
+	customer.ChannelID = request.ChannelID
+	customer.Type = customerType
+	customer.EntitlementValues = request.EntitlementValues
+	customer.IsAirgapEnabled = request.IsAirgapEnabled
+	customer.IsGitopsSupported = request.IsGitopsSupported
+	customer.IsSnapshotSupported = request.IsSnapshotSupported
+	return response{}, true
+}
+
+func (s *Server) listInstallers(r *http.Request, params map[string]string) response {
+	app := s.app(params["app"], AppTypeKots)
+	if app == nil {
+		return fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+
+	installers := []types.InstallerSpec{}
+	for _, installer := range app.Installers {
+		installers = append(installers, kotsInstaller(app, installer))
+	}
+	return response{status: http.StatusOK, body: types.ListInstallersResponse{Body: installers}}
+}
+
+func (s *Server) createInstaller(r *http.Request, params map[string]string) response {
+	app := s.app(params["app"], AppTypeKots)
+	if app == nil {
+		return fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+
+	var request types.CreateInstallerRequest
+	if err := decodeBody(r, &request); err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+
+	installer := s.addInstaller(app, request.Yaml)
+	s.changed = true
+	return response{status: http.StatusCreated, body: types.InstallerSpecResponse{Body: kotsInstaller(app, installer)}}
+}
+
+func (s *Server) promoteInstaller(r *http.Request, params map[string]string) response {
+	app := s.app(params["app"], AppTypeKots)
+	if app == nil {
+		return fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+
+	var request types.PromoteInstallerRequest
+	if err := decodeBody(r, &request); err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+	installer := app.installer(request.Sequence)
+	if installer == nil {
+		return fail(http.StatusNotFound, "installer %d not found", request.Sequence)
+	}
+	channel := app.channel(request.ChannelID)
+	if channel == nil {
+		return fail(http.StatusBadRequest, "channel %s not found", request.ChannelID)
+	}
+
+	channel.InstallerSequence = installer.Sequence
+	installer.Promoted = true
+	s.changed = true
+	return response{status: http.StatusOK, body: map[string]interface{}{}}
+}
+
+// paging reads the zero based currentPage and pageSize query parameters
+func paging(r *http.Request, defaultPageSize int) (int, int) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("currentPage"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if page < 0 {
+		page = 0
+	}
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return page, pageSize
+}
+
+// specFromGzipRequest reads the spec out of a KotsCreateReleaseRequest
+func specFromGzipRequest(r *http.Request) (string, error) {
+	var request types.KotsCreateReleaseRequest
+	if err := decodeBody(r, &request); err != nil {
+		return "", err
+	}
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(request.SpecGzip))
+	if err != nil {
+		return "", fmt.Errorf("spec_gzip is not gzipped: %v", err)
+	}
+	defer gzipReader.Close()
+
+	spec, err := ioutil.ReadAll(gzipReader)
+	if err != nil {
+		return "", fmt.Errorf("read spec_gzip: %v", err)
+	}
+	return string(spec), nil
+}
+
+func kotsApp(app *App) types.KotsAppWithChannels {
+	channels := []types.Channel{}
+	for _, channel := range app.Channels {
+		if !channel.IsArchived {
+			channels = append(channels, typesChannel(channel))
+		}
+	}
+	return types.KotsAppWithChannels{
+		Channels:  channels,
+		Created:   app.Created,
+		Id:        app.ID,
+		IsKotsApp: true,
+		Name:      app.Name,
+		Slug:      app.Slug,
+	}
+}
+
+func kotsAppPtr(app *App) *types.KotsAppWithChannels {
+	ka := kotsApp(app)
+	return &ka
+}
+
+func typesChannel(channel *Channel) types.Channel {
+	c := types.Channel{
+		ID:             channel.ID,
+		Name:           channel.Name,
+		Description:    channel.Description,
+		Slug:           channel.Slug,
+		SemverRequired: channel.SemverRequired,
+		IsArchived:     channel.IsArchived,
+	}
+	if current := channel.current(); current != nil {
+		c.ReleaseSequence = current.Sequence
+		c.ReleaseLabel = current.VersionLabel
+	}
+	return c
+}
+
+func kotsChannel(app *App, channel *Channel) types.KotsChannel {
+	kc := types.KotsChannel{
+		AppId:           app.ID,
+		ChannelSequence: int32(len(channel.Releases)),
+		ChannelSlug:     channel.Slug,
+		Created:         channel.Created,
+		Description:     channel.Description,
+		Id:              channel.ID,
+		IsArchived:      channel.IsArchived,
+		Name:            channel.Name,
+		NumReleases:     int32(len(channel.Releases)),
+		SemverRequired:  channel.SemverRequired,
+	}
+	if current := channel.current(); current != nil {
+		kc.CurrentVersion = current.VersionLabel
+		kc.ReleaseSequence = int32(current.Sequence)
+		kc.ReleaseNotes = current.ReleaseNotes
+		kc.Updated = current.ReleasedAt
+	}
+	return kc
+}
+
+// channelReleases returns the channel's releases newest first
+func channelReleases(channel *Channel) []types.ChannelRelease {
+	releases := []types.ChannelRelease{}
+	for i := len(channel.Releases) - 1; i >= 0; i-- {
+		release := channel.Releases[i]
+		releases = append(releases, types.ChannelRelease{
+			ChannelId:       channel.ID,
+			ChannelName:     channel.Name,
+			ChannelSequence: int32(i),
+			Created:         release.ReleasedAt,
+			ReleaseNotes:    release.ReleaseNotes,
+			ReleasedAt:      release.ReleasedAt,
+			Semver:          release.VersionLabel,
+			Sequence:        int32(release.Sequence),
+		})
+	}
+	return releases
+}
+
+func kotsRelease(app *App, release *Release) *types.KotsAppRelease {
+	channels := []*types.Channel{}
+	for _, channel := range app.Channels {
+		if current := channel.current(); current != nil && current.Sequence == release.Sequence {
+			c := typesChannel(channel)
+			channels = append(channels, &c)
+		}
+	}
+	return &types.KotsAppRelease{
+		AppID:                app.ID,
+		Sequence:             release.Sequence,
+		CreatedAt:            release.Created,
+		Spec:                 release.Spec,
+		ReleaseNotes:         release.ReleaseNotes,
+		IsReleaseNotEditable: release.Promoted,
+		Channels:             channels,
+	}
+}
+
+func kotsCustomer(app *App, customer *Customer) types.Customer {
+	kc := types.Customer{
+		ID:                  customer.ID,
+		Name:                customer.Name,
+		Channels:            []types.Channel{},
+		Type:                customer.Type,
+		IsAirgapEnabled:     customer.IsAirgapEnabled,
+		IsGitopsSupported:   customer.IsGitopsSupported,
+		IsSnapshotSupported: customer.IsSnapshotSupported,
+		IsArchived:          customer.IsArchived,
+	}
+	for _, channel := range app.Channels {
+		if channel.ID == customer.ChannelID {
+			kc.Channels = append(kc.Channels, typesChannel(channel))
+		}
+	}
+	// like the Vendor API, a customer that never expires has a zero expiry
+	kc.Expires = &util.Time{}
+	if customer.ExpiresAt != nil {
+		kc.Expires = &util.Time{Time: *customer.ExpiresAt}
+	}
+	return kc
+}
+
+func kotsInstaller(app *App, installer *Installer) types.InstallerSpec {
+	channels := []types.Channel{}
+	for _, channel := range app.Channels {
+		if !channel.IsArchived && channel.InstallerSequence == installer.Sequence {
+			channels = append(channels, typesChannel(channel))
+		}
+	}
+	return types.InstallerSpec{
+		AppID:           app.ID,
+		Sequence:        installer.Sequence,
+		YAML:            installer.YAML,
+		ActiveChannels:  channels,
+		CreatedAt:       util.Time{Time: installer.Created},
+		CreatedAtString: installer.Created.Format(time.RFC3339),
+		Immutable:       installer.Promoted,
+	}
+}
@@ -0,0 +1,313 @@
+package fakeapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	v1 "github.com/replicatedhq/replicated/gen/go/v1"
+)
+
+func (s *Server) platformRoutes() []route {
+	return []route{
+		newRoute("GET", "/v1/apps", s.listPlatformApps),
+		newRoute("POST", "/v1/app", s.createPlatformApp),
+		newRoute("DELETE", "/v1/app/:app", s.deletePlatformApp),
+
+		newRoute("GET", "/v1/app/:app/channels", s.listPlatformChannels),
+		newRoute("POST", "/v1/app/:app/channel", s.createPlatformChannel),
+		newRoute("POST", "/v1/app/:app/channel/:channel/archive", s.archivePlatformChannel),
+		newRoute("GET", "/v1/app/:app/channel/:channel/releases", s.getPlatformChannel),
+
+		newRoute("GET", "/v1/app/:app/releases", s.listPlatformReleases),
+		newRoute("POST", "/v1/app/:app/release", s.createPlatformRelease),
+		newRoute("PUT", "/v1/app/:app/:sequence/raw", s.updatePlatformRelease),
+		newRoute("GET", "/v1/app/:app/:sequence/properties", s.getPlatformRelease),
+		newRoute("POST", "/v1/app/:app/:sequence/promote", s.promotePlatformRelease),
+	}
+}
+
+func (s *Server) listPlatformApps(r *http.Request, params map[string]string) response {
+	apps := []v1.AppAndChannels{}
+	for _, app := range s.state.Apps {
+		if app.Type == AppTypePlatform {
+			apps = append(apps, v1.AppAndChannels{App: platformApp(app), Channels: platformChannels(app)})
+		}
+	}
+	return response{status: http.StatusOK, body: apps}
+}
+
+func (s *Server) createPlatformApp(r *http.Request, params map[string]string) response {
+	var request v1.Body
+	if err := decodeBody(r, &request); err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+	if request.Name == "" {
+		return fail(http.StatusBadRequest, "app name is required")
+	}
+
+	app := s.addApp(request.Name, AppTypePlatform)
+	s.changed = true
+	return response{status: http.StatusCreated, body: platformApp(app)}
+}
+
+func (s *Server) deletePlatformApp(r *http.Request, params map[string]string) response {
+	for i, app := range s.state.Apps {
+		if app.Type == AppTypePlatform && app.ID == params["app"] {
+			s.state.Apps = append(s.state.Apps[:i], s.state.Apps[i+1:]...)
+			s.changed = true
+			return response{status: http.StatusNoContent}
+		}
+	}
+	return fail(http.StatusNotFound, "app %s not found", params["app"])
+}
+
+func (s *Server) listPlatformChannels(r *http.Request, params map[string]string) response {
+	app := s.app(params["app"], AppTypePlatform)
+	if app == nil {
+		return fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+	return response{status: http.StatusOK, body: platformChannels(app)}
+}
+
+func (s *Server) createPlatformChannel(r *http.Request, params map[string]string) response {
+	app := s.app(params["app"], AppTypePlatform)
+	if app == nil {
+		return fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+
+	var request v1.BodyCreateChannel
+	if err := decodeBody(r, &request); err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+	if request.Name == "" {
+		return fail(http.StatusBadRequest, "channel name is required")
+	}
+
+	s.addChannel(app, request.Name, request.Description)
+	s.changed = true
+	// the v1 API returns every channel of the app
+	return response{status: http.StatusOK, body: platformChannels(app)}
+}
+
+func (s *Server) archivePlatformChannel(r *http.Request, params map[string]string) response {
+	app, channel, resp := s.platformAppChannel(params)
+	if channel == nil {
+		return resp
+	}
+	if isDefaultChannel(app, channel) {
+		return fail(http.StatusBadRequest, "the default channel cannot be archived")
+	}
+
+	channel.IsArchived = true
+	s.changed = true
+	return response{status: http.StatusNoContent}
+}
+
+func (s *Server) getPlatformChannel(r *http.Request, params map[string]string) response {
+	app, channel, resp := s.platformAppChannel(params)
+	if channel == nil {
+		return resp
+	}
+
+	releases := []v1.ChannelRelease{}
+	for i, release := range channel.Releases {
+		releases = append(releases, v1.ChannelRelease{
+			ChannelId:       channel.ID,
+			ChannelSequence: int64(i),
+			Created:         release.ReleasedAt,
+			ReleaseNotes:    release.ReleaseNotes,
+			ReleaseSequence: release.Sequence,
+			Required:        release.Required,
+			Updated:         release.ReleasedAt,
+			Version:         release.VersionLabel,
+		})
+	}
+	pc := platformChannel(app, channel)
+	return response{status: http.StatusOK, body: v1.GetChannelInlineResponse200{Channel: &pc, Releases: releases}}
+}
+
+func (s *Server) platformAppChannel(params map[string]string) (*App, *Channel, response) {
+	app := s.app(params["app"], AppTypePlatform)
+	if app == nil {
+		return nil, nil, fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+	channel := app.channel(params["channel"])
+	if channel == nil {
+		return nil, nil, fail(http.StatusNotFound, "channel %s not found", params["channel"])
+	}
+	return app, channel, response{}
+}
+
+func (s *Server) listPlatformReleases(r *http.Request, params map[string]string) response {
+	app := s.app(params["app"], AppTypePlatform)
+	if app == nil {
+		return fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+
+	releases := []v1.AppReleaseInfo{}
+	// newest first, like the real API
+	for i := len(app.Releases) - 1; i >= 0; i-- {
+		releases = append(releases, platformReleaseInfo(app, app.Releases[i]))
+	}
+	return response{status: http.StatusOK, body: releases}
+}
+
+// createPlatformRelease creates a release with the spec of the latest one,
+// the v1 API only takes the spec in a following update
+func (s *Server) createPlatformRelease(r *http.Request, params map[string]string) response {
+	app := s.app(params["app"], AppTypePlatform)
+	if app == nil {
+		return fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+
+	var request v1.BodyCreateRelease
+	if err := decodeBody(r, &request); err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+	if request.Source != "latest" {
+		return fail(http.StatusBadRequest, "unsupported release source %q", request.Source)
+	}
+
+	spec := ""
+	if len(app.Releases) > 0 {
+		spec = app.Releases[len(app.Releases)-1].Spec
+	}
+	release := s.addRelease(app, spec)
+	return response{status: http.StatusCreated, body: platformReleaseInfo(app, release)}
+}
+
+func (s *Server) updatePlatformRelease(r *http.Request, params map[string]string) response {
+	_, release, resp := s.platformAppRelease(params)
+	if release == nil {
+		return resp
+	}
+	if release.Promoted {
+		return fail(http.StatusBadRequest, "release %d has been promoted and cannot be edited", release.Sequence)
+	}
+
+	spec, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return fail(http.StatusBadRequest, "read release yaml: %v", err)
+	}
+	release.Spec = string(spec)
+	release.Edited = s.now().UTC()
+	s.changed = true
+	return response{status: http.StatusOK}
+}
+
+func (s *Server) getPlatformRelease(r *http.Request, params map[string]string) response {
+	_, release, resp := s.platformAppRelease(params)
+	if release == nil {
+		return resp
+	}
+	return response{status: http.StatusOK, body: v1.AppRelease{
+		Config:    release.Spec,
+		CreatedAt: release.Created,
+		Editable:  !release.Promoted,
+		EditedAt:  release.Edited,
+		Sequence:  release.Sequence,
+	}}
+}
+
+// promotePlatformRelease promotes the release even when dry_run is set, the
+// CLI always sets it and expects the channels to be updated
+func (s *Server) promotePlatformRelease(r *http.Request, params map[string]string) response {
+	app, release, resp := s.platformAppRelease(params)
+	if release == nil {
+		return resp
+	}
+
+	var request v1.BodyPromoteRelease
+	if err := decodeBody(r, &request); err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+	if resp, ok := s.promote(app, release, request.Channels, request.Label, request.ReleaseNotes, request.Required); !ok {
+		return resp
+	}
+	return response{status: http.StatusNoContent}
+}
+
+func (s *Server) platformAppRelease(params map[string]string) (*App, *Release, response) {
+	app := s.app(params["app"], AppTypePlatform)
+	if app == nil {
+		return nil, nil, fail(http.StatusNotFound, "app %s not found", params["app"])
+	}
+	sequence, err := strconv.ParseInt(params["sequence"], 10, 64)
+	if err != nil {
+		return nil, nil, fail(http.StatusBadRequest, "invalid sequence %q", params["sequence"])
+	}
+	release := app.release(sequence)
+	if release == nil {
+		return nil, nil, fail(http.StatusNotFound, "release %d not found", sequence)
+	}
+	return app, release, response{}
+}
+
+// isDefaultChannel reports whether the channel is the first one of the app,
+// which new licenses are assigned to
+func isDefaultChannel(app *App, channel *Channel) bool {
+	return len(app.Channels) > 0 && app.Channels[0] == channel
+}
+
+func platformApp(app *App) *v1.App {
+	return &v1.App{
+		Id:   app.ID,
+		Name: app.Name,
+		Slug: app.Slug,
+	}
+}
+
+// platformChannels returns the app's channels in the order they were
+// created, which is the order of their positions
+func platformChannels(app *App) []v1.AppChannel {
+	channels := []v1.AppChannel{}
+	for _, channel := range app.Channels {
+		if !channel.IsArchived {
+			channels = append(channels, platformChannel(app, channel))
+		}
+	}
+	return channels
+}
+
+// platformChannel returns the channel with empty license statistics, the fake
+// API does not issue platform licenses
+func platformChannel(app *App, channel *Channel) v1.AppChannel {
+	pc := v1.AppChannel{
+		Adoption:      &v1.ChannelAdoption{},
+		Description:   channel.Description,
+		Id:            channel.ID,
+		IsDefault:     isDefaultChannel(app, channel),
+		LicenseCounts: &v1.LicenseCounts{},
+		Name:          channel.Name,
+	}
+	for i, c := range app.Channels {
+		if c == channel {
+			pc.Position = int64(i)
+		}
+	}
+	if current := channel.current(); current != nil {
+		pc.ReleaseLabel = current.VersionLabel
+		pc.ReleaseNotes = current.ReleaseNotes
+		pc.ReleaseSequence = current.Sequence
+	}
+	return pc
+}
+
+func platformReleaseInfo(app *App, release *Release) v1.AppReleaseInfo {
+	info := v1.AppReleaseInfo{
+		ActiveChannels: []v1.AppChannel{},
+		AppId:          app.ID,
+		CreatedAt:      release.Created,
+		Editable:       !release.Promoted,
+		EditedAt:       release.Edited,
+		Sequence:       release.Sequence,
+	}
+	for _, channel := range app.Channels {
+		if current := channel.current(); current != nil && current.Sequence == release.Sequence && !channel.IsArchived {
+			info.ActiveChannels = append(info.ActiveChannels, platformChannel(app, channel))
+			info.Version = current.VersionLabel
+		}
+	}
+	return info
+}
@@ -0,0 +1,123 @@
+package fakeapi
+
+import (
+	"time"
+
+	"github.com/replicatedhq/replicated/pkg/types"
+)
+
+// State is everything the fake API stores, and the format of its state file
+type State struct {
+	Apps []*App `json:"apps"`
+}
+
+type App struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Slug    string    `json:"slug"`
+	Type    string    `json:"type"`
+	Created time.Time `json:"created"`
+
+	Channels   []*Channel   `json:"channels"`
+	Releases   []*Release   `json:"releases"`
+	Customers  []*Customer  `json:"customers"`
+	Installers []*Installer `json:"installers"`
+}
+
+type Channel struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	Slug           string    `json:"slug"`
+	Created        time.Time `json:"created"`
+	SemverRequired bool      `json:"semverRequired"`
+	IsArchived     bool      `json:"isArchived"`
+	// InstallerSequence is the installer promoted to the channel, 0 for none
+	InstallerSequence int64 `json:"installerSequence,omitempty"`
+	// Releases are the releases promoted to the channel, oldest first
+	Releases []*ChannelRelease `json:"releases"`
+}
+
+type ChannelRelease struct {
+	Sequence     int64     `json:"sequence"`
+	VersionLabel string    `json:"versionLabel"`
+	ReleaseNotes string    `json:"releaseNotes"`
+	ReleasedAt   time.Time `json:"releasedAt"`
+	// Required is only set by platform apps
+	Required bool `json:"required"`
+}
+
+type Release struct {
+	Sequence     int64     `json:"sequence"`
+	Spec         string    `json:"spec"`
+	ReleaseNotes string    `json:"releaseNotes"`
+	Created      time.Time `json:"created"`
+	Edited       time.Time `json:"edited"`
+	// Promoted releases can no longer be edited
+	Promoted bool `json:"promoted"`
+}
+
+type Customer struct {
+	ID                  string                   `json:"id"`
+	Name                string                   `json:"name"`
+	ChannelID           string                   `json:"channelId"`
+	Type                string                   `json:"type"`
+	ExpiresAt           *time.Time               `json:"expiresAt,omitempty"`
+	EntitlementValues   []types.EntitlementValue `json:"entitlementValues,omitempty"`
+	IsAirgapEnabled     bool                     `json:"isAirgapEnabled"`
+	IsGitopsSupported   bool                     `json:"isGitopsSupported"`
+	IsSnapshotSupported bool                     `json:"isSnapshotSupported"`
+	IsArchived          bool                     `json:"isArchived"`
+	Created             time.Time                `json:"created"`
+}
+
+type Installer struct {
+	Sequence int64     `json:"sequence"`
+	YAML     string    `json:"yaml"`
+	Created  time.Time `json:"created"`
+	Promoted bool      `json:"promoted"`
+}
+
+func (a *App) channel(channelID string) *Channel {
+	for _, channel := range a.Channels {
+		if channel.ID == channelID && !channel.IsArchived {
+			return channel
+		}
+	}
+	return nil
+}
+
+func (a *App) release(sequence int64) *Release {
+	for _, release := range a.Releases {
+		if release.Sequence == sequence {
+			return release
+		}
+	}
+	return nil
+}
+
+func (a *App) customer(customerID string) *Customer {
+	for _, customer := range a.Customers {
+		if customer.ID == customerID {
+			return customer
+		}
+	}
+	return nil
+}
+
+func (a *App) installer(sequence int64) *Installer {
+	for _, installer := range a.Installers {
+		if installer.Sequence == sequence {
+			return installer
+		}
+	}
+	return nil
+}
+
+// current returns the release the channel is on, or nil
+func (c *Channel) current() *ChannelRelease {
+	if len(c.Releases) == 0 {
+		return nil
+	}
+	return c.Releases[len(c.Releases)-1]
+}
@@ -2,6 +2,7 @@ package graphql
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	multierror "github.com/hashicorp/go-multierror"
@@ -9,6 +10,9 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
+
+	"github.com/replicatedhq/replicated/pkg/transport"
 )
 
 const APIOrigin = "https://g.replicated.com/graphql"
@@ -17,6 +21,8 @@ const APIOrigin = "https://g.replicated.com/graphql"
 type Client struct {
 	GQLServer *url.URL
 	Token     string
+	// HTTPClient sends the requests, transport.DefaultClient when nil
+	HTTPClient *http.Client
 }
 
 func NewClient(origin string, apiKey string) *Client {
@@ -60,6 +66,10 @@ func (r ResponseErrorOnly) GraphQLError() []Error {
 }
 
 func (c *Client) ExecuteRequest(requestObj Request, deserializeTarget interface{}) error {
+	return c.ExecuteRequestContext(context.Background(), requestObj, deserializeTarget)
+}
+
+func (c *Client) ExecuteRequestContext(ctx context.Context, requestObj Request, deserializeTarget interface{}) error {
 	body, err := json.Marshal(requestObj)
 	if err != nil {
 		return err
@@ -67,7 +77,7 @@ func (c *Client) ExecuteRequest(requestObj Request, deserializeTarget interface{
 
 	bodyReader := bytes.NewReader(body)
 
-	req, err := http.NewRequest("POST", c.GQLServer.String(), bodyReader)
+	req, err := http.NewRequestWithContext(ctx, "POST", c.GQLServer.String(), bodyReader)
 	if err != nil {
 		return err
 	}
@@ -75,12 +85,20 @@ func (c *Client) ExecuteRequest(requestObj Request, deserializeTarget interface{
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", c.Token)
 
-	client := http.DefaultClient
+	// queries don't change anything and are safe to retry like a GET
+	if strings.HasPrefix(strings.TrimSpace(requestObj.Query), "query") {
+		req = req.WithContext(transport.WithIdempotent(req.Context()))
+	}
 
-	resp, err := client.Do(req)
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = transport.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return errors.Wrap(err, "do request")
 	}
+	defer resp.Body.Close()
 
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
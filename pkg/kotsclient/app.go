@@ -1,6 +1,7 @@
 package kotsclient
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/pkg/errors"
@@ -12,9 +13,13 @@ type kotsAppResponse struct {
 }
 
 func (c *VendorV3Client) ListApps() ([]types.AppAndChannels, error) {
+	return c.ListAppsContext(context.Background())
+}
+
+func (c *VendorV3Client) ListAppsContext(ctx context.Context) ([]types.AppAndChannels, error) {
 	var response = kotsAppResponse{}
 
-	err := c.DoJSON("GET", "/v3/apps", http.StatusOK, nil, &response)
+	err := c.DoJSONContext(ctx, "GET", "/v3/apps", http.StatusOK, nil, &response)
 	if err != nil {
 		return nil, errors.Wrap(err, "list channels")
 	}
@@ -37,7 +42,11 @@ func (c *VendorV3Client) ListApps() ([]types.AppAndChannels, error) {
 }
 
 func (c *VendorV3Client) GetApp(appID string) (*types.App, error) {
-	apps, err := c.ListApps()
+	return c.GetAppContext(context.Background(), appID)
+}
+
+func (c *VendorV3Client) GetAppContext(ctx context.Context, appID string) (*types.App, error) {
+	apps, err := c.ListAppsContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package kotsclient
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -16,9 +17,13 @@ type CreateKOTSAppResponse struct {
 }
 
 func (c *VendorV3Client) CreateKOTSApp(name string) (*types.KotsAppWithChannels, error) {
+	return c.CreateKOTSAppContext(context.Background(), name)
+}
+
+func (c *VendorV3Client) CreateKOTSAppContext(ctx context.Context, name string) (*types.KotsAppWithChannels, error) {
 	reqBody := &CreateKOTSAppRequest{Name: name}
 	app := CreateKOTSAppResponse{}
-	err := c.DoJSON("POST", "/v3/app", http.StatusCreated, reqBody, &app)
+	err := c.DoJSONContext(ctx, "POST", "/v3/app", http.StatusCreated, reqBody, &app)
 	if err != nil {
 		return nil, err
 	}
@@ -26,9 +31,13 @@ func (c *VendorV3Client) CreateKOTSApp(name string) (*types.KotsAppWithChannels,
 }
 
 func (c *VendorV3Client) DeleteKOTSApp(id string) error {
+	return c.DeleteKOTSAppContext(context.Background(), id)
+}
+
+func (c *VendorV3Client) DeleteKOTSAppContext(ctx context.Context, id string) error {
 	url := fmt.Sprintf("/v3/app/%s", id)
 
-	err := c.DoJSON("DELETE", url, http.StatusOK, nil, nil)
+	err := c.DoJSONContext(ctx, "DELETE", url, http.StatusOK, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -1,10 +1,12 @@
 package kotsclient
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
+	"sort"
 
 	"github.com/pkg/errors"
 	channels "github.com/replicatedhq/replicated/gen/go/v1"
@@ -68,6 +70,10 @@ type ListChannelsResponse struct {
 }
 
 func (c *VendorV3Client) ListChannels(appID string, appSlug string, channelName string) ([]types.Channel, error) {
+	return c.ListChannelsContext(context.Background(), appID, appSlug, channelName)
+}
+
+func (c *VendorV3Client) ListChannelsContext(ctx context.Context, appID string, appSlug string, channelName string) ([]types.Channel, error) {
 	var response = ListChannelsResponse{}
 
 	v := url.Values{}
@@ -75,7 +81,7 @@ func (c *VendorV3Client) ListChannels(appID string, appSlug string, channelName
 	v.Set("excludeDetail", "true")
 
 	url := fmt.Sprintf("/v3/app/%s/channels?%s", appID, v.Encode())
-	err := c.DoJSON("GET", url, http.StatusOK, nil, &response)
+	err := c.DoJSONContext(ctx, "GET", url, http.StatusOK, nil, &response)
 	if err != nil {
 		return nil, errors.Wrap(err, "list channels")
 	}
@@ -87,6 +93,7 @@ func (c *VendorV3Client) ListChannels(appID string, appSlug string, channelName
 			Name:            kotsChannel.Name,
 			ReleaseLabel:    kotsChannel.CurrentVersion,
 			ReleaseSequence: int64(kotsChannel.ReleaseSequence),
+			SemverRequired:  kotsChannel.SemverRequired,
 			InstallCommands: &types.InstallCommands{
 				Existing: existingInstallCommand(appSlug, kotsChannel),
 				Embedded: embeddedInstallCommand(appSlug, kotsChannel),
@@ -101,6 +108,10 @@ func (c *VendorV3Client) ListChannels(appID string, appSlug string, channelName
 }
 
 func (c *VendorV3Client) CreateChannel(appID, name, description string) (*types.Channel, error) {
+	return c.CreateChannelContext(context.Background(), appID, name, description)
+}
+
+func (c *VendorV3Client) CreateChannelContext(ctx context.Context, appID, name, description string) (*types.Channel, error) {
 	request := types.CreateChannelRequest{
 		Name:        name,
 		Description: description,
@@ -112,7 +123,7 @@ func (c *VendorV3Client) CreateChannel(appID, name, description string) (*types.
 	var response createChannelResponse
 
 	url := fmt.Sprintf("/v3/app/%s/channel", appID)
-	err := c.DoJSON("POST", url, http.StatusCreated, request, &response)
+	err := c.DoJSONContext(ctx, "POST", url, http.StatusCreated, request, &response)
 	if err != nil {
 		return nil, errors.Wrap(err, "list channels")
 	}
@@ -124,18 +135,23 @@ func (c *VendorV3Client) CreateChannel(appID, name, description string) (*types.
 		Slug:            response.Channel.ChannelSlug,
 		ReleaseSequence: int64(response.Channel.ReleaseSequence),
 		ReleaseLabel:    response.Channel.CurrentVersion,
+		SemverRequired:  response.Channel.SemverRequired,
 		IsArchived:      response.Channel.IsArchived,
 	}, nil
 }
 
 func (c *VendorV3Client) GetChannel(appID string, channelID string) (*channels.AppChannel, []channels.ChannelRelease, error) {
+	return c.GetChannelContext(context.Background(), appID, channelID)
+}
+
+func (c *VendorV3Client) GetChannelContext(ctx context.Context, appID string, channelID string) (*channels.AppChannel, []channels.ChannelRelease, error) {
 	type getChannelResponse struct {
 		Channel types.KotsChannel `json:"channel"`
 	}
 
 	response := getChannelResponse{}
 	url := fmt.Sprintf("/v3/app/%s/channel/%s", appID, url.QueryEscape(channelID))
-	err := c.DoJSON("GET", url, http.StatusOK, nil, &response)
+	err := c.DoJSONContext(ctx, "GET", url, http.StatusOK, nil, &response)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "get app channel")
 	}
@@ -150,10 +166,38 @@ func (c *VendorV3Client) GetChannel(appID string, channelID string) (*channels.A
 	return &channelDetail, nil, nil
 }
 
+func (c *VendorV3Client) ListChannelReleases(appID string, channelID string) ([]types.ChannelRelease, error) {
+	return c.ListChannelReleasesContext(context.Background(), appID, channelID)
+}
+
+// ListChannelReleasesContext returns every release that has been promoted to
+// the channel, newest first
+func (c *VendorV3Client) ListChannelReleasesContext(ctx context.Context, appID string, channelID string) ([]types.ChannelRelease, error) {
+	type listChannelReleasesResponse struct {
+		Releases []types.ChannelRelease `json:"releases"`
+	}
+
+	response := listChannelReleasesResponse{}
+	url := fmt.Sprintf("/v3/app/%s/channel/%s/releases", appID, url.QueryEscape(channelID))
+	err := c.DoJSONContext(ctx, "GET", url, http.StatusOK, nil, &response)
+	if err != nil {
+		return nil, errors.Wrap(err, "list channel releases")
+	}
+
+	sort.SliceStable(response.Releases, func(i, j int) bool {
+		return response.Releases[i].ChannelSequence > response.Releases[j].ChannelSequence
+	})
+	return response.Releases, nil
+}
+
 func (c *VendorV3Client) ArchiveChannel(appID, channelID string) error {
+	return c.ArchiveChannelContext(context.Background(), appID, channelID)
+}
+
+func (c *VendorV3Client) ArchiveChannelContext(ctx context.Context, appID, channelID string) error {
 	url := fmt.Sprintf("/v3/app/%s/channel/%s", appID, url.QueryEscape(channelID))
 
-	err := c.DoJSON("DELETE", url, http.StatusOK, nil, nil)
+	err := c.DoJSONContext(ctx, "DELETE", url, http.StatusOK, nil, nil)
 	if err != nil {
 		return errors.Wrap(err, "archive app channel")
 	}
@@ -162,6 +206,10 @@ func (c *VendorV3Client) ArchiveChannel(appID, channelID string) error {
 }
 
 func (c *VendorV3Client) UpdateSemanticVersioning(appID string, channel *channels.AppChannel, enableSemver bool) error {
+	return c.UpdateSemanticVersioningContext(context.Background(), appID, channel, enableSemver)
+}
+
+func (c *VendorV3Client) UpdateSemanticVersioningContext(ctx context.Context, appID string, channel *channels.AppChannel, enableSemver bool) error {
 	request := types.UpdateChannelRequest{
 		Name:           channel.Name,
 		SemverRequired: enableSemver,
@@ -173,7 +221,7 @@ func (c *VendorV3Client) UpdateSemanticVersioning(appID string, channel *channel
 	var response updateChannelResponse
 
 	url := fmt.Sprintf("/v3/app/%s/channel/%s", appID, channel.Id)
-	err := c.DoJSON("PUT", url, http.StatusOK, request, &response)
+	err := c.DoJSONContext(ctx, "PUT", url, http.StatusOK, request, &response)
 	if err != nil {
 		return errors.Wrap(err, "edit semantic versioning for channel")
 	}
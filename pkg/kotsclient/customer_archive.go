@@ -0,0 +1,35 @@
+package kotsclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+func (c *VendorV3Client) ArchiveCustomer(customerID string) error {
+	return c.ArchiveCustomerContext(context.Background(), customerID)
+}
+
+func (c *VendorV3Client) ArchiveCustomerContext(ctx context.Context, customerID string) error {
+	path := fmt.Sprintf("/v3/customer/%s/archive", customerID)
+	err := c.DoJSONContext(ctx, "POST", path, http.StatusNoContent, nil, nil)
+	if err != nil {
+		return errors.Wrapf(err, "archive customer %s", customerID)
+	}
+	return nil
+}
+
+func (c *VendorV3Client) UnarchiveCustomer(customerID string) error {
+	return c.UnarchiveCustomerContext(context.Background(), customerID)
+}
+
+func (c *VendorV3Client) UnarchiveCustomerContext(ctx context.Context, customerID string) error {
+	path := fmt.Sprintf("/v3/customer/%s/unarchive", customerID)
+	err := c.DoJSONContext(ctx, "POST", path, http.StatusNoContent, nil, nil)
+	if err != nil {
+		return errors.Wrapf(err, "unarchive customer %s", customerID)
+	}
+	return nil
+}
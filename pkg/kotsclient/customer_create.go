@@ -1,40 +1,103 @@
 package kotsclient
 
 import (
-	"github.com/pkg/errors"
-	"github.com/replicatedhq/replicated/pkg/types"
+	"context"
+	"fmt"
 	"net/http"
 	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/types"
 )
 
 type CreateCustomerRequest struct {
-	Name      string `json:"name"`
-	ChannelID string `json:"channel_id"`
-	AppID     string `json:"app_id"`
-	Type      string `json:"type"`
-	ExpiresAt string `json:"expires_at"`
+	Name              string                   `json:"name"`
+	ChannelID         string                   `json:"channel_id"`
+	AppID             string                   `json:"app_id"`
+	Type              string                   `json:"type"`
+	ExpiresAt         string                   `json:"expires_at"`
+	EntitlementValues []types.EntitlementValue `json:"entitlementValues,omitempty"`
+
+	IsAirgapEnabled     bool `json:"is_airgap_enabled"`
+	IsGitopsSupported   bool `json:"is_gitops_supported"`
+	IsSnapshotSupported bool `json:"is_snapshot_supported"`
 }
 
+type UpdateCustomerRequest = CreateCustomerRequest
+
 type CreateCustomerResponse struct {
 	Customer *types.Customer `json:"customer"`
 }
 
 func (c *VendorV3Client) CreateCustomer(name string, appID string, channelID string, expiresIn time.Duration) (*types.Customer, error) {
-	request := &CreateCustomerRequest{
+	return c.CreateCustomerContext(context.Background(), name, appID, channelID, expiresIn)
+}
+
+func (c *VendorV3Client) CreateCustomerContext(ctx context.Context, name string, appID string, channelID string, expiresIn time.Duration) (*types.Customer, error) {
+	opts := types.CustomerOptions{
 		Name:      name,
 		ChannelID: channelID,
-		AppID:     appID,
-		Type:      "dev", // hardcode for now
+		Type:      "dev",
 	}
-
 	if expiresIn > 0 {
-		request.ExpiresAt = (time.Now().UTC().Add(expiresIn)).Format(time.RFC3339)
+		expiresAt := time.Now().UTC().Add(expiresIn)
+		opts.ExpiresAt = &expiresAt
+	}
+	return c.CreateCustomerWithOptionsContext(ctx, appID, opts)
+}
+
+func (c *VendorV3Client) CreateCustomerWithOptions(appID string, opts types.CustomerOptions) (*types.Customer, error) {
+	return c.CreateCustomerWithOptionsContext(context.Background(), appID, opts)
+}
+
+func (c *VendorV3Client) CreateCustomerWithOptionsContext(ctx context.Context, appID string, opts types.CustomerOptions) (*types.Customer, error) {
+	request := customerRequest(appID, opts)
+	if request.Type == "" {
+		request.Type = "dev"
 	}
+
 	var response CreateCustomerResponse
-	err := c.DoJSON("POST", "/v3/customer", http.StatusCreated, request, &response)
+	err := c.DoJSONContext(ctx, "POST", "/v3/customer", http.StatusCreated, request, &response)
 	if err != nil {
 		return nil, errors.Wrap(err, "create customer")
 	}
 
 	return response.Customer, nil
 }
+
+// UpdateCustomer replaces the customer's name, channel, type, expiry and
+// features. Entitlement values that are not in opts are left unchanged.
+func (c *VendorV3Client) UpdateCustomer(appID string, customerID string, opts types.CustomerOptions) (*types.Customer, error) {
+	return c.UpdateCustomerContext(context.Background(), appID, customerID, opts)
+}
+
+func (c *VendorV3Client) UpdateCustomerContext(ctx context.Context, appID string, customerID string, opts types.CustomerOptions) (*types.Customer, error) {
+	request := customerRequest(appID, opts)
+
+	var response CreateCustomerResponse
+	path := fmt.Sprintf("/v3/customer/%s", customerID)
+	err := c.DoJSONContext(ctx, "PUT", path, http.StatusOK, request, &response)
+	if err != nil {
+		return nil, errors.Wrap(err, "update customer")
+	}
+
+	return response.Customer, nil
+}
+
+func customerRequest(appID string, opts types.CustomerOptions) *UpdateCustomerRequest {
+	request := &UpdateCustomerRequest{
+		Name:              opts.Name,
+		ChannelID:         opts.ChannelID,
+		AppID:             appID,
+		Type:              opts.Type,
+		EntitlementValues: opts.EntitlementValues,
+
+		IsAirgapEnabled:     opts.IsAirgapEnabled,
+		IsGitopsSupported:   opts.IsGitopsSupported,
+		IsSnapshotSupported: opts.IsSnapshotSupported,
+	}
+	if opts.ExpiresAt != nil {
+		request.ExpiresAt = opts.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return request
+}
@@ -0,0 +1,29 @@
+package kotsclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/types"
+)
+
+type GetCustomerResponse struct {
+	Customer *types.Customer `json:"customer"`
+}
+
+func (c *VendorV3Client) GetCustomer(appID string, customerID string) (*types.Customer, error) {
+	return c.GetCustomerContext(context.Background(), appID, customerID)
+}
+
+func (c *VendorV3Client) GetCustomerContext(ctx context.Context, appID string, customerID string) (*types.Customer, error) {
+	var response GetCustomerResponse
+	path := fmt.Sprintf("/v3/app/%s/customer/%s", appID, customerID)
+	err := c.DoJSONContext(ctx, "GET", path, http.StatusOK, nil, &response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get customer %s", customerID)
+	}
+
+	return response.Customer, nil
+}
@@ -1,14 +1,19 @@
 package kotsclient
 
 import (
+	"context"
 	"fmt"
 	"github.com/pkg/errors"
 	"net/http"
 )
 
 func (c *VendorV3Client) DownloadLicense(appID string, customerID string) ([]byte, error) {
+	return c.DownloadLicenseContext(context.Background(), appID, customerID)
+}
+
+func (c *VendorV3Client) DownloadLicenseContext(ctx context.Context, appID string, customerID string) ([]byte, error) {
 	path := fmt.Sprintf("/v3/app/%s/customer/%s/license-download", appID, customerID)
-	licenseBytes, err := c.HTTPGet(path, http.StatusOK)
+	licenseBytes, err := c.HTTPGetContext(ctx, path, http.StatusOK)
 	if err != nil {
 		return nil, errors.Wrapf(err, "list channels")
 	}
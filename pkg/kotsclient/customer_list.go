@@ -1,6 +1,7 @@
 package kotsclient
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -24,12 +25,16 @@ type CustomerListResponse struct {
 }
 
 func (c *VendorV3Client) ListCustomers(appID string) ([]types.Customer, error) {
+	return c.ListCustomersContext(context.Background(), appID)
+}
+
+func (c *VendorV3Client) ListCustomersContext(ctx context.Context, appID string) ([]types.Customer, error) {
 	allCustomers := []types.Customer{}
 	page := 0
 	for {
 		resp := CustomerListResponse{}
 		path := fmt.Sprintf("/v3/app/%s/customers?currentPage=%d", appID, page)
-		err := c.DoJSON("GET", path, http.StatusOK, nil, &resp)
+		err := c.DoJSONContext(ctx, "GET", path, http.StatusOK, nil, &resp)
 		if err != nil {
 			return nil, errors.Wrapf(err, "list customers page %d", page)
 		}
@@ -47,7 +52,11 @@ func (c *VendorV3Client) ListCustomers(appID string) ([]types.Customer, error) {
 }
 
 func (c *VendorV3Client) GetCustomerByName(appID string, name string) (*types.Customer, error) {
-	allCustomers, err := c.ListCustomers(appID)
+	return c.GetCustomerByNameContext(context.Background(), appID, name)
+}
+
+func (c *VendorV3Client) GetCustomerByNameContext(ctx context.Context, appID string, name string) (*types.Customer, error) {
+	allCustomers, err := c.ListCustomersContext(ctx, appID)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package kotsclient
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -10,10 +11,14 @@ import (
 )
 
 func (c *VendorV3Client) ListInstallers(appID string) ([]types.InstallerSpec, error) {
+	return c.ListInstallersContext(context.Background(), appID)
+}
+
+func (c *VendorV3Client) ListInstallersContext(ctx context.Context, appID string) ([]types.InstallerSpec, error) {
 	var response types.ListInstallersResponse
 
 	url := fmt.Sprintf("/v3/app/%s/installers", appID)
-	err := c.DoJSON("GET", url, http.StatusOK, nil, &response)
+	err := c.DoJSONContext(ctx, "GET", url, http.StatusOK, nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -23,13 +28,17 @@ func (c *VendorV3Client) ListInstallers(appID string) ([]types.InstallerSpec, er
 		if err != nil {
 			return nil, errors.Wrap(err, "parsing time string to CreatedAt time")
 		}
-		response.Body[i].CreatedAt = util.Time{createdAtTime}
+		response.Body[i].CreatedAt = util.Time{Time: createdAtTime}
 	}
 
 	return response.Body, nil
 }
 
 func (c *VendorV3Client) CreateInstaller(appID string, yaml string) (*types.InstallerSpec, error) {
+	return c.CreateInstallerContext(context.Background(), appID, yaml)
+}
+
+func (c *VendorV3Client) CreateInstallerContext(ctx context.Context, appID string, yaml string) (*types.InstallerSpec, error) {
 	request := types.CreateInstallerRequest{
 		Yaml: yaml,
 	}
@@ -37,7 +46,7 @@ func (c *VendorV3Client) CreateInstaller(appID string, yaml string) (*types.Inst
 	var response types.InstallerSpecResponse
 
 	url := fmt.Sprintf("/v3/app/%s/installer", appID)
-	err := c.DoJSON("POST", url, http.StatusCreated, request, &response)
+	err := c.DoJSONContext(ctx, "POST", url, http.StatusCreated, request, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -46,6 +55,10 @@ func (c *VendorV3Client) CreateInstaller(appID string, yaml string) (*types.Inst
 }
 
 func (c *VendorV3Client) PromoteInstaller(appID string, sequence int64, channelID string, versionLabel string) error {
+	return c.PromoteInstallerContext(context.Background(), appID, sequence, channelID, versionLabel)
+}
+
+func (c *VendorV3Client) PromoteInstallerContext(ctx context.Context, appID string, sequence int64, channelID string, versionLabel string) error {
 	request := types.PromoteInstallerRequest{
 		Sequence:     sequence,
 		VersionLabel: versionLabel,
@@ -53,7 +66,7 @@ func (c *VendorV3Client) PromoteInstaller(appID string, sequence int64, channelI
 	}
 
 	url := fmt.Sprintf("/v3/app/%s/installer/promote", appID)
-	err := c.DoJSON("POST", url, http.StatusOK, request, nil)
+	err := c.DoJSONContext(ctx, "POST", url, http.StatusOK, request, nil)
 	if err != nil {
 		return err
 	}
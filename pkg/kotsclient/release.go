@@ -1,8 +1,9 @@
 package kotsclient
 
 import (
-	"bytes"
 	"compress/gzip"
+	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"net/http"
@@ -32,11 +33,15 @@ type KotsRelease struct {
 }
 
 func (c *VendorV3Client) GetRelease(appID string, sequence int64) (*releases.AppRelease, error) {
+	return c.GetReleaseContext(context.Background(), appID, sequence)
+}
+
+func (c *VendorV3Client) GetReleaseContext(ctx context.Context, appID string, sequence int64) (*releases.AppRelease, error) {
 	resp := types.KotsGetReleaseResponse{}
 
 	path := fmt.Sprintf("/v3/app/%s/release/%v", appID, sequence)
 
-	err := c.DoJSON("GET", path, http.StatusOK, nil, &resp)
+	err := c.DoJSONContext(ctx, "GET", path, http.StatusOK, nil, &resp)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get release")
 	}
@@ -52,26 +57,18 @@ func (c *VendorV3Client) GetRelease(appID string, sequence int64) (*releases.App
 }
 
 func (c *VendorV3Client) CreateRelease(appID string, multiyaml string) (*types.ReleaseInfo, error) {
-	gzipData := bytes.NewBuffer(nil)
-	gzipWriter := gzip.NewWriter(gzipData)
-	_, err := io.Copy(gzipWriter, strings.NewReader(multiyaml))
-	if err != nil {
-		gzipWriter.Close()
-		return nil, errors.Wrap(err, "failed to write gzip data")
-	}
-
-	if err := gzipWriter.Close(); err != nil {
-		return nil, errors.Wrap(err, "failed to close gzip writer")
-	}
+	return c.CreateReleaseContext(context.Background(), appID, multiyaml)
+}
 
-	request := types.KotsCreateReleaseRequest{
-		SpecGzip: gzipData.Bytes(),
+func (c *VendorV3Client) CreateReleaseContext(ctx context.Context, appID string, multiyaml string) (*types.ReleaseInfo, error) {
+	request := func() io.ReadCloser {
+		return specGzipRequest(multiyaml)
 	}
 
 	response := types.KotsGetReleaseResponse{}
 
 	url := fmt.Sprintf("/v3/app/%s/release", appID)
-	err = c.DoJSON("POST", url, http.StatusCreated, request, &response)
+	err := c.DoJSONStreamContext(ctx, "POST", url, http.StatusCreated, request, &response)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create release")
 	}
@@ -85,24 +82,16 @@ func (c *VendorV3Client) CreateRelease(appID string, multiyaml string) (*types.R
 }
 
 func (c *VendorV3Client) UpdateRelease(appID string, sequence int64, multiyaml string) error {
-	gzipData := bytes.NewBuffer(nil)
-	gzipWriter := gzip.NewWriter(gzipData)
-	_, err := io.Copy(gzipWriter, strings.NewReader(multiyaml))
-	if err != nil {
-		gzipWriter.Close()
-		return errors.Wrap(err, "failed to write gzip data")
-	}
-
-	if err := gzipWriter.Close(); err != nil {
-		return errors.Wrap(err, "failed to close gzip writer")
-	}
+	return c.UpdateReleaseContext(context.Background(), appID, sequence, multiyaml)
+}
 
-	request := types.KotsUpdateReleaseRequest{
-		SpecGzip: gzipData.Bytes(),
+func (c *VendorV3Client) UpdateReleaseContext(ctx context.Context, appID string, sequence int64, multiyaml string) error {
+	request := func() io.ReadCloser {
+		return specGzipRequest(multiyaml)
 	}
 
 	url := fmt.Sprintf("/v3/app/%s/release/%d", appID, sequence)
-	err = c.DoJSON("PUT", url, http.StatusOK, request, nil)
+	err := c.DoJSONStreamContext(ctx, "PUT", url, http.StatusOK, request, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to create release")
 	}
@@ -110,14 +99,51 @@ func (c *VendorV3Client) UpdateRelease(appID string, sequence int64, multiyaml s
 	return nil
 }
 
+// specGzipRequest streams the JSON body of a create or update release
+// request, the equivalent of KotsCreateReleaseRequest, gzipping and encoding
+// the spec as it is read instead of holding the whole body in memory
+func specGzipRequest(multiyaml string) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeSpecGzipRequest(pw, multiyaml))
+	}()
+	return pr
+}
+
+func writeSpecGzipRequest(w io.Writer, multiyaml string) error {
+	if _, err := io.WriteString(w, `{"spec_gzip":"`); err != nil {
+		return err
+	}
+
+	base64Writer := base64.NewEncoder(base64.StdEncoding, w)
+	gzipWriter := gzip.NewWriter(base64Writer)
+	if _, err := io.Copy(gzipWriter, strings.NewReader(multiyaml)); err != nil {
+		gzipWriter.Close()
+		return errors.Wrap(err, "failed to write gzip data")
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return errors.Wrap(err, "failed to close gzip writer")
+	}
+	if err := base64Writer.Close(); err != nil {
+		return errors.Wrap(err, "failed to close base64 writer")
+	}
+
+	_, err := io.WriteString(w, "\"}\n")
+	return err
+}
+
 func (c *VendorV3Client) ListReleases(appID string) ([]types.ReleaseInfo, error) {
+	return c.ListReleasesContext(context.Background(), appID)
+}
+
+func (c *VendorV3Client) ListReleasesContext(ctx context.Context, appID string) ([]types.ReleaseInfo, error) {
 	allReleases := []types.ReleaseInfo{}
 	done := false
 	page := 0
 	for !done {
 		resp := types.KotsListReleasesResponse{}
 		path := fmt.Sprintf("/v3/app/%s/releases?currentPage=%d&pageSize=20", appID, page)
-		err := c.DoJSON("GET", path, http.StatusOK, nil, &resp)
+		err := c.DoJSONContext(ctx, "GET", path, http.StatusOK, nil, &resp)
 		if err != nil {
 			done = true
 			continue
@@ -157,6 +183,10 @@ func (c *VendorV3Client) ListReleases(appID string) ([]types.ReleaseInfo, error)
 }
 
 func (c *VendorV3Client) PromoteRelease(appID, label, notes string, sequence int64, channelIDs ...string) error {
+	return c.PromoteReleaseContext(context.Background(), appID, label, notes, sequence, channelIDs...)
+}
+
+func (c *VendorV3Client) PromoteReleaseContext(ctx context.Context, appID, label, notes string, sequence int64, channelIDs ...string) error {
 	request := types.KotsPromoteReleaseRequest{
 		ReleaseNotes: notes,
 		VersionLabel: label,
@@ -164,7 +194,7 @@ func (c *VendorV3Client) PromoteRelease(appID, label, notes string, sequence int
 	}
 
 	path := fmt.Sprintf("/v3/app/%s/release/%v/promote", appID, sequence)
-	err := c.DoJSON("POST", path, http.StatusOK, request, nil)
+	err := c.DoJSONContext(ctx, "POST", path, http.StatusOK, request, nil)
 	if err != nil {
 		return err
 	}
@@ -2,6 +2,7 @@ package kotsclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -15,16 +16,20 @@ import (
 // this is part of the gql client with plans to rename gql client to kotsclient
 // and have endpoints for multiple release services included
 func (c *VendorV3Client) LintRelease(data []byte) ([]types.LintMessage, error) {
+	return c.LintReleaseContext(context.Background(), data)
+}
+
+func (c *VendorV3Client) LintReleaseContext(ctx context.Context, data []byte) ([]types.LintMessage, error) {
 	endpoint := "https://lint.replicated.com/v1/lint"
 
 	reader := bytes.NewReader(data)
-	req, err := http.NewRequest("POST", endpoint, reader)
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, reader)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create request")
 	}
 
 	req.Header.Set("User-Agent", fmt.Sprintf("Replicated/%s", version.Version()))
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.HTTP().Do(req)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to execute request")
 	}
@@ -0,0 +1,113 @@
+package kotsclient
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/replicatedhq/replicated/pkg/platformclient"
+	"github.com/replicatedhq/replicated/pkg/transport"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecGzipRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		multiyaml string
+	}{
+		{
+			name:      "empty",
+			multiyaml: "",
+		},
+		{
+			name:      "specs",
+			multiyaml: `[{"name":"app.yaml","path":"app.yaml","content":"kind: Application\n","children":[]}]`,
+		},
+		{
+			name:      "large",
+			multiyaml: strings.Repeat("0123456789abcdef", 1<<16),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the streamed body decodes the same as the buffered request type
+			require.Equal(t, test.multiyaml, decodeSpecGzip(t, specGzipRequest(test.multiyaml)))
+		})
+	}
+}
+
+func decodeSpecGzip(t *testing.T, body io.Reader) string {
+	request := types.KotsCreateReleaseRequest{}
+	require.NoError(t, json.NewDecoder(body).Decode(&request))
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(request.SpecGzip))
+	require.NoError(t, err)
+	spec, err := ioutil.ReadAll(gzipReader)
+	require.NoError(t, err)
+	return string(spec)
+}
+
+func TestReleaseRetriedWhenRateLimited(t *testing.T) {
+	multiyaml := `[{"name":"app.yaml","path":"app.yaml","content":"kind: Application\n","children":[]}]`
+
+	tests := []struct {
+		name          string
+		successStatus int
+		do            func(c *VendorV3Client) error
+	}{
+		{
+			name:          "create",
+			successStatus: http.StatusCreated,
+			do: func(c *VendorV3Client) error {
+				release, err := c.CreateRelease("app-1", multiyaml)
+				if err == nil && release.Sequence != 3 {
+					t.Errorf("got sequence %d, want 3", release.Sequence)
+				}
+				return err
+			},
+		},
+		{
+			name:          "update",
+			successStatus: http.StatusOK,
+			do: func(c *VendorV3Client) error {
+				return c.UpdateRelease("app-1", 3, multiyaml)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			var specs []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// every attempt has to carry the whole spec, not a drained body
+				specs = append(specs, decodeSpecGzip(t, r.Body))
+				if len(specs) == 1 {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.WriteHeader(test.successStatus)
+				w.Write([]byte(`{"release":{"appId":"app-1","sequence":3}}`))
+			}))
+			defer server.Close()
+
+			c := &VendorV3Client{HTTPClient: *platformclient.NewHTTPClient(server.URL, "token")}
+			c.SetHTTPClient(&http.Client{
+				Transport: &transport.RetryTransport{Base: http.DefaultTransport, MaxRetries: 2},
+			})
+
+			req.NoError(test.do(c))
+			req.Equal([]string{multiyaml, multiyaml}, specs)
+		})
+	}
+}
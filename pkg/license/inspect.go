@@ -0,0 +1,77 @@
+package license
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ExpiryNever    = "never"
+	ExpiryValid    = "valid"
+	ExpiryExpiring = "expiring"
+	ExpiryExpired  = "expired"
+)
+
+// An Inspection is everything there is to know about a license file
+type Inspection struct {
+	// License is the signed license data when it matches the signature,
+	// since that is what KOTS uses, and the license file otherwise
+	License   *License      `json:"license"`
+	Signature *Verification `json:"signature"`
+	ExpiresAt *time.Time    `json:"expiresAt"`
+	// ExpiryStatus is one of never, valid, expiring or expired
+	ExpiryStatus string `json:"expiryStatus"`
+}
+
+// Inspect verifies a license and works out whether it has expired or will
+// expire within warnWithin of now
+func Inspect(l *License, publicKeyPEM []byte, now time.Time, warnWithin time.Duration) (*Inspection, error) {
+	verification, err := Verify(l, publicKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	inspection := &Inspection{
+		License:   l,
+		Signature: verification,
+	}
+	if verification.DataVerified {
+		inspection.License = verification.Signed
+	}
+
+	expiresAt, err := inspection.License.ExpiresAt()
+	if err != nil {
+		return nil, err
+	}
+	inspection.ExpiresAt = expiresAt
+
+	switch {
+	case expiresAt == nil:
+		inspection.ExpiryStatus = ExpiryNever
+	case !now.Before(*expiresAt):
+		inspection.ExpiryStatus = ExpiryExpired
+	case now.Add(warnWithin).After(*expiresAt):
+		inspection.ExpiryStatus = ExpiryExpiring
+	default:
+		inspection.ExpiryStatus = ExpiryValid
+	}
+
+	return inspection, nil
+}
+
+// Check returns an error when the license can't be trusted or has expired.
+// A license can only be trusted when it was verified with the vendor key.
+func (i *Inspection) Check() error {
+	switch {
+	case i.Signature.Valid:
+	case i.Signature.DataVerified:
+		return errors.New("license signing key was not checked, a vendor public key is required")
+	default:
+		return errors.Errorf("license signature is invalid: %s", i.Signature.Error)
+	}
+	if i.ExpiryStatus == ExpiryExpired {
+		return errors.New("license has expired")
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// Package license decodes KOTS license files and verifies their signatures.
+package license
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// A License is a kots.io/v1beta1 License
+type License struct {
+	APIVersion string   `json:"apiVersion" yaml:"apiVersion"`
+	Kind       string   `json:"kind" yaml:"kind"`
+	Metadata   Metadata `json:"metadata" yaml:"metadata"`
+	Spec       Spec     `json:"spec" yaml:"spec"`
+}
+
+type Metadata struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+type Spec struct {
+	LicenseID       string `json:"licenseID" yaml:"licenseID"`
+	LicenseType     string `json:"licenseType,omitempty" yaml:"licenseType,omitempty"`
+	LicenseSequence int64  `json:"licenseSequence,omitempty" yaml:"licenseSequence,omitempty"`
+	AppSlug         string `json:"appSlug" yaml:"appSlug"`
+	ChannelID       string `json:"channelID,omitempty" yaml:"channelID,omitempty"`
+	ChannelName     string `json:"channelName,omitempty" yaml:"channelName,omitempty"`
+	CustomerName    string `json:"customerName,omitempty" yaml:"customerName,omitempty"`
+	CustomerEmail   string `json:"customerEmail,omitempty" yaml:"customerEmail,omitempty"`
+	Endpoint        string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+
+	Entitlements map[string]Entitlement `json:"entitlements,omitempty" yaml:"entitlements,omitempty"`
+
+	IsAirgapSupported              bool `json:"isAirgapSupported,omitempty" yaml:"isAirgapSupported,omitempty"`
+	IsGitOpsSupported              bool `json:"isGitOpsSupported,omitempty" yaml:"isGitOpsSupported,omitempty"`
+	IsSnapshotSupported            bool `json:"isSnapshotSupported,omitempty" yaml:"isSnapshotSupported,omitempty"`
+	IsSupportBundleUploadSupported bool `json:"isSupportBundleUploadSupported,omitempty" yaml:"isSupportBundleUploadSupported,omitempty"`
+	IsIdentityServiceSupported     bool `json:"isIdentityServiceSupported,omitempty" yaml:"isIdentityServiceSupported,omitempty"`
+	IsGeoaxisSupported             bool `json:"isGeoaxisSupported,omitempty" yaml:"isGeoaxisSupported,omitempty"`
+
+	// Signature is the base64 encoded outer signature
+	Signature []byte `json:"signature,omitempty" yaml:"-"`
+}
+
+// An Entitlement is a license field. Value holds whatever type the vendor
+// defined the field as.
+type Entitlement struct {
+	Title       string      `json:"title,omitempty" yaml:"title,omitempty"`
+	Description string      `json:"description,omitempty" yaml:"description,omitempty"`
+	Value       interface{} `json:"value" yaml:"value"`
+	ValueType   string      `json:"valueType,omitempty" yaml:"valueType,omitempty"`
+	IsHidden    bool        `json:"isHidden,omitempty" yaml:"isHidden,omitempty"`
+}
+
+// ExpiresAtField is the entitlement holding the license expiry
+const ExpiresAtField = "expires_at"
+
+// Load reads and decodes a license file
+func Load(filename string) (*License, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "read license")
+	}
+	return Decode(b)
+}
+
+// Decode decodes a license from yaml
+func Decode(b []byte) (*License, error) {
+	var l License
+	if err := yaml.Unmarshal(b, &l); err != nil {
+		return nil, errors.Wrap(err, "parse license")
+	}
+	if l.Kind != "License" {
+		return nil, errors.Errorf("expected kind License, got %q", l.Kind)
+	}
+
+	// yaml.v2 can't decode base64 into []byte, so the signature is read as
+	// a string and decoded here
+	var raw struct {
+		Spec struct {
+			Signature string `yaml:"signature"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(b, &raw); err != nil {
+		return nil, errors.Wrap(err, "parse license signature")
+	}
+	if raw.Spec.Signature != "" {
+		signature, err := base64.StdEncoding.DecodeString(raw.Spec.Signature)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode license signature")
+		}
+		l.Spec.Signature = signature
+	}
+
+	return &l, nil
+}
+
+// ExpiresAt returns when the license expires, or nil if it never does
+func (l *License) ExpiresAt() (*time.Time, error) {
+	entitlement, ok := l.Spec.Entitlements[ExpiresAtField]
+	if !ok || entitlement.Value == nil {
+		return nil, nil
+	}
+	value, ok := entitlement.Value.(string)
+	if !ok {
+		return nil, errors.Errorf("%s is not a timestamp", ExpiresAtField)
+	}
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse %s", ExpiresAtField)
+	}
+	return &t, nil
+}
+
+// FieldValue returns a license field as the KOTS LicenseFieldValue template
+// function does: a built-in field by its name in the spec, or the value of
+// the custom field with the name
+func (l *License) FieldValue(name string) (string, bool) {
+	switch name {
+	case "licenseID", "licenseId":
+		return l.Spec.LicenseID, true
+	case "licenseType":
+		return l.Spec.LicenseType, true
+	case "licenseSequence":
+		return strconv.FormatInt(l.Spec.LicenseSequence, 10), true
+	case "appSlug":
+		return l.Spec.AppSlug, true
+	case "channelID":
+		return l.Spec.ChannelID, true
+	case "channelName":
+		return l.Spec.ChannelName, true
+	case "customerName":
+		return l.Spec.CustomerName, true
+	case "customerEmail":
+		return l.Spec.CustomerEmail, true
+	case "endpoint":
+		return l.Spec.Endpoint, true
+	case "signature":
+		return base64.StdEncoding.EncodeToString(l.Spec.Signature), true
+	case "isAirgapSupported":
+		return strconv.FormatBool(l.Spec.IsAirgapSupported), true
+	case "isGitOpsSupported":
+		return strconv.FormatBool(l.Spec.IsGitOpsSupported), true
+	case "isSnapshotSupported":
+		return strconv.FormatBool(l.Spec.IsSnapshotSupported), true
+	case "isSupportBundleUploadSupported":
+		return strconv.FormatBool(l.Spec.IsSupportBundleUploadSupported), true
+	case "isIdentityServiceSupported":
+		return strconv.FormatBool(l.Spec.IsIdentityServiceSupported), true
+	case "isGeoaxisSupported":
+		return strconv.FormatBool(l.Spec.IsGeoaxisSupported), true
+	}
+
+	entitlement, ok := l.Spec.Entitlements[name]
+	if !ok {
+		return "", false
+	}
+	if entitlement.Value == nil {
+		return "", true
+	}
+	return fmt.Sprint(entitlement.Value), true
+}
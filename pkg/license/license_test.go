@@ -0,0 +1,294 @@
+package license
+
+import (
+	"crypto"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const licenseSpecYAML = `  licenseID: 1vusOokxAVp1tkRGuyxnF23PJcq
+  licenseType: prod
+  appSlug: my-app
+  channelName: Stable
+  customerName: Acme
+  entitlements:
+    expires_at:
+      title: Expiration
+      value: "2030-01-02T03:04:05Z"
+      valueType: String
+    seats:
+      title: Seats
+      value: 10
+      valueType: Integer
+  isAirgapSupported: true
+`
+
+func generateKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+// signedLicense returns a license file with specYAML as its spec, signed with
+// key over the license in signedSpecYAML
+func signedLicense(t *testing.T, key *rsa.PrivateKey, publicKeyPEM []byte, specYAML string, signedSpecYAML string) []byte {
+	req := require.New(t)
+
+	signed, err := Decode([]byte("apiVersion: kots.io/v1beta1\nkind: License\nspec:\n" + signedSpecYAML))
+	req.NoError(err)
+	licenseData, err := json.Marshal(signed)
+	req.NoError(err)
+
+	hashed := md5.Sum(licenseData)
+	licenseSignature, err := rsa.SignPSS(rand.Reader, key, crypto.MD5, hashed[:], nil)
+	req.NoError(err)
+
+	inner, err := json.Marshal(innerSignature{
+		LicenseSignature: licenseSignature,
+		PublicKey:        base64.StdEncoding.EncodeToString(publicKeyPEM),
+	})
+	req.NoError(err)
+	outer, err := json.Marshal(outerSignature{
+		LicenseData:    licenseData,
+		InnerSignature: inner,
+	})
+	req.NoError(err)
+
+	return []byte(fmt.Sprintf("apiVersion: kots.io/v1beta1\nkind: License\nmetadata:\n  name: acme\nspec:\n%s  signature: %s\n", specYAML, base64.StdEncoding.EncodeToString(outer)))
+}
+
+func TestDecode(t *testing.T) {
+	req := require.New(t)
+
+	key, publicKeyPEM := generateKey(t)
+	l, err := Decode(signedLicense(t, key, publicKeyPEM, licenseSpecYAML, licenseSpecYAML))
+	req.NoError(err)
+
+	req.Equal("Acme", l.Spec.CustomerName)
+	req.Equal("Stable", l.Spec.ChannelName)
+	req.True(l.Spec.IsAirgapSupported)
+	req.Equal(10, l.Spec.Entitlements["seats"].Value)
+	req.NotEmpty(l.Spec.Signature)
+
+	expiresAt, err := l.ExpiresAt()
+	req.NoError(err)
+	req.Equal(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC), *expiresAt)
+
+	_, err = Decode([]byte("kind: Config\n"))
+	req.EqualError(err, `expected kind License, got "Config"`)
+}
+
+func TestFieldValue(t *testing.T) {
+	l, err := Decode([]byte("apiVersion: kots.io/v1beta1\nkind: License\nspec:\n" + licenseSpecYAML))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		want      string
+		wantFound bool
+	}{
+		{name: "licenseID", want: "1vusOokxAVp1tkRGuyxnF23PJcq", wantFound: true},
+		{name: "licenseId", want: "1vusOokxAVp1tkRGuyxnF23PJcq", wantFound: true},
+		{name: "customerName", want: "Acme", wantFound: true},
+		{name: "isAirgapSupported", want: "true", wantFound: true},
+		{name: "isSnapshotSupported", want: "false", wantFound: true},
+		{name: "licenseSequence", want: "0", wantFound: true},
+		{name: "seats", want: "10", wantFound: true},
+		{name: "expires_at", want: "2030-01-02T03:04:05Z", wantFound: true},
+		{name: "missing", want: "", wantFound: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, found := l.FieldValue(test.name)
+			require.Equal(t, test.wantFound, found)
+			require.Equal(t, test.want, value)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	key, publicKeyPEM := generateKey(t)
+	_, otherPublicKeyPEM := generateKey(t)
+
+	tests := []struct {
+		name         string
+		license      []byte
+		publicKey    []byte
+		wantValid    bool
+		wantVerified bool
+		wantModified bool
+		wantError    string
+	}{
+		{
+			name:         "embedded key is not trusted",
+			license:      signedLicense(t, key, publicKeyPEM, licenseSpecYAML, licenseSpecYAML),
+			wantVerified: true,
+			wantError:    keyNotChecked,
+		},
+		{
+			name:         "expected key",
+			license:      signedLicense(t, key, publicKeyPEM, licenseSpecYAML, licenseSpecYAML),
+			publicKey:    publicKeyPEM,
+			wantValid:    true,
+			wantVerified: true,
+		},
+		{
+			name:      "different key",
+			license:   signedLicense(t, key, publicKeyPEM, licenseSpecYAML, licenseSpecYAML),
+			publicKey: otherPublicKeyPEM,
+			wantError: "license is signed with a different key",
+		},
+		{
+			name:      "embedded key does not match signature",
+			license:   signedLicense(t, key, otherPublicKeyPEM, licenseSpecYAML, licenseSpecYAML),
+			wantError: "verify license signature: crypto/rsa: verification error",
+		},
+		{
+			name:         "edited fields",
+			license:      signedLicense(t, key, publicKeyPEM, licenseSpecYAML+"  isSnapshotSupported: true\n", licenseSpecYAML),
+			publicKey:    publicKeyPEM,
+			wantValid:    true,
+			wantVerified: true,
+			wantModified: true,
+		},
+		{
+			name:      "unsigned",
+			license:   []byte("apiVersion: kots.io/v1beta1\nkind: License\nspec:\n" + licenseSpecYAML),
+			publicKey: publicKeyPEM,
+			wantError: "license is not signed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			l, err := Decode(test.license)
+			req.NoError(err)
+
+			result, err := Verify(l, test.publicKey)
+			req.NoError(err)
+			req.Equal(test.wantValid, result.Valid)
+			req.Equal(test.wantVerified, result.DataVerified)
+			req.Equal(test.wantModified, result.Modified)
+			req.Equal(test.wantError, result.Error)
+			req.Equal(len(test.publicKey) > 0, result.KeyChecked)
+			if test.wantVerified {
+				req.Equal("Acme", result.Signed.Spec.CustomerName)
+			}
+		})
+	}
+}
+
+func TestInspectCheck(t *testing.T) {
+	key, publicKeyPEM := generateKey(t)
+	_, otherPublicKeyPEM := generateKey(t)
+	signed := signedLicense(t, key, publicKeyPEM, licenseSpecYAML, licenseSpecYAML)
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		license   []byte
+		publicKey []byte
+		now       time.Time
+		wantErr   string
+	}{
+		{
+			name:      "verified",
+			license:   signed,
+			publicKey: publicKeyPEM,
+			now:       expiresAt.Add(-time.Hour),
+		},
+		{
+			name:    "signing key not checked",
+			license: signed,
+			now:     expiresAt.Add(-time.Hour),
+			wantErr: "license signing key was not checked, a vendor public key is required",
+		},
+		{
+			name:      "different key",
+			license:   signed,
+			publicKey: otherPublicKeyPEM,
+			now:       expiresAt.Add(-time.Hour),
+			wantErr:   "license signature is invalid: license is signed with a different key",
+		},
+		{
+			name:      "unsigned",
+			license:   []byte("apiVersion: kots.io/v1beta1\nkind: License\nspec:\n" + licenseSpecYAML),
+			publicKey: publicKeyPEM,
+			now:       expiresAt.Add(-time.Hour),
+			wantErr:   "license signature is invalid: license is not signed",
+		},
+		{
+			name:      "expired",
+			license:   signed,
+			publicKey: publicKeyPEM,
+			now:       expiresAt,
+			wantErr:   "license has expired",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			l, err := Decode(test.license)
+			req.NoError(err)
+			inspection, err := Inspect(l, test.publicKey, test.now, 0)
+			req.NoError(err)
+
+			err = inspection.Check()
+			if test.wantErr != "" {
+				req.EqualError(err, test.wantErr)
+			} else {
+				req.NoError(err)
+			}
+		})
+	}
+}
+
+func TestInspectExpiry(t *testing.T) {
+	key, publicKeyPEM := generateKey(t)
+	l, err := Decode(signedLicense(t, key, publicKeyPEM, licenseSpecYAML, licenseSpecYAML))
+	require.NoError(t, err)
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want string
+	}{
+		{name: "valid", now: expiresAt.Add(-60 * 24 * time.Hour), want: ExpiryValid},
+		{name: "expiring", now: expiresAt.Add(-24 * time.Hour), want: ExpiryExpiring},
+		{name: "expired", now: expiresAt, want: ExpiryExpired},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			inspection, err := Inspect(l, nil, test.now, 30*24*time.Hour)
+			require.NoError(t, err)
+			require.Equal(t, test.want, inspection.ExpiryStatus)
+			require.Equal(t, expiresAt, *inspection.ExpiresAt)
+		})
+	}
+
+	unsigned, err := Decode([]byte("kind: License\nspec:\n  licenseID: abc\n"))
+	require.NoError(t, err)
+	inspection, err := Inspect(unsigned, nil, expiresAt, 0)
+	require.NoError(t, err)
+	require.Equal(t, ExpiryNever, inspection.ExpiryStatus)
+	require.False(t, inspection.Signature.Valid)
+}
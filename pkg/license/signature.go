@@ -0,0 +1,164 @@
+package license
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/md5"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+
+	"github.com/pkg/errors"
+)
+
+// The license signature is JSON nested three levels deep. The outer signature
+// holds the signed license data and the inner signature, which holds the
+// signature of the license data and the vendor's public key. The inner
+// signature also has Replicated's signature of the vendor key, which is not
+// checked here: the vendor key is compared to the expected key instead.
+type outerSignature struct {
+	LicenseData    []byte `json:"licenseData"`
+	InnerSignature []byte `json:"innerSignature"`
+}
+
+type innerSignature struct {
+	LicenseSignature []byte `json:"licenseSignature"`
+	// PublicKey is the base64 encoded PEM of the vendor's public key
+	PublicKey string `json:"publicKey"`
+}
+
+// Verification is the result of checking a license signature
+type Verification struct {
+	// Valid is true when the license data is signed by the expected vendor
+	// key. A license is never valid when no expected key was given.
+	Valid bool `json:"valid"`
+	// DataVerified is true when the license data is signed by the key
+	// embedded in the signature. On its own this does not show who signed
+	// the license, anyone can sign a license with their own key.
+	DataVerified bool `json:"dataVerified"`
+	// KeyChecked is true when the signing key was compared to an expected
+	// vendor key
+	KeyChecked bool `json:"keyChecked"`
+	// Modified is true when the fields in the license file differ from the
+	// signed license data
+	Modified bool `json:"modified"`
+	// Error explains why the signature is not valid
+	Error string `json:"error,omitempty"`
+	// Signed is the license as it was signed
+	Signed *License `json:"-"`
+}
+
+// keyNotChecked is the verification error of a license whose data matches its
+// signature, when there was no expected key to check the signing key with
+const keyNotChecked = "the signing key was not checked against a vendor public key"
+
+// Verify checks the license signature. The license is only valid when it is
+// signed with publicKeyPEM. Without publicKeyPEM the license data is still
+// checked against the key embedded in the signature, but the result is not
+// valid since that key is not trusted. Problems with the signature are
+// reported in the result rather than returned as errors.
+func Verify(l *License, publicKeyPEM []byte) (*Verification, error) {
+	result := &Verification{KeyChecked: len(publicKeyPEM) > 0}
+	if len(l.Spec.Signature) == 0 {
+		result.Error = "license is not signed"
+		return result, nil
+	}
+
+	outer := outerSignature{}
+	if err := json.Unmarshal(l.Spec.Signature, &outer); err != nil {
+		result.Error = errors.Wrap(err, "parse outer signature").Error()
+		return result, nil
+	}
+	inner := innerSignature{}
+	if err := json.Unmarshal(outer.InnerSignature, &inner); err != nil {
+		result.Error = errors.Wrap(err, "parse inner signature").Error()
+		return result, nil
+	}
+	embeddedKey, err := base64.StdEncoding.DecodeString(inner.PublicKey)
+	if err != nil {
+		result.Error = errors.Wrap(err, "decode signing key").Error()
+		return result, nil
+	}
+
+	signingKey, err := parsePublicKey(embeddedKey)
+	if err != nil {
+		result.Error = errors.Wrap(err, "parse signing key").Error()
+		return result, nil
+	}
+	if result.KeyChecked {
+		expectedKey, err := parsePublicKey(publicKeyPEM)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse public key")
+		}
+		if !expectedKey.Equal(signingKey) {
+			result.Error = "license is signed with a different key"
+			return result, nil
+		}
+	}
+
+	if err := verifyPSS(signingKey, outer.LicenseData, inner.LicenseSignature); err != nil {
+		result.Error = errors.Wrap(err, "verify license signature").Error()
+		return result, nil
+	}
+	result.DataVerified = true
+	result.Valid = result.KeyChecked
+	if !result.KeyChecked {
+		result.Error = keyNotChecked
+	}
+
+	signed := License{}
+	if err := json.Unmarshal(outer.LicenseData, &signed); err != nil {
+		return nil, errors.Wrap(err, "parse signed license data")
+	}
+	result.Signed = &signed
+
+	modified, err := specsDiffer(l.Spec, signed.Spec)
+	if err != nil {
+		return nil, err
+	}
+	result.Modified = modified
+
+	return result, nil
+}
+
+func parsePublicKey(publicKeyPEM []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(publicKeyPEM)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("not an RSA public key")
+	}
+	return rsaKey, nil
+}
+
+// verifyPSS checks an RSA-PSS signature over the MD5 of message, the scheme
+// KOTS licenses are signed with
+func verifyPSS(key *rsa.PublicKey, message []byte, signature []byte) error {
+	hashed := md5.Sum(message)
+	return rsa.VerifyPSS(key, crypto.MD5, hashed[:], signature, &rsa.PSSOptions{
+		SaltLength: rsa.PSSSaltLengthAuto,
+	})
+}
+
+// specsDiffer compares two specs, ignoring the signature
+func specsDiffer(a Spec, b Spec) (bool, error) {
+	a.Signature = nil
+	b.Signature = nil
+	aJSON, err := json.Marshal(a)
+	if err != nil {
+		return false, errors.Wrap(err, "marshal license")
+	}
+	bJSON, err := json.Marshal(b)
+	if err != nil {
+		return false, errors.Wrap(err, "marshal signed license")
+	}
+	return !bytes.Equal(aJSON, bJSON), nil
+}
@@ -0,0 +1,87 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/replicatedhq/replicated/pkg/types"
+)
+
+var troubleshootGroups = map[string]bool{
+	"troubleshoot.sh":             true,
+	"troubleshoot.replicated.com": true,
+}
+
+type requiredKind struct {
+	rule    string
+	name    string
+	matches func(d document) bool
+}
+
+var requiredKinds = []requiredKind{
+	{
+		rule: "application-spec",
+		name: "application",
+		matches: func(d document) bool {
+			return d.group() == "kots.io" && d.Kind == "Application"
+		},
+	},
+	{
+		rule: "config-spec",
+		name: "config",
+		matches: func(d document) bool {
+			return d.group() == "kots.io" && d.Kind == "Config"
+		},
+	},
+	{
+		rule: "preflight-spec",
+		name: "preflight",
+		matches: func(d document) bool {
+			return troubleshootGroups[d.group()] && d.Kind == "Preflight"
+		},
+	},
+	{
+		rule: "troubleshoot-spec",
+		name: "troubleshoot",
+		matches: func(d document) bool {
+			return troubleshootGroups[d.group()] && (d.Kind == "SupportBundle" || d.Kind == "Collector")
+		},
+	},
+}
+
+// lintRequiredKinds warns about each KOTS kind that is missing from the release
+func lintRequiredKinds(docs []document) []types.LintMessage {
+	var messages []types.LintMessage
+	for _, required := range requiredKinds {
+		found := false
+		for _, doc := range docs {
+			if required.matches(doc) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			messages = append(messages, newMessage(required.rule, "warn", "", 0, fmt.Sprintf("Missing %s spec", required.name)))
+		}
+	}
+	return messages
+}
+
+// lintDuplicateNames reports resources that share a kind, namespace and name
+// with a resource defined earlier in the release
+func lintDuplicateNames(docs []document) []types.LintMessage {
+	var messages []types.LintMessage
+	seen := map[string]document{}
+	for _, doc := range docs {
+		if doc.Kind == "" || doc.Metadata.Name == "" {
+			continue
+		}
+		key := fmt.Sprintf("%s/%s/%s/%s", doc.group(), doc.Kind, doc.Metadata.Namespace, doc.Metadata.Name)
+		if first, ok := seen[key]; ok {
+			messages = append(messages, newMessage("duplicate-resource-name", "error", doc.Path, doc.Line,
+				fmt.Sprintf("%s %q is already defined in %s on line %d", doc.Kind, doc.Metadata.Name, first.Path, first.Line)))
+			continue
+		}
+		seen[key] = doc
+	}
+	return messages
+}
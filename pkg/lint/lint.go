@@ -0,0 +1,125 @@
+// Package lint checks a directory of KOTS manifests in-process, without
+// sending anything to the hosted lint service, and renders their templates.
+package lint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/releasefiles"
+	"github.com/replicatedhq/replicated/pkg/types"
+)
+
+// A File is a single manifest from a release, keyed by its path relative to
+// the release root.
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// LintDir reads the KOTS manifests in yamlDir and lints them. Only the yaml
+// files that would be included in a release are read.
+func LintDir(yamlDir string, opts releasefiles.Options) ([]types.LintMessage, error) {
+	files, err := ReadDir(yamlDir, opts)
+	if err != nil {
+		return nil, err
+	}
+	return Lint(files), nil
+}
+
+// ReadDir returns the yaml files of the release in yamlDir, with paths
+// relative to yamlDir.
+func ReadDir(yamlDir string, opts releasefiles.Options) ([]File, error) {
+	selector, err := releasefiles.NewSelector(yamlDir, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []File
+	err = selector.Walk(func(path string, relPath string, info os.FileInfo) error {
+		switch filepath.Ext(info.Name()) {
+		case ".yaml", ".yml":
+		default:
+			return nil
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "read file %s", path)
+		}
+		files = append(files, File{
+			Path:    relPath,
+			Content: content,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "walk %s", yamlDir)
+	}
+	return files, nil
+}
+
+// Lint runs every local rule against files and returns the findings sorted by
+// path and line.
+func Lint(files []File) []types.LintMessage {
+	var docs []document
+	var messages []types.LintMessage
+	for _, file := range files {
+		fileDocs, fileMessages := parseFile(file)
+		docs = append(docs, fileDocs...)
+		messages = append(messages, fileMessages...)
+	}
+
+	for _, file := range files {
+		messages = append(messages, lintTemplates(file)...)
+		messages = append(messages, lintTroubleshoot(file)...)
+	}
+	messages = append(messages, lintRequiredKinds(docs)...)
+	messages = append(messages, lintDuplicateNames(docs)...)
+
+	sort.SliceStable(messages, func(i, j int) bool {
+		if messages[i].Path != messages[j].Path {
+			return messages[i].Path < messages[j].Path
+		}
+		return firstLine(messages[i]) < firstLine(messages[j])
+	})
+	return messages
+}
+
+// LintTroubleshoot runs only the troubleshoot.sh spec rules against files,
+// for use alongside the hosted lint service.
+func LintTroubleshoot(files []File) []types.LintMessage {
+	var messages []types.LintMessage
+	for _, file := range files {
+		messages = append(messages, lintTroubleshoot(file)...)
+	}
+	return messages
+}
+
+func firstLine(msg types.LintMessage) int64 {
+	if len(msg.Positions) == 0 {
+		return 0
+	}
+	return msg.Positions[0].Start.Line
+}
+
+func newMessage(rule, severity, path string, line int64, message string) types.LintMessage {
+	msg := types.LintMessage{
+		Rule:    rule,
+		Type:    severity,
+		Path:    path,
+		Message: message,
+	}
+	if line > 0 {
+		msg.Positions = []*types.LintPosition{
+			{
+				Path:  path,
+				Start: types.LintLinePosition{Line: line},
+			},
+		}
+	}
+	return msg
+}
@@ -0,0 +1,338 @@
+package lint
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+var requiredSpecs = File{
+	Path: "kots.yaml",
+	Content: []byte(`apiVersion: kots.io/v1beta1
+kind: Application
+metadata:
+  name: app
+---
+apiVersion: kots.io/v1beta1
+kind: Config
+metadata:
+  name: config
+---
+apiVersion: troubleshoot.sh/v1beta2
+kind: Preflight
+metadata:
+  name: preflight
+---
+apiVersion: troubleshoot.sh/v1beta2
+kind: SupportBundle
+metadata:
+  name: support-bundle
+`),
+}
+
+func rulesAndLines(messages []types.LintMessage) []string {
+	var results []string
+	for _, msg := range messages {
+		line := ""
+		if len(msg.Positions) > 0 {
+			line = fmt.Sprint(msg.Positions[0].Start.Line)
+		}
+		results = append(results, fmt.Sprintf("%s:%s:%s", msg.Rule, msg.Path, line))
+	}
+	return results
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []File
+		want  []string
+	}{
+		{
+			name:  "all required kinds",
+			files: []File{requiredSpecs},
+			want:  nil,
+		},
+		{
+			name: "missing required kinds",
+			files: []File{
+				{
+					Path:    "config-map.yaml",
+					Content: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: fake\n"),
+				},
+			},
+			want: []string{
+				"application-spec::",
+				"config-spec::",
+				"preflight-spec::",
+				"troubleshoot-spec::",
+			},
+		},
+		{
+			name: "invalid yaml",
+			files: []File{
+				requiredSpecs,
+				{
+					Path:    "broken.yaml",
+					Content: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: fake\n---\napiVersion: v1\nkind: [\n"),
+				},
+			},
+			want: []string{
+				"invalid-yaml:broken.yaml:7",
+			},
+		},
+		{
+			name: "duplicate names",
+			files: []File{
+				requiredSpecs,
+				{
+					Path:    "a.yaml",
+					Content: []byte("apiVersion: v1\nkind: Service\nmetadata:\n  name: web\n"),
+				},
+				{
+					Path:    "b.yaml",
+					Content: []byte("apiVersion: v1\nkind: Secret\nmetadata:\n  name: web\n---\napiVersion: v1\nkind: Service\nmetadata:\n  name: web\n"),
+				},
+			},
+			want: []string{
+				"duplicate-resource-name:b.yaml:6",
+			},
+		},
+		{
+			name: "templates",
+			files: []File{
+				requiredSpecs,
+				{
+					Path: "deployment.yaml",
+					Content: []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  annotations:
+    ok: repl{{ ConfigOption "hostname" }}
+    unquoted: {{repl ConfigOptionEquals "tls" "1" }}
+    unknown: repl{{ ConfigOptionz "hostname" }}
+    syntax: '{{repl ConfigOption "hostname" ) }}'
+    unterminated: repl{{ ConfigOption "x"
+`),
+				},
+			},
+			want: []string{
+				"invalid-template:deployment.yaml:8",
+				"invalid-template:deployment.yaml:9",
+				"invalid-template:deployment.yaml:10",
+			},
+		},
+		{
+			name: "template blocks",
+			files: []File{
+				requiredSpecs,
+				{
+					Path: "deployment.yaml",
+					Content: []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+        - name: web
+          env:
+repl{{ if ConfigOptionEquals "db_type" "embedded" }}
+            - name: DB_HOST
+              value: postgres
+repl{{ else if ConfigOptionEquals "db_type" "external" }}
+            - name: DB_HOST
+              value: '{{repl ConfigOption "db_host" }}'
+repl{{ else }}
+            - name: DB_HOST
+              value: localhost
+repl{{ end }}
+repl{{ range $i, $host := Split (ConfigOption "hosts") "," }}
+            - name: HOST_repl{{ $i }}
+              value: repl{{ $host }}
+repl{{ end }}
+repl{{ with ConfigOption "proxy" }}
+            - name: HTTP_PROXY
+              value: repl{{ . }}
+repl{{ end }}
+`),
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid template blocks",
+			files: []File{
+				requiredSpecs,
+				{
+					Path: "unclosed.yaml",
+					Content: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unclosed
+data:
+  # repl{{ if ConfigOptionEquals "tls" "1" }}
+  tls: "true"
+  # repl{{ range Split "a,b" "," }}
+  item: x
+  # repl{{ end }}
+`),
+				},
+				{
+					Path: "stray.yaml",
+					Content: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: stray
+data:
+  # repl{{ if ConfigOptionEquals "tls" "1" }}
+  tls: "true"
+  # repl{{ end }}
+  # repl{{ else }}
+  # repl{{ end }}
+`),
+				},
+				{
+					Path: "inside.yaml",
+					Content: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: inside
+data:
+  # repl{{ if ConfigOptionz "tls" }}
+  tls: repl{{ ConfigOption "tls" ) }}
+  # repl{{ else }}
+  tls: repl{{ ConfigOptionz "tls" }}
+  # repl{{ end }}
+`),
+				},
+			},
+			want: []string{
+				"invalid-template:inside.yaml:6",
+				"invalid-template:inside.yaml:7",
+				"invalid-template:inside.yaml:9",
+				"invalid-template:stray.yaml:9",
+				"invalid-template:unclosed.yaml:6",
+			},
+		},
+		{
+			name: "troubleshoot specs",
+			files: []File{
+				requiredSpecs,
+				{
+					Path: "support-bundle.yaml",
+					Content: []byte(`apiVersion: troubleshoot.sh/v1beta2
+kind: SupportBundle
+metadata:
+  name: bundle
+spec:
+  collectors:
+    - clusterInfo: {}
+    - clusterResourcez: {}
+  analyzers:
+    - clusterVersion:
+        outcomes:
+          - fail:
+              when: "< 1.16.0"
+          - warn:
+              when: "< one.two"
+    - nodeResources:
+        outcomes:
+          - fail:
+              when: "count() < 3"
+          - pass:
+              when: "count() <"
+    - deploymentStatus:
+        outcomes:
+          - fail:
+              when: "absent"
+          - warn:
+              when: "< some"
+    - magic: {}
+`),
+				},
+				{
+					Path: "redactor.yaml",
+					Content: []byte(`apiVersion: troubleshoot.sh/v1beta2
+kind: Redactor
+metadata:
+  name: redactor
+spec:
+  redactors:
+    - name: passwords
+      removals:
+        regex:
+          - redactor: '(?i)(password=)(?P<mask>.*)'
+          - selector: '(unclosed'
+            redactor: 'ok'
+`),
+				},
+			},
+			want: []string{
+				"invalid-redactor-regex:redactor.yaml:11",
+				"unknown-collector:support-bundle.yaml:8",
+				"invalid-outcome:support-bundle.yaml:15",
+				"invalid-outcome:support-bundle.yaml:21",
+				"invalid-outcome:support-bundle.yaml:27",
+				"unknown-analyzer:support-bundle.yaml:28",
+			},
+		},
+		{
+			name: "troubleshoot version ranges",
+			files: []File{
+				requiredSpecs,
+				{
+					Path: "versions.yaml",
+					Content: []byte(`apiVersion: troubleshoot.sh/v1beta2
+kind: Preflight
+metadata:
+  name: versions
+spec:
+  analyzers:
+    - clusterVersion:
+        outcomes:
+          - fail:
+              when: ">= 1.22.0 || < 1.10.0"
+          - warn:
+              when: ">=1.16.0 <1.22.0"
+          - warn:
+              when: ">= 1.16.0 < 1.22.0"
+          - warn:
+              when: "1.21.x"
+          - warn:
+              when: ">= 1.16.0, < 1.22.0"
+          - pass:
+              when: "< 1.16"
+`),
+				},
+			},
+			want: []string{
+				"invalid-outcome:versions.yaml:18",
+				"invalid-outcome:versions.yaml:20",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			got := Lint(tt.files)
+			req.Equal(tt.want, rulesAndLines(got))
+		})
+	}
+}
+
+func TestInvalidYAMLMessageLine(t *testing.T) {
+	req := require.New(t)
+
+	_, messages := parseFile(File{
+		Path:    "broken.yaml",
+		Content: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: fake\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a: b\n"),
+	})
+	req.Len(messages, 1)
+	req.Equal(int64(9), messages[0].Positions[0].Start.Line)
+	req.Equal("Invalid YAML: line 9: mapping values are not allowed in this context", messages[0].Message)
+}
@@ -0,0 +1,460 @@
+package lint
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/license"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"gopkg.in/yaml.v2"
+)
+
+// RenderOptions are the inputs KOTS templates read from the install
+type RenderOptions struct {
+	// ConfigValues is a kots.io ConfigValues manifest. Config items without
+	// a value fall back to the value and default in the release's Config.
+	ConfigValues []byte
+	// License is a kots.io License manifest, required by LicenseFieldValue
+	License []byte
+	// Namespace is returned by the Namespace function
+	Namespace string
+}
+
+// A RenderedFile is a release file with its templates evaluated. Content is
+// empty when rendering failed, and Errors says why.
+type RenderedFile struct {
+	Path    string
+	Content []byte
+	Errors  []types.LintMessage
+}
+
+var renderErrRegex = regexp.MustCompile(`^(\d+):(?:\d+:)? ?`)
+
+// Render evaluates the KOTS templates in files the way KOTS would when
+// installing, with the config values and license in opts. Functions that need
+// a cluster, like Lookup or KubeSeal, return an error.
+func Render(files []File, opts RenderOptions) ([]RenderedFile, error) {
+	renderer, err := newRenderer(files, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := make([]RenderedFile, 0, len(files))
+	for _, file := range files {
+		content, err := renderer.render(file.Path, string(file.Content))
+		if err != nil {
+			rendered = append(rendered, RenderedFile{Path: file.Path, Errors: []types.LintMessage{renderMessage(file.Path, err)}})
+			continue
+		}
+		rendered = append(rendered, RenderedFile{Path: file.Path, Content: []byte(content)})
+	}
+	return rendered, nil
+}
+
+// renderMessage turns a template error into a message at the line it refers
+// to. repl{{ is the same length as {{repl, so template lines are file lines.
+func renderMessage(path string, err error) types.LintMessage {
+	msg := strings.TrimPrefix(err.Error(), "template: "+path+":")
+	line := int64(1)
+	if m := renderErrRegex.FindStringSubmatch(msg); m != nil {
+		if n, convErr := strconv.ParseInt(m[1], 10, 64); convErr == nil {
+			line = n
+		}
+		msg = msg[len(m[0]):]
+	}
+	msg = strings.TrimPrefix(msg, fmt.Sprintf("executing %q at ", path))
+	return newMessage("render-template", "error", path, line, msg)
+}
+
+type configItem struct {
+	Name    string `yaml:"name"`
+	Value   string `yaml:"value"`
+	Default string `yaml:"default"`
+}
+
+type configSpec struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Spec       struct {
+		Groups []struct {
+			Items []configItem `yaml:"items"`
+		} `yaml:"groups"`
+	} `yaml:"spec"`
+}
+
+type configValue struct {
+	Value          string `yaml:"value"`
+	ValuePlaintext string `yaml:"valuePlaintext"`
+	Filename       string `yaml:"filename"`
+}
+
+type configValuesSpec struct {
+	Kind string `yaml:"kind"`
+	Spec struct {
+		Values map[string]configValue `yaml:"values"`
+	} `yaml:"spec"`
+}
+
+type renderer struct {
+	opts    RenderOptions
+	items   map[string]configItem
+	values  map[string]configValue
+	license *license.License
+	funcs   template.FuncMap
+	// resolving holds the config items being rendered, to catch cycles
+	resolving map[string]bool
+}
+
+func newRenderer(files []File, opts RenderOptions) (*renderer, error) {
+	r := &renderer{
+		opts:      opts,
+		items:     map[string]configItem{},
+		values:    map[string]configValue{},
+		resolving: map[string]bool{},
+	}
+
+	for _, file := range files {
+		for _, chunk := range splitDocuments(string(file.Content)) {
+			// defaults are often templates themselves. A scalar can't start
+			// with {{repl, but it can with repl{{, which renders the same.
+			content := strings.ReplaceAll(chunk.content, "{{repl", "repl{{")
+			config := configSpec{}
+			err := yaml.Unmarshal([]byte(content), &config)
+			if _, isTypeErr := err.(*yaml.TypeError); err != nil && !isTypeErr {
+				continue
+			}
+			if config.Kind != "Config" || !strings.HasPrefix(config.APIVersion, "kots.io/") {
+				continue
+			}
+			for _, group := range config.Spec.Groups {
+				for _, item := range group.Items {
+					r.items[item.Name] = item
+				}
+			}
+		}
+	}
+
+	if len(opts.ConfigValues) > 0 {
+		values := configValuesSpec{}
+		if err := yaml.Unmarshal(opts.ConfigValues, &values); err != nil {
+			return nil, errors.Wrap(err, "parse config values")
+		}
+		if values.Kind != "ConfigValues" {
+			return nil, errors.Errorf("config values must be a ConfigValues manifest, got kind %q", values.Kind)
+		}
+		for name, value := range values.Spec.Values {
+			r.values[name] = value
+		}
+	}
+
+	if len(opts.License) > 0 {
+		l, err := license.Decode(opts.License)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode license")
+		}
+		r.license = l
+	}
+
+	r.funcs = r.funcMap()
+	return r, nil
+}
+
+func (r *renderer) render(name string, content string) (string, error) {
+	content = strings.ReplaceAll(content, "repl{{", "{{repl")
+	tmpl, err := template.New(name).Delims("{{repl", "}}").Funcs(r.funcs).Parse(content)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// funcMap has every function in kotsTemplateFuncs. The sprig functions come
+// from sprig, as they do in KOTS, and the rest that need an install fail.
+func (r *renderer) funcMap() template.FuncMap {
+	kots := template.FuncMap{
+		"ConfigOption":          r.configOption,
+		"ConfigOptionData":      r.configOptionData,
+		"ConfigOptionFilename":  r.configOptionFilename,
+		"ConfigOptionEquals":    r.configOptionEquals,
+		"ConfigOptionNotEquals": r.configOptionNotEquals,
+		"LicenseFieldValue":     r.licenseFieldValue,
+		"Namespace":             func() string { return r.opts.Namespace },
+
+		"HasLocalRegistry":       func() bool { return false },
+		"LocalRegistryAddress":   func() string { return "" },
+		"LocalRegistryHost":      func() string { return "" },
+		"LocalRegistryNamespace": func() string { return "" },
+		"LocalImageName":         func(image string) string { return image },
+		"IsKurl":                 func() bool { return false },
+		"Distribution":           func() string { return "" },
+		"NodeCount":              func() int { return 0 },
+		"HTTPProxy":              func() string { return "" },
+		"HTTPSProxy":             func() string { return "" },
+		"NoProxy":                func() string { return "" },
+		"IdentityServiceEnabled": func() bool { return false },
+
+		"Now":          func() string { return time.Now().UTC().Format(time.RFC3339) },
+		"NowFmt":       func(format string) string { return time.Now().UTC().Format(format) },
+		"ToLower":      strings.ToLower,
+		"ToUpper":      strings.ToUpper,
+		"TrimSpace":    strings.TrimSpace,
+		"Trim":         trim,
+		"UrlEncode":    url.QueryEscape,
+		"Base64Encode": func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+		"Base64Decode": base64Decode,
+		"Split":        strings.Split,
+		"RandomString": randomString,
+		"RandomBytes":  randomBytes,
+		"Add":          func(a, b interface{}) (interface{}, error) { return arithmetic(a, b, "Add") },
+		"Sub":          func(a, b interface{}) (interface{}, error) { return arithmetic(a, b, "Sub") },
+		"Mult":         func(a, b interface{}) (interface{}, error) { return arithmetic(a, b, "Mult") },
+		"Div":          func(a, b interface{}) (interface{}, error) { return arithmetic(a, b, "Div") },
+		"ParseBool":    strconv.ParseBool,
+		"ParseFloat":   func(s string) (float64, error) { return strconv.ParseFloat(s, 64) },
+		"ParseInt":     parseInt,
+		"ParseUint":    parseUint,
+	}
+	sprigFuncs := sprig.TxtFuncMap()
+
+	funcs := template.FuncMap{}
+	for _, name := range kotsTemplateFuncs {
+		if fn, ok := kots[name]; ok {
+			funcs[name] = fn
+		} else if fn, ok := sprigFuncs[name]; ok {
+			funcs[name] = fn
+		} else {
+			funcs[name] = unsupportedFunc(name)
+		}
+	}
+	return funcs
+}
+
+func unsupportedFunc(name string) func(...interface{}) (interface{}, error) {
+	return func(...interface{}) (interface{}, error) {
+		return nil, errors.Errorf("%s is not supported when rendering locally", name)
+	}
+}
+
+// configOption returns the item's value from the config values, then the
+// value and default from the Config, rendering them if they are templates
+func (r *renderer) configOption(name string) (string, error) {
+	if value, ok := r.values[name]; ok {
+		if value.Value != "" {
+			return value.Value, nil
+		}
+		if value.ValuePlaintext != "" {
+			return value.ValuePlaintext, nil
+		}
+	}
+
+	item, ok := r.items[name]
+	if !ok {
+		if _, hasValue := r.values[name]; hasValue {
+			return "", nil
+		}
+		return "", errors.Errorf("config option %q is not defined in a Config", name)
+	}
+
+	value := item.Value
+	if value == "" {
+		value = item.Default
+	}
+	if !templateStartRegex.MatchString(value) {
+		return value, nil
+	}
+
+	if r.resolving[name] {
+		return "", errors.Errorf("config option %q refers to itself", name)
+	}
+	r.resolving[name] = true
+	defer delete(r.resolving, name)
+
+	rendered, err := r.render("config option "+name, value)
+	if err != nil {
+		return "", errors.Wrapf(err, "render config option %q", name)
+	}
+	return rendered, nil
+}
+
+func (r *renderer) configOptionData(name string) (string, error) {
+	value, err := r.configOption(name)
+	if err != nil {
+		return "", err
+	}
+	return base64Decode(value)
+}
+
+func (r *renderer) configOptionFilename(name string) string {
+	return r.values[name].Filename
+}
+
+func (r *renderer) configOptionEquals(name string, expected string) (bool, error) {
+	value, err := r.configOption(name)
+	if err != nil {
+		return false, err
+	}
+	return value == expected, nil
+}
+
+func (r *renderer) configOptionNotEquals(name string, expected string) (bool, error) {
+	equals, err := r.configOptionEquals(name, expected)
+	return !equals, err
+}
+
+// licenseFieldValue returns a built-in license field, or the value of the
+// custom field with the name
+func (r *renderer) licenseFieldValue(name string) (string, error) {
+	if r.license == nil {
+		return "", errors.New("LicenseFieldValue requires a license")
+	}
+	value, ok := r.license.FieldValue(name)
+	if !ok {
+		return "", errors.Errorf("license field %q not found", name)
+	}
+	return value, nil
+}
+
+func trim(s string, cutset ...string) string {
+	if len(cutset) == 0 {
+		return strings.TrimSpace(s)
+	}
+	return strings.Trim(s, strings.Join(cutset, ""))
+}
+
+func base64Decode(s string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
+
+const defaultRandomCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+func randomString(length int, charset ...string) (string, error) {
+	chars := defaultRandomCharset
+	if len(charset) > 0 && charset[0] != "" {
+		chars = charset[0]
+	}
+
+	var sb strings.Builder
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(chars[n.Int64()])
+	}
+	return sb.String(), nil
+}
+
+func randomBytes(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// arithmetic is integer arithmetic unless either operand is a float, like in
+// KOTS
+func arithmetic(a, b interface{}, op string) (interface{}, error) {
+	af, aIsFloat, err := toNumber(a)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	bf, bIsFloat, err := toNumber(b)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if aIsFloat || bIsFloat {
+		switch op {
+		case "Add":
+			return af + bf, nil
+		case "Sub":
+			return af - bf, nil
+		case "Mult":
+			return af * bf, nil
+		default:
+			if bf == 0 {
+				return nil, errors.New("Div: division by zero")
+			}
+			return af / bf, nil
+		}
+	}
+
+	ai, bi := int64(af), int64(bf)
+	switch op {
+	case "Add":
+		return ai + bi, nil
+	case "Sub":
+		return ai - bi, nil
+	case "Mult":
+		return ai * bi, nil
+	default:
+		if bi == 0 {
+			return nil, errors.New("Div: division by zero")
+		}
+		return ai / bi, nil
+	}
+}
+
+func toNumber(v interface{}) (float64, bool, error) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), false, nil
+	case int64:
+		return float64(n), false, nil
+	case int32:
+		return float64(n), false, nil
+	case uint64:
+		return float64(n), false, nil
+	case float64:
+		return n, true, nil
+	case float32:
+		return float64(n), true, nil
+	case string:
+		if i, err := strconv.ParseInt(n, 10, 64); err == nil {
+			return float64(i), false, nil
+		}
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return 0, false, errors.Errorf("%q is not a number", n)
+		}
+		return f, true, nil
+	}
+	return 0, false, errors.Errorf("%v is not a number", v)
+}
+
+func parseInt(s string, base ...int) (int64, error) {
+	b := 10
+	if len(base) > 0 {
+		b = base[0]
+	}
+	return strconv.ParseInt(s, b, 64)
+}
+
+func parseUint(s string, base ...int) (uint64, error) {
+	b := 10
+	if len(base) > 0 {
+		b = base[0]
+	}
+	return strconv.ParseUint(s, b, 64)
+}
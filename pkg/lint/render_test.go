@@ -0,0 +1,191 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var renderConfig = File{
+	Path: "config.yaml",
+	Content: []byte(`apiVersion: kots.io/v1beta1
+kind: Config
+metadata:
+  name: config
+spec:
+  groups:
+    - name: settings
+      items:
+        - name: hostname
+          type: text
+          default: example.com
+        - name: replicas
+          type: text
+          value: "2"
+        - name: url
+          type: text
+          default: repl{{ printf "https://%s" (ConfigOption "hostname") }}
+        - name: loop
+          type: text
+          default: '{{repl ConfigOption "loop" }}'
+        - name: tls_cert
+          type: file
+`),
+}
+
+var renderConfigValues = []byte(`apiVersion: kots.io/v1beta1
+kind: ConfigValues
+spec:
+  values:
+    hostname:
+      value: app.acme.com
+    tls_cert:
+      value: Y2VydA==
+      filename: cert.pem
+`)
+
+var renderLicense = []byte(`apiVersion: kots.io/v1beta1
+kind: License
+spec:
+  licenseID: license-1
+  customerName: Acme
+  isAirgapSupported: true
+  entitlements:
+    seats:
+      title: Seats
+      value: 25
+`)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		configValues []byte
+		license      []byte
+		want         string
+		wantErr      string
+		wantLine     int64
+	}{
+		{
+			name:         "config values override defaults",
+			content:      `host: repl{{ ConfigOption "hostname" }}`,
+			configValues: renderConfigValues,
+			want:         `host: app.acme.com`,
+		},
+		{
+			name:    "default without config values",
+			content: `host: '{{repl ConfigOption "hostname" }}'`,
+			want:    `host: 'example.com'`,
+		},
+		{
+			name:    "value before default",
+			content: `replicas: repl{{ ConfigOption "replicas" | ParseInt }}`,
+			want:    `replicas: 2`,
+		},
+		{
+			name:         "templated default",
+			content:      `url: repl{{ ConfigOption "url" }}`,
+			configValues: renderConfigValues,
+			want:         `url: https://app.acme.com`,
+		},
+		{
+			name:         "file config option",
+			content:      `cert: repl{{ ConfigOptionData "tls_cert" }} name: repl{{ ConfigOptionFilename "tls_cert" }}`,
+			configValues: renderConfigValues,
+			want:         `cert: cert name: cert.pem`,
+		},
+		{
+			name:    "equals",
+			content: `repl{{ if ConfigOptionEquals "hostname" "example.com" }}default repl{{ end }}`,
+			want:    `default `,
+		},
+		{
+			name:    "license fields",
+			content: `customer: repl{{ LicenseFieldValue "customerName" }} seats: repl{{ LicenseFieldValue "seats" }} airgap: repl{{ LicenseFieldValue "isAirgapSupported" }}`,
+			license: renderLicense,
+			want:    `customer: Acme seats: 25 airgap: true`,
+		},
+		{
+			name:    "license id",
+			content: `id: repl{{ LicenseFieldValue "licenseId" }} ID: repl{{ LicenseFieldValue "licenseID" }}`,
+			license: renderLicense,
+			want:    `id: license-1 ID: license-1`,
+		},
+		{
+			name:    "static and sprig functions",
+			content: `ns: repl{{ Namespace }} n: repl{{ Add 2 3 }} up: repl{{ "x" | upper | quote }}`,
+			want:    `ns: app-ns n: 5 up: "X"`,
+		},
+		{
+			name:    "helm templates are left alone",
+			content: "image: {{ .Values.image }}\nhost: repl{{ ConfigOption \"hostname\" }}",
+			want:    "image: {{ .Values.image }}\nhost: example.com",
+		},
+		{
+			name:     "unknown config option",
+			content:  "a: 1\nb: repl{{ ConfigOption \"missing\" }}",
+			wantErr:  `config option "missing" is not defined in a Config`,
+			wantLine: 2,
+		},
+		{
+			name:     "license required",
+			content:  `seats: repl{{ LicenseFieldValue "seats" }}`,
+			wantErr:  "LicenseFieldValue requires a license",
+			wantLine: 1,
+		},
+		{
+			name:     "cluster functions fail",
+			content:  "\n\nsecret: repl{{ Lookup \"v1\" \"Secret\" \"ns\" \"name\" }}",
+			wantErr:  "Lookup is not supported when rendering locally",
+			wantLine: 3,
+		},
+		{
+			name:     "self reference",
+			content:  `loop: repl{{ ConfigOption "loop" }}`,
+			wantErr:  `config option "loop" refers to itself`,
+			wantLine: 1,
+		},
+		{
+			name:     "parse error",
+			content:  "a: 1\nb: repl{{ ConfigOption \"hostname\" ",
+			wantErr:  "unclosed action",
+			wantLine: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			files := []File{renderConfig, {Path: "manifest.yaml", Content: []byte(test.content)}}
+			rendered, err := Render(files, RenderOptions{
+				ConfigValues: test.configValues,
+				License:      test.license,
+				Namespace:    "app-ns",
+			})
+			req.NoError(err)
+			req.Len(rendered, 2)
+			manifest := rendered[1]
+			req.Equal("manifest.yaml", manifest.Path)
+
+			if test.wantErr == "" {
+				req.Empty(manifest.Errors)
+				req.Equal(test.want, string(manifest.Content))
+				return
+			}
+			req.Empty(manifest.Content)
+			req.Len(manifest.Errors, 1)
+			req.Equal("render-template", manifest.Errors[0].Rule)
+			req.Contains(manifest.Errors[0].Message, test.wantErr)
+			req.Equal(test.wantLine, manifest.Errors[0].Positions[0].Start.Line)
+		})
+	}
+}
+
+func TestRenderInvalidInputs(t *testing.T) {
+	_, err := Render(nil, RenderOptions{ConfigValues: renderLicense})
+	require.EqualError(t, err, `config values must be a ConfigValues manifest, got kind "License"`)
+
+	_, err = Render(nil, RenderOptions{License: renderConfigValues})
+	require.EqualError(t, err, `decode license: expected kind License, got "ConfigValues"`)
+}
@@ -0,0 +1,284 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/replicatedhq/replicated/pkg/types"
+)
+
+// templateRegex matches both the repl{{ }} and {{repl }} KOTS template forms
+var templateRegex = regexp.MustCompile(`(?s)repl\{\{(.*?)\}\}|\{\{repl(.*?)\}\}`)
+
+var templateStartRegex = regexp.MustCompile(`repl\{\{|\{\{repl`)
+
+// kotsTemplateFuncs are the functions KOTS makes available to templates,
+// including the sprig functions it registers
+var kotsTemplateFuncs = []string{
+	// config context
+	"ConfigOption", "ConfigOptionIndex", "ConfigOptionData", "ConfigOptionFilename",
+	"ConfigOptionEquals", "ConfigOptionNotEquals", "LocalRegistryAddress",
+	"LocalRegistryHost", "LocalRegistryNamespace", "LocalImageName",
+	"LocalRegistryImagePullSecret", "ImagePullSecretName", "HasLocalRegistry",
+	// license context
+	"LicenseFieldValue", "LicenseDockerCfg",
+	// static context
+	"Now", "NowFmt", "ToLower", "ToUpper", "TrimSpace", "Trim", "UrlEncode",
+	"Base64Encode", "Base64Decode", "Split", "RandomBytes", "RandomString", "Add",
+	"Sub", "Mult", "Div", "ParseBool", "ParseFloat", "ParseInt", "ParseUint",
+	"HumanSize", "KubeSeal", "Namespace", "TLSCert", "TLSKey", "TLSCACert",
+	"TLSCertFromCa", "TLSKeyFromCa", "IsKurl", "Distribution", "NodeCount",
+	"HTTPProxy", "HTTPSProxy", "NoProxy", "KotsVersion", "YamlEscape",
+	"PrivateCACert", "Lookup",
+	// kurl context
+	"KurlBool", "KurlString", "KurlInt", "KurlOption", "KurlAll",
+	// identity context
+	"IdentityServiceEnabled", "IdentityServiceClientID", "IdentityServiceClientSecret",
+	"IdentityServiceRoles", "IdentityServiceName", "IdentityServiceIssuerURL",
+	"IdentityServiceRestrictedGroups",
+	// sprig
+	"default", "empty", "coalesce", "ternary", "fail", "quote", "squote", "cat",
+	"indent", "nindent", "replace", "plural", "trim", "trimAll", "trimSuffix",
+	"trimPrefix", "upper", "lower", "title", "untitle", "repeat", "substr",
+	"nospace", "trunc", "abbrev", "abbrevboth", "initials", "randAlphaNum",
+	"randAlpha", "randNumeric", "randAscii", "wrap", "wrapWith", "contains",
+	"hasPrefix", "hasSuffix", "camelcase", "kebabcase", "snakecase", "swapcase",
+	"shuffle", "toString", "toStrings", "atoi", "int", "int64", "float64",
+	"toDecimal", "add", "add1", "sub", "div", "mod", "mul", "max", "min", "floor",
+	"ceil", "round", "until", "untilStep", "seq", "list", "first", "rest", "last",
+	"initial", "append", "prepend", "concat", "reverse", "uniq", "without", "has",
+	"compact", "split", "splitList", "splitn", "join", "sortAlpha", "dict", "get",
+	"set", "unset", "hasKey", "pluck", "keys", "pick", "omit", "merge",
+	"mergeOverwrite", "values", "deepCopy", "b64enc", "b64dec", "b32enc", "b32dec",
+	"sha1sum", "sha256sum", "adler32sum", "toJson", "toPrettyJson", "toRawJson",
+	"now", "date", "dateInZone", "dateModify", "date_modify", "ago", "toDate",
+	"unixEpoch", "duration", "durationRound", "htmlDate", "htmlDateInZone", "env",
+	"expandenv", "base", "dir", "clean", "ext", "isAbs", "regexMatch",
+	"regexFindAll", "regexFind", "regexReplaceAll", "regexReplaceAllLiteral",
+	"regexSplit", "semver", "semverCompare", "uuidv4", "genPrivateKey",
+	"derivePassword", "buildCustomCert", "genCA", "genSelfSignedCert",
+	"genSignedCert", "encryptAES", "decryptAES", "typeOf", "typeIs", "typeIsLike",
+	"kindOf", "kindIs", "deepEqual", "tuple", "urlParse", "urlJoin",
+}
+
+var parseOnlyFuncs = func() template.FuncMap {
+	funcs := template.FuncMap{}
+	for _, name := range kotsTemplateFuncs {
+		funcs[name] = func(...interface{}) interface{} { return nil }
+	}
+	return funcs
+}()
+
+// MaskTemplates replaces each template expression with a placeholder of the
+// same length so that yaml parse errors keep their line numbers. Block
+// keywords like repl{{ if }} and repl{{ end }} render to nothing, so they are
+// replaced with spaces.
+func MaskTemplates(content string) string {
+	return templateRegex.ReplaceAllStringFunc(content, func(match string) string {
+		placeholder := 'x'
+		if m := templateRegex.FindStringSubmatch(match); m != nil && templateBlockKeywords[actionKeyword(m[1]+m[2])] {
+			placeholder = ' '
+		}
+		return strings.Map(func(r rune) rune {
+			if r == '\n' {
+				return r
+			}
+			return placeholder
+		}, match)
+	})
+}
+
+// templateBlockKeywords are the keywords of actions that open, continue or
+// close a block
+var templateBlockKeywords = map[string]bool{
+	"if": true, "else": true, "end": true, "range": true, "with": true,
+	"define": true, "block": true,
+}
+
+// lintTemplates reports template expressions that do not parse or that call
+// a function KOTS does not provide. All of a file's expressions are parsed as
+// one template, so that blocks like repl{{ if }} ... repl{{ end }} can span
+// several expressions.
+func lintTemplates(file File) []types.LintMessage {
+	var messages []types.LintMessage
+	content := string(file.Content)
+
+	matches := templateRegex.FindAllStringSubmatchIndex(content, -1)
+	actions := make([]templateAction, 0, len(matches))
+	for _, m := range matches {
+		expr := ""
+		if m[2] >= 0 {
+			expr = content[m[2]:m[3]]
+		} else {
+			expr = content[m[4]:m[5]]
+		}
+		actions = append(actions, templateAction{
+			text:      strings.TrimSpace(content[m[0]:m[1]]),
+			expr:      expr,
+			startLine: lineAt(content, m[0]),
+			endLine:   lineAt(content, m[1]),
+			action:    "{{" + expr + "}}",
+		})
+	}
+
+	// each invalid expression is reported and replaced with one that parses,
+	// so that every invalid expression in the file is reported
+	for range actions {
+		line, msg, ok := parseTemplateActions(content, matches, actions)
+		if ok {
+			break
+		}
+
+		i := actionAtLine(actions, line)
+		if strings.HasSuffix(msg, "unexpected EOF") {
+			// the parser reached the end of the file in an unclosed block
+			i = lastOpenBlock(actions)
+		}
+		if i < 0 {
+			messages = append(messages, newMessage("invalid-template", "error", file.Path, line,
+				fmt.Sprintf("Invalid template: %s", msg)))
+			break
+		}
+		messages = append(messages, newMessage("invalid-template", "error", file.Path, actions[i].startLine,
+			fmt.Sprintf("Invalid template %q: %s", actions[i].text, msg)))
+
+		if templateBlockErrRegex.MatchString(msg) {
+			// the expressions after a misplaced block keyword can't be
+			// checked without knowing what the block was meant to be
+			break
+		}
+		actions[i].action = placeholderAction(actions[i].expr)
+	}
+
+	for _, start := range templateStartRegex.FindAllStringIndex(content, -1) {
+		if !withinMatch(start[0], matches) {
+			messages = append(messages, newMessage("invalid-template", "error", file.Path, lineAt(content, start[0]),
+				"Unterminated template, missing closing \"}}\""))
+		}
+	}
+
+	return messages
+}
+
+// templateAction is a template expression in a file
+type templateAction struct {
+	// text is the expression as it's written in the file
+	text string
+	// expr is the expression without its delimiters
+	expr      string
+	startLine int64
+	endLine   int64
+	// action is the expression as it's parsed
+	action string
+}
+
+// templateBlockErrRegex matches parse errors about block keywords that don't
+// have a block to open or close
+var templateBlockErrRegex = regexp.MustCompile(`unexpected (EOF|\{\{end\}\}|\{\{else\}\})`)
+
+var templateParseErrRegex = regexp.MustCompile(`^template: lint:(\d+):(?:\d+:)? ?`)
+
+// parseTemplateActions parses the actions as one template, in place of the
+// expressions they are for. Everything else in the file is dropped except for
+// line breaks, so the template's line numbers are the file's. It returns the
+// line and message of the parse error, if any.
+func parseTemplateActions(content string, matches [][]int, actions []templateAction) (int64, string, bool) {
+	var b strings.Builder
+	last := 0
+	for i, m := range matches {
+		b.WriteString(strings.Repeat("\n", strings.Count(content[last:m[0]], "\n")))
+		b.WriteString(actions[i].action)
+		last = m[1]
+	}
+
+	_, err := template.New("lint").Funcs(parseOnlyFuncs).Parse(b.String())
+	if err == nil {
+		return 0, "", true
+	}
+
+	line := int64(1)
+	msg := err.Error()
+	if m := templateParseErrRegex.FindStringSubmatch(msg); m != nil {
+		if n, convErr := strconv.ParseInt(m[1], 10, 64); convErr == nil {
+			line = n
+		}
+		msg = msg[len(m[0]):]
+	}
+	return line, msg, false
+}
+
+// actionAtLine returns the index of the first action on the line, or -1
+func actionAtLine(actions []templateAction, line int64) int {
+	for i, action := range actions {
+		if line >= action.startLine && line <= action.endLine {
+			return i
+		}
+	}
+	return -1
+}
+
+// lastOpenBlock returns the index of the last action that opens a block that
+// is not closed, or -1
+func lastOpenBlock(actions []templateAction) int {
+	var open []int
+	for i, action := range actions {
+		switch actionKeyword(action.expr) {
+		case "if", "range", "with", "block", "define":
+			open = append(open, i)
+		case "end":
+			if len(open) > 0 {
+				open = open[:len(open)-1]
+			}
+		}
+	}
+	if len(open) == 0 {
+		return -1
+	}
+	return open[len(open)-1]
+}
+
+// placeholderAction returns an action that parses, and opens or closes a
+// block if expr does
+func placeholderAction(expr string) string {
+	switch keyword := actionKeyword(expr); keyword {
+	case "if", "range", "with":
+		return "{{" + keyword + " 1}}"
+	case "block", "define":
+		return "{{with 1}}"
+	case "else":
+		fields := strings.Fields(strings.Trim(strings.TrimSpace(expr), "-"))
+		if len(fields) > 1 && (fields[1] == "if" || fields[1] == "with") {
+			return "{{else " + fields[1] + " 1}}"
+		}
+		return "{{else}}"
+	case "end":
+		return "{{end}}"
+	default:
+		return "{{/* */}}"
+	}
+}
+
+// actionKeyword returns the first word of the expression
+func actionKeyword(expr string) string {
+	fields := strings.Fields(strings.Trim(strings.TrimSpace(expr), "-"))
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+func withinMatch(offset int, matches [][]int) bool {
+	for _, m := range matches {
+		if offset >= m[0] && offset < m[1] {
+			return true
+		}
+	}
+	return false
+}
+
+func lineAt(content string, offset int) int64 {
+	return int64(strings.Count(content[:offset], "\n") + 1)
+}
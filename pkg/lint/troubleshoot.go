@@ -0,0 +1,233 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+const troubleshootAPIVersion = "troubleshoot.sh/v1beta2"
+
+var knownCollectors = map[string]bool{
+	"clusterInfo":      true,
+	"clusterResources": true,
+	"secret":           true,
+	"configMap":        true,
+	"logs":             true,
+	"run":              true,
+	"runPod":           true,
+	"http":             true,
+	"postgres":         true,
+	"mysql":            true,
+	"redis":            true,
+	"collectd":         true,
+	"copy":             true,
+	"copyFromHost":     true,
+	"exec":             true,
+	"data":             true,
+	"ceph":             true,
+	"longhorn":         true,
+	"registryImages":   true,
+	"sysctl":           true,
+	"certificates":     true,
+}
+
+var knownAnalyzers = map[string]bool{
+	"clusterVersion":           true,
+	"storageClass":             true,
+	"customResourceDefinition": true,
+	"ingress":                  true,
+	"secret":                   true,
+	"configMap":                true,
+	"imagePullSecret":          true,
+	"deploymentStatus":         true,
+	"statefulsetStatus":        true,
+	"jobStatus":                true,
+	"replicasetStatus":         true,
+	"clusterPodStatuses":       true,
+	"containerRuntime":         true,
+	"distribution":             true,
+	"nodeResources":            true,
+	"textAnalyze":              true,
+	"yamlCompare":              true,
+	"jsonCompare":              true,
+	"postgres":                 true,
+	"mysql":                    true,
+	"redis":                    true,
+	"cephStatus":               true,
+	"longhorn":                 true,
+	"registryImages":           true,
+	"weaveReport":              true,
+	"sysctl":                   true,
+	"certificates":             true,
+}
+
+var (
+	// an outcome condition is an optional subject, which may be a function
+	// call such as count() or min(memoryCapacity), an operator and a value
+	whenRegex       = regexp.MustCompile(`^(?:[A-Za-z_][\w.]*(?:\(\s*[\w.]*\s*\))?\s*)?(==|!=|<=|>=|=|<|>)\s*\S.*$`)
+	whenKeywords    = map[string]bool{"true": true, "false": true}
+	replicaRegex    = regexp.MustCompile(`^(==|!=|<=|>=|=|<|>)?\s*\d+$`)
+	statusAnalyzers = map[string]bool{
+		"deploymentStatus":  true,
+		"statefulsetStatus": true,
+		"jobStatus":         true,
+		"replicasetStatus":  true,
+	}
+)
+
+// lintTroubleshoot checks the collectors, analyzers and redactors in the
+// troubleshoot.sh/v1beta2 SupportBundle, Preflight and Redactor documents of
+// a file. Documents that do not parse are skipped, they are reported by
+// parseFile.
+func lintTroubleshoot(file File) []types.LintMessage {
+	var messages []types.LintMessage
+
+	masked := MaskTemplates(string(file.Content))
+	for _, chunk := range splitDocuments(masked) {
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(chunk.content), &node); err != nil || len(node.Content) == 0 {
+			continue
+		}
+		root := node.Content[0]
+		if value(root, "apiVersion") != troubleshootAPIVersion {
+			continue
+		}
+
+		at := func(n *yaml.Node) int64 {
+			return chunk.line + int64(n.Line) - 1
+		}
+		spec := child(root, "spec")
+
+		switch value(root, "kind") {
+		case "SupportBundle", "Preflight":
+			for _, item := range items(child(spec, "collectors")) {
+				for _, key := range keys(item) {
+					if !knownCollectors[key.Value] {
+						messages = append(messages, newMessage("unknown-collector", "error", file.Path, at(key),
+							fmt.Sprintf("Unknown collector %q", key.Value)))
+					}
+				}
+			}
+			for _, item := range items(child(spec, "analyzers")) {
+				for _, key := range keys(item) {
+					if !knownAnalyzers[key.Value] {
+						messages = append(messages, newMessage("unknown-analyzer", "error", file.Path, at(key),
+							fmt.Sprintf("Unknown analyzer %q", key.Value)))
+						continue
+					}
+					for _, when := range outcomeConditions(child(item, key.Value)) {
+						if err := checkWhen(key.Value, when.Value); err != nil {
+							messages = append(messages, newMessage("invalid-outcome", "error", file.Path, at(when),
+								fmt.Sprintf("Invalid %s outcome %q: %s", key.Value, when.Value, err)))
+						}
+					}
+				}
+			}
+		case "Redactor":
+			for _, redactor := range items(child(spec, "redactors")) {
+				for _, regex := range items(child(child(redactor, "removals"), "regex")) {
+					for _, field := range []string{"selector", "redactor"} {
+						n := child(regex, field)
+						if n == nil || n.Kind != yaml.ScalarNode {
+							continue
+						}
+						if _, err := regexp.Compile(n.Value); err != nil {
+							messages = append(messages, newMessage("invalid-redactor-regex", "error", file.Path, at(n),
+								fmt.Sprintf("Invalid redactor regex %q: %s", n.Value, strings.TrimPrefix(err.Error(), "error parsing regexp: "))))
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return messages
+}
+
+// outcomeConditions returns the when conditions of an analyzer's outcomes
+func outcomeConditions(analyzer *yaml.Node) []*yaml.Node {
+	var conditions []*yaml.Node
+	for _, outcome := range items(child(analyzer, "outcomes")) {
+		for _, result := range []string{"fail", "warn", "pass"} {
+			when := child(child(outcome, result), "when")
+			if when != nil && when.Kind == yaml.ScalarNode {
+				conditions = append(conditions, when)
+			}
+		}
+	}
+	return conditions
+}
+
+// checkWhen reports outcome conditions that troubleshoot cannot parse
+func checkWhen(analyzer string, when string) error {
+	when = strings.TrimSpace(when)
+	if when == "" {
+		return nil
+	}
+
+	switch {
+	case analyzer == "clusterVersion":
+		// troubleshoot parses cluster version conditions as blang/semver ranges
+		if _, err := semver.ParseRange(when); err != nil {
+			return fmt.Errorf("expected a version range such as \">= 1.16.0 < 1.22.0\"")
+		}
+		return nil
+	case statusAnalyzers[analyzer]:
+		if when == "absent" || replicaRegex.MatchString(when) {
+			return nil
+		}
+		return fmt.Errorf("expected a replica count comparison such as \"< 1\"")
+	}
+
+	if whenKeywords[when] || whenRegex.MatchString(when) {
+		return nil
+	}
+	return fmt.Errorf("expected a comparison such as \"count() < 3\"")
+}
+
+// child returns the value of key in a mapping node, or nil
+func child(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// value returns the scalar value of key in a mapping node
+func value(n *yaml.Node, key string) string {
+	c := child(n, key)
+	if c == nil || c.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return c.Value
+}
+
+// items returns the elements of a sequence node
+func items(n *yaml.Node) []*yaml.Node {
+	if n == nil || n.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return n.Content
+}
+
+// keys returns the key nodes of a mapping node
+func keys(n *yaml.Node) []*yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	var k []*yaml.Node
+	for i := 0; i < len(n.Content); i += 2 {
+		k = append(k, n.Content[i])
+	}
+	return k
+}
@@ -0,0 +1,112 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/replicatedhq/replicated/pkg/types"
+	"gopkg.in/yaml.v2"
+)
+
+var yamlErrLineRegex = regexp.MustCompile(`line (\d+):`)
+
+// a document is a single yaml document within a file
+type document struct {
+	Path       string `yaml:"-"`
+	Line       int64  `yaml:"-"`
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+}
+
+func (d document) group() string {
+	parts := strings.SplitN(d.APIVersion, "/", 2)
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[0]
+}
+
+// parseFile splits a file into its yaml documents and reports any that do not
+// parse. Template expressions are masked before parsing, since KOTS renders
+// them before the yaml is read.
+func parseFile(file File) ([]document, []types.LintMessage) {
+	var docs []document
+	var messages []types.LintMessage
+
+	masked := MaskTemplates(string(file.Content))
+	for _, chunk := range splitDocuments(masked) {
+		if strings.TrimSpace(stripComments(chunk.content)) == "" {
+			continue
+		}
+
+		// a type error means the yaml is valid but the header fields are not
+		// strings, which is not this rule's concern
+		doc := document{}
+		err := yaml.Unmarshal([]byte(chunk.content), &doc)
+		if _, isTypeErr := err.(*yaml.TypeError); err != nil && !isTypeErr {
+			// yaml counts lines from the start of the document, the message
+			// uses the file's line like the position does
+			line := chunk.line
+			msg := strings.TrimPrefix(err.Error(), "yaml: ")
+			if m := yamlErrLineRegex.FindStringSubmatchIndex(msg); m != nil {
+				if n, convErr := strconv.ParseInt(msg[m[2]:m[3]], 10, 64); convErr == nil {
+					line = chunk.line + n - 1
+					msg = fmt.Sprintf("%sline %d:%s", msg[:m[0]], line, msg[m[1]:])
+				}
+			}
+			messages = append(messages, newMessage("invalid-yaml", "error", file.Path, line, fmt.Sprintf("Invalid YAML: %s", msg)))
+			continue
+		}
+
+		doc.Path = file.Path
+		doc.Line = chunk.line
+		docs = append(docs, doc)
+	}
+
+	return docs, messages
+}
+
+type documentChunk struct {
+	line    int64
+	content string
+}
+
+// splitDocuments splits multi-document yaml on "---" separators, keeping track
+// of the line each document starts on.
+func splitDocuments(content string) []documentChunk {
+	var chunks []documentChunk
+	var current []string
+	start := int64(1)
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if strings.TrimRight(line, " \t\r") == "---" {
+			chunks = append(chunks, documentChunk{line: start, content: strings.Join(current, "\n")})
+			current = nil
+			start = int64(i + 2)
+			continue
+		}
+		current = append(current, line)
+	}
+	chunks = append(chunks, documentChunk{line: start, content: strings.Join(current, "\n")})
+
+	return chunks
+}
+
+func stripComments(content string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
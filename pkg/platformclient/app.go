@@ -1,6 +1,7 @@
 package platformclient
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -9,8 +10,12 @@ import (
 
 // ListApps returns all apps and their channels.
 func (c *HTTPClient) ListApps() ([]apps.AppAndChannels, error) {
+	return c.ListAppsContext(context.Background())
+}
+
+func (c *HTTPClient) ListAppsContext(ctx context.Context) ([]apps.AppAndChannels, error) {
 	appsAndChannels := make([]apps.AppAndChannels, 0)
-	err := c.DoJSON("GET", "/v1/apps", http.StatusOK, nil, &appsAndChannels)
+	err := c.DoJSONContext(ctx, "GET", "/v1/apps", http.StatusOK, nil, &appsAndChannels)
 	if err != nil {
 		return nil, err
 	}
@@ -20,7 +25,11 @@ func (c *HTTPClient) ListApps() ([]apps.AppAndChannels, error) {
 
 // GetApp resolves an app by either slug or ID.
 func (c *HTTPClient) GetApp(slugOrID string) (*apps.App, error) {
-	appsAndChannels, err := c.ListApps()
+	return c.GetAppContext(context.Background(), slugOrID)
+}
+
+func (c *HTTPClient) GetAppContext(ctx context.Context, slugOrID string) (*apps.App, error) {
+	appsAndChannels, err := c.ListAppsContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("GetApp: %w", err)
 	}
@@ -34,9 +43,13 @@ func (c *HTTPClient) GetApp(slugOrID string) (*apps.App, error) {
 
 // CreateApp creates a new app with the given name and returns it.
 func (c *HTTPClient) CreateApp(opts *AppOptions) (*apps.App, error) {
+	return c.CreateAppContext(context.Background(), opts)
+}
+
+func (c *HTTPClient) CreateAppContext(ctx context.Context, opts *AppOptions) (*apps.App, error) {
 	reqBody := &apps.Body{Name: opts.Name}
 	app := &apps.App{}
-	err := c.DoJSON("POST", "/v1/app", http.StatusCreated, reqBody, app)
+	err := c.DoJSONContext(ctx, "POST", "/v1/app", http.StatusCreated, reqBody, app)
 	if err != nil {
 		return nil, err
 	}
@@ -45,13 +58,17 @@ func (c *HTTPClient) CreateApp(opts *AppOptions) (*apps.App, error) {
 
 // DeleteApp deletes an app by id.
 func (c *HTTPClient) DeleteApp(id string) error {
+	return c.DeleteAppContext(context.Background(), id)
+}
+
+func (c *HTTPClient) DeleteAppContext(ctx context.Context, id string) error {
 	endpoint := fmt.Sprintf("%s/v1/app/%s", c.apiOrigin, id)
-	req, err := http.NewRequest("DELETE", endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", endpoint, nil)
 	if err != nil {
 		return err
 	}
 	req.Header.Add("Authorization", c.apiKey)
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.HTTP().Do(req)
 	if err != nil {
 		return fmt.Errorf("DeleteApp (%s %s): %w", req.Method, endpoint, err)
 	}
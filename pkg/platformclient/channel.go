@@ -1,6 +1,7 @@
 package platformclient
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"sort"
@@ -25,9 +26,13 @@ func (acs AppChannels) Less(i, j int) bool {
 
 // ListChannels returns all channels for an app.
 func (c *HTTPClient) ListChannels(appID string) ([]channels.AppChannel, error) {
+	return c.ListChannelsContext(context.Background(), appID)
+}
+
+func (c *HTTPClient) ListChannelsContext(ctx context.Context, appID string) ([]channels.AppChannel, error) {
 	path := fmt.Sprintf("/v1/app/%s/channels", appID)
 	appChannels := make([]channels.AppChannel, 0)
-	err := c.DoJSON("GET", path, http.StatusOK, nil, &appChannels)
+	err := c.DoJSONContext(ctx, "GET", path, http.StatusOK, nil, &appChannels)
 	if err != nil {
 		return nil, fmt.Errorf("ListChannels: %w", err)
 	}
@@ -38,13 +43,17 @@ func (c *HTTPClient) ListChannels(appID string) ([]channels.AppChannel, error) {
 
 // CreateChannel adds a channel to an app.
 func (c *HTTPClient) CreateChannel(appID string, name string, description string) error {
+	return c.CreateChannelContext(context.Background(), appID, name, description)
+}
+
+func (c *HTTPClient) CreateChannelContext(ctx context.Context, appID string, name string, description string) error {
 	path := fmt.Sprintf("/v1/app/%s/channel", appID)
 	body := &channels.BodyCreateChannel{
 		Name:        name,
 		Description: description,
 	}
 	appChannels := make([]channels.AppChannel, 0)
-	err := c.DoJSON("POST", path, http.StatusOK, body, &appChannels)
+	err := c.DoJSONContext(ctx, "POST", path, http.StatusOK, body, &appChannels)
 	if err != nil {
 		return fmt.Errorf("CreateChannel: %w", err)
 	}
@@ -53,13 +62,17 @@ func (c *HTTPClient) CreateChannel(appID string, name string, description string
 
 // ArchiveChannel archives a channel.
 func (c *HTTPClient) ArchiveChannel(appID, channelID string) error {
+	return c.ArchiveChannelContext(context.Background(), appID, channelID)
+}
+
+func (c *HTTPClient) ArchiveChannelContext(ctx context.Context, appID, channelID string) error {
 	endpoint := fmt.Sprintf("%s/v1/app/%s/channel/%s/archive", c.apiOrigin, appID, channelID)
-	req, err := http.NewRequest("POST", endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, nil)
 	if err != nil {
 		return err
 	}
 	req.Header.Add("Authorization", c.apiKey)
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.HTTP().Do(req)
 	if err != nil {
 		return fmt.Errorf("ArchiveChannel (%s %s): %w", req.Method, endpoint, err)
 	}
@@ -90,9 +103,13 @@ func (crs ChannelReleases) Less(i, j int) bool {
 
 // GetChannel returns channel details and release history
 func (c *HTTPClient) GetChannel(appID, channelID string) (*channels.AppChannel, []channels.ChannelRelease, error) {
+	return c.GetChannelContext(context.Background(), appID, channelID)
+}
+
+func (c *HTTPClient) GetChannelContext(ctx context.Context, appID, channelID string) (*channels.AppChannel, []channels.ChannelRelease, error) {
 	path := fmt.Sprintf("/v1/app/%s/channel/%s/releases", appID, channelID)
 	respBody := channels.GetChannelInlineResponse200{}
-	err := c.DoJSON("GET", path, http.StatusOK, nil, &respBody)
+	err := c.DoJSONContext(ctx, "GET", path, http.StatusOK, nil, &respBody)
 	if err != nil {
 		return nil, nil, fmt.Errorf("GetChannel: %w", err)
 	}
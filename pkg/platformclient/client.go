@@ -3,12 +3,15 @@ package platformclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 
 	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/transport"
 )
 
 const apiOrigin = "https://api.replicated.com/vendor"
@@ -25,8 +28,9 @@ type ChannelOptions struct {
 // An HTTPClient communicates with the Replicated Vendor HTTP API.
 // TODO: rename this to client
 type HTTPClient struct {
-	apiKey    string
-	apiOrigin string
+	apiKey     string
+	apiOrigin  string
+	httpClient *http.Client
 }
 
 // New returns a new  HTTP client.
@@ -43,23 +47,65 @@ func NewHTTPClient(origin string, apiKey string) *HTTPClient {
 	return c
 }
 
+// SetHTTPClient makes the client send requests with httpClient instead of
+// transport.DefaultClient
+func (c *HTTPClient) SetHTTPClient(httpClient *http.Client) {
+	c.httpClient = httpClient
+}
+
+// HTTP returns the client requests are sent with
+func (c *HTTPClient) HTTP() *http.Client {
+	if c.httpClient != nil {
+		return c.httpClient
+	}
+	return transport.DefaultClient
+}
+
 func (c *HTTPClient) DoJSON(method, path string, successStatus int, reqBody, respBody interface{}) error {
-	endpoint := fmt.Sprintf("%s%s", c.apiOrigin, path)
+	return c.DoJSONContext(context.Background(), method, path, successStatus, reqBody, respBody)
+}
+
+// DoJSONContext is DoJSON with a context, the request is cancelled when ctx is done
+func (c *HTTPClient) DoJSONContext(ctx context.Context, method, path string, successStatus int, reqBody, respBody interface{}) error {
 	var buf bytes.Buffer
 	if reqBody != nil {
 		if err := json.NewEncoder(&buf).Encode(reqBody); err != nil {
 			return err
 		}
 	}
-	req, err := http.NewRequest(method, endpoint, &buf)
+
+	endpoint := fmt.Sprintf("%s%s", c.apiOrigin, path)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, &buf)
+	if err != nil {
+		return err
+	}
+	return c.doJSON(req, successStatus, respBody)
+}
+
+// DoJSONStreamContext is DoJSONContext with a request body that is already
+// JSON encoded. The body is streamed rather than buffered, so it can be
+// produced while it is sent. newBody is called again for each retry of the
+// request, so it must return the same body every time.
+func (c *HTTPClient) DoJSONStreamContext(ctx context.Context, method, path string, successStatus int, newBody func() io.ReadCloser, respBody interface{}) error {
+	endpoint := fmt.Sprintf("%s%s", c.apiOrigin, path)
+	body := newBody()
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
 	if err != nil {
+		body.Close()
 		return err
 	}
+	req.GetBody = func() (io.ReadCloser, error) {
+		return newBody(), nil
+	}
+	return c.doJSON(req, successStatus, respBody)
+}
 
+func (c *HTTPClient) doJSON(req *http.Request, successStatus int, respBody interface{}) error {
+	method, endpoint := req.Method, req.URL.String()
 	req.Header.Set("Authorization", c.apiKey)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.HTTP().Do(req)
 	if err != nil {
 		return err
 	}
@@ -86,16 +132,20 @@ func (c *HTTPClient) DoJSON(method, path string, successStatus int, reqBody, res
 
 // Minimal, simplified version of DoJSON for GET requests, just returns bytes
 func (c *HTTPClient) HTTPGet(path string, successStatus int) ([]byte, error) {
+	return c.HTTPGetContext(context.Background(), path, successStatus)
+}
+
+func (c *HTTPClient) HTTPGetContext(ctx context.Context, path string, successStatus int) ([]byte, error) {
 
 	endpoint := fmt.Sprintf("%s%s", c.apiOrigin, path)
 
-	req, err := http.NewRequest("GET", endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Authorization", c.apiKey)
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.HTTP().Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package platformclient
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -9,11 +10,15 @@ import (
 
 // Vendor-API: PromoteCollector points the specified channels at a named collector.
 func (c *HTTPClient) PromoteCollector(appID string, specID string, channelIDs ...string) error {
+	return c.PromoteCollectorContext(context.Background(), appID, specID, channelIDs...)
+}
+
+func (c *HTTPClient) PromoteCollectorContext(ctx context.Context, appID string, specID string, channelIDs ...string) error {
 	path := fmt.Sprintf("/v1/app/%s/collector/%s/promote", appID, specID)
 	body := &v1.BodyPromoteCollector{
 		ChannelIDs: channelIDs,
 	}
-	if err := c.DoJSON("POST", path, http.StatusOK, body, nil); err != nil {
+	if err := c.DoJSONContext(ctx, "POST", path, http.StatusOK, body, nil); err != nil {
 		return fmt.Errorf("PromoteCollector: %w", err)
 	}
 	return nil
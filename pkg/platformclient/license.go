@@ -1,6 +1,7 @@
 package platformclient
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -9,8 +10,12 @@ import (
 
 // CreateLicense creates a new License.
 func (c *HTTPClient) CreateLicense(license *v2.LicenseV2) (*v2.LicenseV2, error) {
+	return c.CreateLicenseContext(context.Background(), license)
+}
+
+func (c *HTTPClient) CreateLicenseContext(ctx context.Context, license *v2.LicenseV2) (*v2.LicenseV2, error) {
 	created := &v2.LicenseV2{}
-	if err := c.DoJSON("POST", "/v2/license", http.StatusCreated, license, created); err != nil {
+	if err := c.DoJSONContext(ctx, "POST", "/v2/license", http.StatusCreated, license, created); err != nil {
 		return nil, fmt.Errorf("CreateLicense: %w", err)
 	}
 	return created, nil
@@ -1,6 +1,7 @@
 package platformclient
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -12,9 +13,13 @@ import (
 
 // ListReleases lists all releases for an app.
 func (c *HTTPClient) ListReleases(appID string) ([]releases.AppReleaseInfo, error) {
+	return c.ListReleasesContext(context.Background(), appID)
+}
+
+func (c *HTTPClient) ListReleasesContext(ctx context.Context, appID string) ([]releases.AppReleaseInfo, error) {
 	path := fmt.Sprintf("/v1/app/%s/releases", appID)
 	releases := make([]releases.AppReleaseInfo, 0)
-	if err := c.DoJSON("GET", path, http.StatusOK, nil, &releases); err != nil {
+	if err := c.DoJSONContext(ctx, "GET", path, http.StatusOK, nil, &releases); err != nil {
 		return nil, fmt.Errorf("ListReleases: %w", err)
 	}
 	return releases, nil
@@ -22,17 +27,21 @@ func (c *HTTPClient) ListReleases(appID string) ([]releases.AppReleaseInfo, erro
 
 // CreateRelease adds a release to an app.
 func (c *HTTPClient) CreateRelease(appID string, yaml string) (*releases.AppReleaseInfo, error) {
+	return c.CreateReleaseContext(context.Background(), appID, yaml)
+}
+
+func (c *HTTPClient) CreateReleaseContext(ctx context.Context, appID string, yaml string) (*releases.AppReleaseInfo, error) {
 	path := fmt.Sprintf("/v1/app/%s/release", appID)
 	body := &releases.BodyCreateRelease{
 		Source: "latest",
 	}
 	release := &releases.AppReleaseInfo{}
-	if err := c.DoJSON("POST", path, http.StatusCreated, body, release); err != nil {
+	if err := c.DoJSONContext(ctx, "POST", path, http.StatusCreated, body, release); err != nil {
 		return nil, fmt.Errorf("CreateRelease: %w", err)
 	}
 	// API does not accept yaml in create operation, so first create then udpate
 	if yaml != "" {
-		if err := c.UpdateRelease(appID, release.Sequence, yaml); err != nil {
+		if err := c.UpdateReleaseContext(ctx, appID, release.Sequence, yaml); err != nil {
 			return nil, fmt.Errorf("CreateRelease with YAML: %w", err)
 		}
 	}
@@ -41,14 +50,18 @@ func (c *HTTPClient) CreateRelease(appID string, yaml string) (*releases.AppRele
 
 // UpdateRelease updates a release's yaml.
 func (c *HTTPClient) UpdateRelease(appID string, sequence int64, yaml string) error {
+	return c.UpdateReleaseContext(context.Background(), appID, sequence, yaml)
+}
+
+func (c *HTTPClient) UpdateReleaseContext(ctx context.Context, appID string, sequence int64, yaml string) error {
 	endpoint := fmt.Sprintf("%s/v1/app/%s/%d/raw", c.apiOrigin, appID, sequence)
-	req, err := http.NewRequest("PUT", endpoint, strings.NewReader(yaml))
+	req, err := http.NewRequestWithContext(ctx, "PUT", endpoint, strings.NewReader(yaml))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Authorization", c.apiKey)
 	req.Header.Set("Content-Type", "application/yaml")
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.HTTP().Do(req)
 	if err != nil {
 		return fmt.Errorf("UpdateRelease: %w", err)
 	}
@@ -64,9 +77,13 @@ func (c *HTTPClient) UpdateRelease(appID string, sequence int64, yaml string) er
 
 // GetRelease returns a release's properties.
 func (c *HTTPClient) GetRelease(appID string, sequence int64) (*releases.AppRelease, error) {
+	return c.GetReleaseContext(context.Background(), appID, sequence)
+}
+
+func (c *HTTPClient) GetReleaseContext(ctx context.Context, appID string, sequence int64) (*releases.AppRelease, error) {
 	path := fmt.Sprintf("/v1/app/%s/%d/properties", appID, sequence)
 	release := &releases.AppRelease{}
-	if err := c.DoJSON("GET", path, http.StatusOK, nil, release); err != nil {
+	if err := c.DoJSONContext(ctx, "GET", path, http.StatusOK, nil, release); err != nil {
 		return nil, fmt.Errorf("GetRelease: %w", err)
 	}
 	return release, nil
@@ -74,6 +91,10 @@ func (c *HTTPClient) GetRelease(appID string, sequence int64) (*releases.AppRele
 
 // PromoteRelease points the specified channels at a release sequence.
 func (c *HTTPClient) PromoteRelease(appID string, sequence int64, label, notes string, required bool, channelIDs ...string) error {
+	return c.PromoteReleaseContext(context.Background(), appID, sequence, label, notes, required, channelIDs...)
+}
+
+func (c *HTTPClient) PromoteReleaseContext(ctx context.Context, appID string, sequence int64, label, notes string, required bool, channelIDs ...string) error {
 	path := fmt.Sprintf("/v1/app/%s/%d/promote?dry_run=true", appID, sequence)
 	body := &releases.BodyPromoteRelease{
 		Label:        label,
@@ -81,12 +102,16 @@ func (c *HTTPClient) PromoteRelease(appID string, sequence int64, label, notes s
 		Required:     required,
 		Channels:     channelIDs,
 	}
-	if err := c.DoJSON("POST", path, http.StatusNoContent, body, nil); err != nil {
+	if err := c.DoJSONContext(ctx, "POST", path, http.StatusNoContent, body, nil); err != nil {
 		return fmt.Errorf("PromoteRelease: %w", err)
 	}
 	return nil
 }
 
 func (c *HTTPClient) LintRelease(appID string, yaml string) ([]types.LintMessage, error) {
+	return c.LintReleaseContext(context.Background(), appID, yaml)
+}
+
+func (c *HTTPClient) LintReleaseContext(ctx context.Context, appID string, yaml string) ([]types.LintMessage, error) {
 	return nil, errors.New("Not implemnented")
 }
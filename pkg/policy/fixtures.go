@@ -0,0 +1,150 @@
+package policy
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/lint"
+	"github.com/replicatedhq/replicated/pkg/releasefiles"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"gopkg.in/yaml.v2"
+)
+
+// ExpectedFile is the name of the file in a fixture that lists the messages
+// the policy should report for the fixture's manifests
+const ExpectedFile = "expected.yaml"
+
+// ManifestsDir is the name of the directory in a fixture that holds the
+// manifests the policy is evaluated against
+const ManifestsDir = "manifests"
+
+// An Expectation is a message the policy should report. Line is only compared
+// when it is set.
+type Expectation struct {
+	Type    string `json:"type" yaml:"type"`
+	Path    string `json:"path" yaml:"path"`
+	Message string `json:"message" yaml:"message"`
+	Line    int64  `json:"line,omitempty" yaml:"line,omitempty"`
+}
+
+// A FixtureResult is the outcome of evaluating a policy against one fixture
+type FixtureResult struct {
+	Name       string        `json:"name"`
+	Passed     bool          `json:"passed"`
+	Missing    []Expectation `json:"missing,omitempty"`
+	Unexpected []Expectation `json:"unexpected,omitempty"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// RunFixtures evaluates the policy against every fixture in dir. Each fixture
+// is a directory with the manifests in a manifests directory and the expected
+// messages in expected.yaml, for example:
+//
+//	fixtures/
+//	  single-replica/
+//	    expected.yaml
+//	    manifests/
+//	      deployment.yaml
+//
+// An expected.yaml lists messages by type, path and message, and optionally
+// line. A fixture without an expected.yaml expects no messages.
+func (p *Policy) RunFixtures(ctx context.Context, dir string) ([]FixtureResult, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read fixtures dir %s", dir)
+	}
+
+	var results []FixtureResult
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		result := p.runFixture(ctx, filepath.Join(dir, entry.Name()))
+		result.Name = entry.Name()
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		return nil, errors.Errorf("no fixtures found in %s", dir)
+	}
+	return results, nil
+}
+
+func (p *Policy) runFixture(ctx context.Context, dir string) FixtureResult {
+	var expected []Expectation
+	b, err := ioutil.ReadFile(filepath.Join(dir, ExpectedFile))
+	if err != nil && !os.IsNotExist(err) {
+		return FixtureResult{Error: err.Error()}
+	} else if err == nil {
+		if err := yaml.UnmarshalStrict(b, &expected); err != nil {
+			return FixtureResult{Error: errors.Wrapf(err, "parse %s", ExpectedFile).Error()}
+		}
+	}
+
+	files, err := lint.ReadDir(filepath.Join(dir, ManifestsDir), releasefiles.Options{})
+	if err != nil {
+		return FixtureResult{Error: err.Error()}
+	}
+
+	messages, err := p.Eval(ctx, files)
+	if err != nil {
+		return FixtureResult{Error: err.Error()}
+	}
+
+	missing, unexpected := compare(expected, messages)
+	return FixtureResult{
+		Passed:     len(missing) == 0 && len(unexpected) == 0,
+		Missing:    missing,
+		Unexpected: unexpected,
+	}
+}
+
+// compare matches each expectation with at most one message, and returns the
+// expectations and messages left over
+func compare(expected []Expectation, messages []types.LintMessage) ([]Expectation, []Expectation) {
+	actual := make([]Expectation, 0, len(messages))
+	for _, msg := range messages {
+		actual = append(actual, Expectation{
+			Type:    msg.Type,
+			Path:    msg.Path,
+			Message: msg.Message,
+			Line:    firstLine(msg),
+		})
+	}
+
+	matched := make([]bool, len(actual))
+	var missing []Expectation
+	for _, want := range expected {
+		found := false
+		for i, got := range actual {
+			if matched[i] || got.Type != want.Type || got.Path != want.Path || got.Message != want.Message {
+				continue
+			}
+			if want.Line != 0 && got.Line != want.Line {
+				continue
+			}
+			matched[i] = true
+			found = true
+			break
+		}
+		if !found {
+			missing = append(missing, want)
+		}
+	}
+
+	var unexpected []Expectation
+	for i, got := range actual {
+		if !matched[i] {
+			unexpected = append(unexpected, got)
+		}
+	}
+
+	sort.SliceStable(missing, func(i, j int) bool {
+		return missing[i].Path < missing[j].Path
+	})
+	return missing, unexpected
+}
@@ -0,0 +1,170 @@
+package policy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/lint"
+	"gopkg.in/yaml.v3"
+)
+
+// a document is a single yaml document within a file, kept as a node tree so
+// that a field can be traced back to its line
+type document struct {
+	node *yaml.Node
+}
+
+// line returns the line of the dotted field in the document, or of the
+// deepest part of it that exists
+func (d document) line(field string) int64 {
+	node := d.node
+	line := int64(node.Line)
+	if field == "" {
+		return line
+	}
+
+	for _, part := range strings.Split(field, ".") {
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == part {
+					line = int64(node.Content[i].Line)
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if n, err := strconv.Atoi(part); err == nil && n >= 0 && n < len(node.Content) {
+				next = node.Content[n]
+				line = int64(next.Line)
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+
+	return line
+}
+
+// buildInput parses files into the input of a policy, and returns the parsed
+// documents of each file by path
+func buildInput(files []lint.File) (map[string]interface{}, map[string][]document, error) {
+	fileInputs := []interface{}{}
+	specInputs := []interface{}{}
+	docs := map[string][]document{}
+
+	for _, file := range files {
+		// template expressions are masked, since KOTS renders them before
+		// the yaml is read
+		masked := lint.MaskTemplates(string(file.Content))
+		decoder := yaml.NewDecoder(bytes.NewReader([]byte(masked)))
+		for docIndex := 0; ; docIndex++ {
+			var node yaml.Node
+			err := decoder.Decode(&node)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, nil, errors.Wrapf(err, "parse %s", file.Path)
+			}
+
+			root := &node
+			if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+				root = root.Content[0]
+			}
+			docs[file.Path] = append(docs[file.Path], document{node: root})
+
+			var content interface{}
+			if err := root.Decode(&content); err != nil {
+				return nil, nil, errors.Wrapf(err, "decode %s", file.Path)
+			}
+			content = normalize(content)
+
+			fileInputs = append(fileInputs, map[string]interface{}{
+				"name":     path.Base(file.Path),
+				"path":     file.Path,
+				"docIndex": docIndex,
+				"content":  content,
+			})
+			specInputs = append(specInputs, findSpecs(file.Path, docIndex, "", content)...)
+		}
+	}
+
+	input := map[string]interface{}{
+		"files": fileInputs,
+		"specs": specInputs,
+	}
+	return input, docs, nil
+}
+
+// findSpecs returns an entry for every spec field under value, including
+// value's own
+func findSpecs(filePath string, docIndex int, prefix string, value interface{}) []interface{} {
+	var specs []interface{}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if spec, ok := v["spec"].(map[string]interface{}); ok {
+			specs = append(specs, map[string]interface{}{
+				"path":     filePath,
+				"docIndex": docIndex,
+				"field":    joinField(prefix, "spec"),
+				"spec":     spec,
+			})
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			specs = append(specs, findSpecs(filePath, docIndex, joinField(prefix, key), v[key])...)
+		}
+	case []interface{}:
+		for i, child := range v {
+			specs = append(specs, findSpecs(filePath, docIndex, joinField(prefix, strconv.Itoa(i)), child)...)
+		}
+	}
+
+	return specs
+}
+
+func joinField(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// normalize converts maps with non-string keys, which yaml allows and json
+// does not, into maps with string keys
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = normalize(child)
+		}
+		return v
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			m[fmt.Sprint(key)] = normalize(child)
+		}
+		return m
+	case []interface{}:
+		for i, child := range v {
+			v[i] = normalize(child)
+		}
+		return v
+	default:
+		return v
+	}
+}
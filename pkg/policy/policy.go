@@ -0,0 +1,197 @@
+// Package policy evaluates enterprise OPA policies locally, against the same
+// input the Replicated API builds from a release, so that a policy can be
+// tested before it is uploaded.
+//
+// A policy is a set of Rego rules without a package declaration. It reports
+// problems by adding objects to the lint set:
+//
+//	lint[output] {
+//	  spec := specs[_]
+//	  spec.spec.replicas == 1
+//	  output := {
+//	    "type": "error",
+//	    "message": "replicas cannot be 1",
+//	    "path": spec.path,
+//	    "field": concat(".", [spec.field, "replicas"]),
+//	    "docIndex": spec.docIndex
+//	  }
+//	}
+//
+// The rules can refer to files, with one entry per yaml document, and specs,
+// with one entry per spec field in a document, nested ones included. A specs
+// entry holds the value of the spec field in spec and its dotted path in field.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/lint"
+	"github.com/replicatedhq/replicated/pkg/types"
+)
+
+const packagePath = "replicated.policy"
+
+// preamble defines the documents a policy can refer to in terms of the input
+const preamble = `package ` + packagePath + `
+
+files = input.files
+
+specs = input.specs
+`
+
+// A Policy is a parsed and compiled policy, ready to be evaluated
+type Policy struct {
+	name  string
+	query rego.PreparedEvalQuery
+}
+
+// Load parses and compiles the policy in source. The name is used as the
+// filename in error messages and as the rule of messages that do not set one.
+func Load(ctx context.Context, name string, source []byte) (*Policy, error) {
+	// the policy is parsed without a package declaration so that the
+	// positions in parse and compile errors match the file
+	stmts, _, err := ast.ParseStatements(name, string(source))
+	if err != nil {
+		return nil, err
+	}
+
+	module := &ast.Module{
+		Package: ast.MustParsePackage("package " + packagePath),
+	}
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *ast.Rule:
+			s.Module = module
+			module.Rules = append(module.Rules, s)
+		case *ast.Import:
+			module.Imports = append(module.Imports, s)
+		case *ast.Package:
+			return nil, errors.Errorf("%s: policies must not declare a package", s.Location)
+		case ast.Body:
+			return nil, errors.Errorf("%s: expected a rule, found an expression", s.Loc())
+		}
+	}
+
+	query, err := rego.New(
+		rego.Query("data."+packagePath+".lint"),
+		rego.Module("preamble.rego", preamble),
+		rego.ParsedModule(module),
+	).PrepareForEval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Policy{
+		name:  strings.TrimSuffix(filepath.Base(name), filepath.Ext(name)),
+		query: query,
+	}, nil
+}
+
+// Eval evaluates the policy against files and returns the lint results,
+// sorted by path and line
+func (p *Policy) Eval(ctx context.Context, files []lint.File) ([]types.LintMessage, error) {
+	input, docs, err := buildInput(files)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := p.query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return nil, errors.Wrap(err, "evaluate policy")
+	}
+
+	var messages []types.LintMessage
+	for _, result := range results {
+		for _, expr := range result.Expressions {
+			outputs, ok := expr.Value.([]interface{})
+			if !ok {
+				return nil, errors.Errorf("lint must be a set, got %T", expr.Value)
+			}
+			for _, output := range outputs {
+				msg, err := p.toMessage(output, docs)
+				if err != nil {
+					return nil, err
+				}
+				messages = append(messages, msg)
+			}
+		}
+	}
+
+	sort.SliceStable(messages, func(i, j int) bool {
+		if messages[i].Path != messages[j].Path {
+			return messages[i].Path < messages[j].Path
+		}
+		return firstLine(messages[i]) < firstLine(messages[j])
+	})
+	return messages, nil
+}
+
+// toMessage converts one lint[output] object into a lint message, using the
+// field and docIndex to find the line it refers to
+func (p *Policy) toMessage(output interface{}, docs map[string][]document) (types.LintMessage, error) {
+	obj, ok := output.(map[string]interface{})
+	if !ok {
+		return types.LintMessage{}, errors.Errorf("lint output must be an object, got %T", output)
+	}
+
+	msg := types.LintMessage{
+		Rule:    stringField(obj, "rule"),
+		Type:    stringField(obj, "type"),
+		Path:    stringField(obj, "path"),
+		Message: stringField(obj, "message"),
+	}
+	if msg.Rule == "" {
+		msg.Rule = p.name
+	}
+	if msg.Type == "" {
+		msg.Type = "error"
+	}
+
+	docIndex := 0
+	if v, ok := obj["docIndex"]; ok {
+		n, err := strconv.Atoi(fmt.Sprint(v))
+		if err != nil {
+			return types.LintMessage{}, errors.Errorf("docIndex must be a number, got %v", v)
+		}
+		docIndex = n
+	}
+
+	fileDocs := docs[msg.Path]
+	if docIndex >= 0 && docIndex < len(fileDocs) {
+		line := fileDocs[docIndex].line(stringField(obj, "field"))
+		msg.Positions = []*types.LintPosition{
+			{
+				Path:  msg.Path,
+				Start: types.LintLinePosition{Line: line},
+			},
+		}
+	}
+
+	return msg, nil
+}
+
+func stringField(obj map[string]interface{}, key string) string {
+	v, ok := obj[key]
+	if !ok || v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+func firstLine(msg types.LintMessage) int64 {
+	if len(msg.Positions) == 0 {
+		return 0
+	}
+	return msg.Positions[0].Start.Line
+}
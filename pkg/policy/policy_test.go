@@ -0,0 +1,215 @@
+package policy
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/replicatedhq/replicated/pkg/lint"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+const replicasPolicy = `lint[output] {
+  spec := specs[_]
+  spec.spec.replicas == 1
+  field := concat(".", [spec.field, "replicas"])
+  output := {
+    "type": "error",
+    "message": "Cannot accept hard coded replica count when it's set to 1",
+    "path": spec.path,
+    "field": field,
+    "docIndex": spec.docIndex
+  }
+}
+`
+
+const deployments = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: one
+spec:
+  replicas: 1
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: templated
+spec:
+  replicas: repl{{ ConfigOption "replicas" }}
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: three
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers: []
+`
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		files  []lint.File
+		want   []types.LintMessage
+	}{
+		{
+			name:   "replicas",
+			policy: replicasPolicy,
+			files:  []lint.File{{Path: "app/deployments.yaml", Content: []byte(deployments)}},
+			want: []types.LintMessage{
+				{
+					Rule:    "replicas",
+					Type:    "error",
+					Path:    "app/deployments.yaml",
+					Message: "Cannot accept hard coded replica count when it's set to 1",
+					Positions: []*types.LintPosition{
+						{Path: "app/deployments.yaml", Start: types.LintLinePosition{Line: 6}},
+					},
+				},
+			},
+		},
+		{
+			name: "files with rule and no field",
+			policy: `lint[output] {
+  file := files[_]
+  file.content.kind == "Deployment"
+  not file.content.metadata.namespace
+  output := {
+    "rule": "namespace",
+    "type": "warn",
+    "message": concat(" ", [file.content.metadata.name, "has no namespace"]),
+    "path": file.path,
+    "docIndex": file.docIndex
+  }
+}`,
+			files: []lint.File{{Path: "deployments.yaml", Content: []byte(deployments)}},
+			want: []types.LintMessage{
+				{
+					Rule:      "namespace",
+					Type:      "warn",
+					Path:      "deployments.yaml",
+					Message:   "one has no namespace",
+					Positions: []*types.LintPosition{{Path: "deployments.yaml", Start: types.LintLinePosition{Line: 1}}},
+				},
+				{
+					Rule:      "namespace",
+					Type:      "warn",
+					Path:      "deployments.yaml",
+					Message:   "templated has no namespace",
+					Positions: []*types.LintPosition{{Path: "deployments.yaml", Start: types.LintLinePosition{Line: 8}}},
+				},
+				{
+					Rule:      "namespace",
+					Type:      "warn",
+					Path:      "deployments.yaml",
+					Message:   "three has no namespace",
+					Positions: []*types.LintPosition{{Path: "deployments.yaml", Start: types.LintLinePosition{Line: 15}}},
+				},
+			},
+		},
+		{
+			name:   "nothing found",
+			policy: replicasPolicy,
+			files:  []lint.File{{Path: "empty.yaml", Content: []byte("apiVersion: v1\nkind: ConfigMap\n")}},
+			want:   nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			p, err := Load(context.Background(), "replicas.rego", []byte(test.policy))
+			req.NoError(err)
+
+			got, err := p.Eval(context.Background(), test.files)
+			req.NoError(err)
+			req.Equal(test.want, got)
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr string
+	}{
+		{
+			name:    "syntax error",
+			policy:  "lint[output] {\n  output := \n}\n",
+			wantErr: "replicas.rego:3",
+		},
+		{
+			name:    "package",
+			policy:  "package mine\n\nlint[x] { x := 1 }\n",
+			wantErr: "policies must not declare a package",
+		},
+		{
+			name:    "unknown reference",
+			policy:  "lint[output] {\n  output := nope[_]\n}\n",
+			wantErr: "nope",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Load(context.Background(), "replicas.rego", []byte(test.policy))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), test.wantErr)
+		})
+	}
+}
+
+func TestRunFixtures(t *testing.T) {
+	req := require.New(t)
+
+	dir, err := ioutil.TempDir("", "policy-fixtures")
+	req.NoError(err)
+	defer os.RemoveAll(dir)
+
+	writeFile := func(path string, content string) {
+		path = filepath.Join(dir, path)
+		req.NoError(os.MkdirAll(filepath.Dir(path), 0755))
+		req.NoError(ioutil.WriteFile(path, []byte(content), 0644))
+	}
+	writeFile("passing/manifests/deployments.yaml", deployments)
+	writeFile("passing/expected.yaml", `- type: error
+  path: deployments.yaml
+  message: Cannot accept hard coded replica count when it's set to 1
+  line: 6
+`)
+	writeFile("failing/manifests/deployments.yaml", deployments)
+	writeFile("failing/expected.yaml", `- type: error
+  path: deployments.yaml
+  message: Cannot accept hard coded replica count when it's set to 1
+  line: 7
+`)
+	writeFile("clean/manifests/configmap.yaml", "apiVersion: v1\nkind: ConfigMap\n")
+
+	p, err := Load(context.Background(), "replicas.rego", []byte(replicasPolicy))
+	req.NoError(err)
+
+	results, err := p.RunFixtures(context.Background(), dir)
+	req.NoError(err)
+
+	unexpected := Expectation{
+		Type:    "error",
+		Path:    "deployments.yaml",
+		Message: "Cannot accept hard coded replica count when it's set to 1",
+		Line:    6,
+	}
+	missing := unexpected
+	missing.Line = 7
+	req.Equal([]FixtureResult{
+		{Name: "clean", Passed: true},
+		{Name: "failing", Missing: []Expectation{missing}, Unexpected: []Expectation{unexpected}},
+		{Name: "passing", Passed: true},
+	}, results)
+}
@@ -0,0 +1,139 @@
+// Package profiles reads and writes the named profiles in
+// ~/.replicated/config.yaml. A profile holds the API token, app and API
+// origins to use when they are not set with a flag or environment variable.
+package profiles
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+type Profile struct {
+	APIToken         string `yaml:"apiToken,omitempty"`
+	App              string `yaml:"app,omitempty"`
+	APIOrigin        string `yaml:"apiOrigin,omitempty"`
+	GraphQLOrigin    string `yaml:"graphqlOrigin,omitempty"`
+	EnterpriseOrigin string `yaml:"enterpriseOrigin,omitempty"`
+	KurlOrigin       string `yaml:"kurlOrigin,omitempty"`
+}
+
+type Config struct {
+	CurrentProfile string              `yaml:"currentProfile,omitempty"`
+	Profiles       map[string]*Profile `yaml:"profiles,omitempty"`
+}
+
+// DefaultPath is ~/.replicated/config.yaml, or the value of
+// REPLICATED_CONFIG when it is set
+func DefaultPath(homeDir string) string {
+	if path := os.Getenv("REPLICATED_CONFIG"); path != "" {
+		return path
+	}
+	return filepath.Join(homeDir, ".replicated", "config.yaml")
+}
+
+// Load reads the config at path. A missing file is an empty config.
+func Load(path string) (*Config, error) {
+	config := Config{}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &config, nil
+		}
+		return nil, errors.Wrapf(err, "read %s", path)
+	}
+
+	if err := yaml.UnmarshalStrict(b, &config); err != nil {
+		return nil, errors.Wrapf(err, "parse %s", path)
+	}
+	return &config, nil
+}
+
+// Save writes the config to path. The file holds API tokens so it is only
+// readable by the current user.
+func (c *Config) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Wrapf(err, "create dir for %s", path)
+	}
+
+	b, err := yaml.Marshal(c)
+	if err != nil {
+		return errors.Wrap(err, "marshal config")
+	}
+
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		return errors.Wrapf(err, "write %s", path)
+	}
+	// WriteFile keeps the mode of an existing file
+	return os.Chmod(path, 0600)
+}
+
+// Names returns the profile names in alphabetical order
+func (c *Config) Names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the named profile, or the current profile when name is empty.
+// It returns nil with no error when name is empty and there is no current profile.
+func (c *Config) Get(name string) (*Profile, error) {
+	if name == "" {
+		name = c.CurrentProfile
+		if name == "" {
+			return nil, nil
+		}
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return nil, errors.Errorf("profile %q not found", name)
+	}
+	return profile, nil
+}
+
+// Set adds or replaces the named profile. The first profile added becomes
+// the current profile.
+func (c *Config) Set(name string, profile Profile) error {
+	if name == "" {
+		return errors.New("profile name is required")
+	}
+	if c.Profiles == nil {
+		c.Profiles = map[string]*Profile{}
+	}
+	c.Profiles[name] = &profile
+	if c.CurrentProfile == "" {
+		c.CurrentProfile = name
+	}
+	return nil
+}
+
+// Use makes the named profile the current profile
+func (c *Config) Use(name string) error {
+	if _, ok := c.Profiles[name]; !ok {
+		return errors.Errorf("profile %q not found", name)
+	}
+	c.CurrentProfile = name
+	return nil
+}
+
+// Remove deletes the named profile. Removing the current profile leaves no
+// profile current.
+func (c *Config) Remove(name string) error {
+	if _, ok := c.Profiles[name]; !ok {
+		return errors.Errorf("profile %q not found", name)
+	}
+	delete(c.Profiles, name)
+	if c.CurrentProfile == name {
+		c.CurrentProfile = ""
+	}
+	return nil
+}
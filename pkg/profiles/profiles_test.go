@@ -0,0 +1,70 @@
+package profiles
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig(t *testing.T) {
+	req := require.New(t)
+
+	dir, err := ioutil.TempDir("", "replicated-profiles")
+	req.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, ".replicated", "config.yaml")
+
+	config, err := Load(path)
+	req.NoError(err)
+	profile, err := config.Get("")
+	req.NoError(err)
+	req.Nil(profile)
+
+	req.NoError(config.Set("staging", Profile{APIToken: "staging-token", App: "my-app", APIOrigin: "https://api.staging.replicated.com/vendor"}))
+	req.NoError(config.Set("prod", Profile{APIToken: "prod-token"}))
+	req.Equal("staging", config.CurrentProfile)
+	req.NoError(config.Save(path))
+
+	info, err := os.Stat(path)
+	req.NoError(err)
+	req.Equal(os.FileMode(0600), info.Mode().Perm())
+
+	config, err = Load(path)
+	req.NoError(err)
+	req.Equal([]string{"prod", "staging"}, config.Names())
+
+	profile, err = config.Get("")
+	req.NoError(err)
+	req.Equal("staging-token", profile.APIToken)
+	req.Equal("my-app", profile.App)
+
+	req.NoError(config.Use("prod"))
+	profile, err = config.Get("")
+	req.NoError(err)
+	req.Equal("prod-token", profile.APIToken)
+
+	_, err = config.Get("dev")
+	req.EqualError(err, `profile "dev" not found`)
+	req.EqualError(config.Use("dev"), `profile "dev" not found`)
+
+	req.NoError(config.Remove("prod"))
+	req.Equal("", config.CurrentProfile)
+	req.Equal([]string{"staging"}, config.Names())
+}
+
+func TestLoadInvalid(t *testing.T) {
+	req := require.New(t)
+
+	dir, err := ioutil.TempDir("", "replicated-profiles")
+	req.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.yaml")
+
+	req.NoError(ioutil.WriteFile(path, []byte("profiles:\n  dev:\n    token: abc\n"), 0600))
+	_, err = Load(path)
+	req.Error(err)
+	req.Contains(err.Error(), "field token not found")
+}
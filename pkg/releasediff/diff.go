@@ -0,0 +1,161 @@
+// Package releasediff compares the files of two releases and renders the
+// differences as unified diffs.
+package releasediff
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+type FileStatus string
+
+const (
+	StatusAdded   FileStatus = "added"
+	StatusRemoved FileStatus = "removed"
+	StatusChanged FileStatus = "changed"
+)
+
+// A File is a single file of a release. Chart archives hold their base64
+// encoded content, as they do in the release spec.
+type File struct {
+	Path    string
+	Content string
+}
+
+// IsChart reports whether the file is a packaged helm chart
+func (f File) IsChart() bool {
+	switch filepath.Ext(f.Path) {
+	case ".tgz", ".gz":
+		return true
+	}
+	return false
+}
+
+// A FileDiff is a file that differs between the two releases. Diff is empty
+// for charts, which are compared by content only.
+type FileDiff struct {
+	Path   string     `json:"path"`
+	Status FileStatus `json:"status"`
+	Chart  bool       `json:"chart"`
+	Diff   string     `json:"diff,omitempty"`
+}
+
+type Result struct {
+	Files []FileDiff `json:"files"`
+}
+
+// Compare diffs the files in from against the files in to. fromLabel and
+// toLabel prefix the file names in the unified diff headers.
+func Compare(fromLabel string, from []File, toLabel string, to []File) (*Result, error) {
+	fromFiles := map[string]File{}
+	for _, file := range from {
+		fromFiles[file.Path] = file
+	}
+	toFiles := map[string]File{}
+	for _, file := range to {
+		toFiles[file.Path] = file
+	}
+
+	paths := []string{}
+	for path := range fromFiles {
+		paths = append(paths, path)
+	}
+	for path := range toFiles {
+		if _, ok := fromFiles[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	result := Result{}
+	for _, path := range paths {
+		fromFile, inFrom := fromFiles[path]
+		toFile, inTo := toFiles[path]
+
+		fileDiff := FileDiff{Path: path}
+		switch {
+		case !inFrom:
+			fileDiff.Status = StatusAdded
+			fileDiff.Chart = toFile.IsChart()
+		case !inTo:
+			fileDiff.Status = StatusRemoved
+			fileDiff.Chart = fromFile.IsChart()
+		case fromFile.Content != toFile.Content:
+			fileDiff.Status = StatusChanged
+			fileDiff.Chart = toFile.IsChart()
+		default:
+			continue
+		}
+
+		if !fileDiff.Chart {
+			unified := difflib.UnifiedDiff{
+				A:        splitLines(fromFile.Content),
+				B:        splitLines(toFile.Content),
+				FromFile: fromLabel + "/" + path,
+				ToFile:   toLabel + "/" + path,
+				Context:  3,
+			}
+			if !inFrom {
+				unified.A = nil
+				unified.FromFile = "/dev/null"
+			}
+			if !inTo {
+				unified.B = nil
+				unified.ToFile = "/dev/null"
+			}
+			text, err := difflib.GetUnifiedDiffString(unified)
+			if err != nil {
+				return nil, errors.Wrapf(err, "diff %s", path)
+			}
+			if text != "" && !strings.HasSuffix(text, "\n") {
+				text += "\n"
+			}
+			fileDiff.Diff = text
+		}
+
+		result.Files = append(result.Files, fileDiff)
+	}
+
+	return &result, nil
+}
+
+func (r Result) IsEmpty() bool {
+	return len(r.Files) == 0
+}
+
+// Summary counts the added, removed and changed files, with charts counted
+// separately from manifests
+func (r Result) Summary() string {
+	files := map[FileStatus]int{}
+	charts := map[FileStatus]int{}
+	for _, file := range r.Files {
+		if file.Chart {
+			charts[file.Status]++
+		} else {
+			files[file.Status]++
+		}
+	}
+
+	return fmt.Sprintf("Files: %d added, %d removed, %d changed. Charts: %d added, %d removed, %d changed.",
+		files[StatusAdded], files[StatusRemoved], files[StatusChanged],
+		charts[StatusAdded], charts[StatusRemoved], charts[StatusChanged])
+}
+
+// splitLines splits content into newline terminated lines, without the empty
+// trailing line difflib.SplitLines adds for content ending in a newline
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
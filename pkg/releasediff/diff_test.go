@@ -0,0 +1,67 @@
+package releasediff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     []File
+		to       []File
+		want     []FileDiff
+		wantDiff map[string]string
+		summary  string
+	}{
+		{
+			name:    "identical",
+			from:    []File{{Path: "a.yaml", Content: "kind: A\n"}},
+			to:      []File{{Path: "a.yaml", Content: "kind: A\n"}},
+			want:    nil,
+			summary: "Files: 0 added, 0 removed, 0 changed. Charts: 0 added, 0 removed, 0 changed.",
+		},
+		{
+			name: "added removed and changed",
+			from: []File{
+				{Path: "a.yaml", Content: "kind: A\nname: one\n"},
+				{Path: "b.yaml", Content: "kind: B\n"},
+				{Path: "chart-0.1.0.tgz", Content: "H4sIAAAA"},
+			},
+			to: []File{
+				{Path: "a.yaml", Content: "kind: A\nname: two\n"},
+				{Path: "c.yaml", Content: "kind: C\n"},
+				{Path: "chart-0.1.0.tgz", Content: "H4sIBBBB"},
+			},
+			want: []FileDiff{
+				{Path: "a.yaml", Status: StatusChanged},
+				{Path: "b.yaml", Status: StatusRemoved},
+				{Path: "c.yaml", Status: StatusAdded},
+				{Path: "chart-0.1.0.tgz", Status: StatusChanged, Chart: true},
+			},
+			wantDiff: map[string]string{
+				"a.yaml": "--- 1/a.yaml\n+++ 2/a.yaml\n@@ -1,2 +1,2 @@\n kind: A\n-name: one\n+name: two\n",
+				"b.yaml": "--- 1/b.yaml\n+++ /dev/null\n@@ -1 +0,0 @@\n-kind: B\n",
+				"c.yaml": "--- /dev/null\n+++ 2/c.yaml\n@@ -0,0 +1 @@\n+kind: C\n",
+			},
+			summary: "Files: 1 added, 1 removed, 1 changed. Charts: 0 added, 0 removed, 1 changed.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			result, err := Compare("1", tt.from, "2", tt.to)
+			req.NoError(err)
+
+			var got []FileDiff
+			for _, file := range result.Files {
+				req.Equal(tt.wantDiff[file.Path], file.Diff, file.Path)
+				file.Diff = ""
+				got = append(got, file)
+			}
+			req.Equal(tt.want, got)
+			req.Equal(tt.summary, result.Summary())
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Package releasefiles decides which files under a yaml dir are part of a
+// KOTS release.
+package releasefiles
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/pkg/errors"
+)
+
+// IgnoreFile holds gitignore-style patterns for files to leave out of a
+// release. It is read from the yaml dir and any directory below it, with
+// patterns relative to the directory the file is in.
+const IgnoreFile = ".replicatedignore"
+
+// Options narrow the files of a release further than the ignore files do.
+// Both take gitignore-style patterns relative to the yaml dir.
+type Options struct {
+	// Include, when set, keeps only the files matching one of the patterns
+	Include []string
+	// Exclude leaves out the files matching any of the patterns
+	Exclude []string
+}
+
+// A Selector picks the release files under a yaml dir. Dotfiles and files
+// other than .yaml, .yml, .tgz and .gz are never part of a release.
+type Selector struct {
+	root    string
+	ignore  gitignore.Matcher
+	include []gitignore.Pattern
+}
+
+// NewSelector reads the ignore files under root
+func NewSelector(root string, opts Options) (*Selector, error) {
+	type ignoreFile struct {
+		depth    int
+		patterns []gitignore.Pattern
+	}
+	var ignoreFiles []ignoreFile
+	err := filepath.Walk(root, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			// files removed while the tree is walked are skipped
+			if os.IsNotExist(err) && filePath != root {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() || info.Name() != IgnoreFile {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, filepath.Dir(filePath))
+		if err != nil {
+			return err
+		}
+		var domain []string
+		if rel != "." {
+			domain = strings.Split(filepath.ToSlash(rel), "/")
+		}
+
+		filePatterns, err := readIgnoreFile(filePath, domain)
+		if os.IsNotExist(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		ignoreFiles = append(ignoreFiles, ignoreFile{depth: len(domain), patterns: filePatterns})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "read %s files in %s", IgnoreFile, root)
+	}
+
+	// later patterns take precedence, so deeper ignore files go last to
+	// override shallower ones, like git
+	sort.SliceStable(ignoreFiles, func(i, j int) bool {
+		return ignoreFiles[i].depth < ignoreFiles[j].depth
+	})
+	var patterns []gitignore.Pattern
+	for _, f := range ignoreFiles {
+		patterns = append(patterns, f.patterns...)
+	}
+
+	for _, exclude := range opts.Exclude {
+		patterns = append(patterns, gitignore.ParsePattern(exclude, nil))
+	}
+
+	var include []gitignore.Pattern
+	for _, p := range opts.Include {
+		include = append(include, gitignore.ParsePattern(p, nil))
+	}
+
+	return &Selector{
+		root:    root,
+		ignore:  gitignore.NewMatcher(patterns),
+		include: include,
+	}, nil
+}
+
+func readIgnoreFile(filename string, domain []string) ([]gitignore.Pattern, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns []gitignore.Pattern
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, domain))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "read %s", filename)
+	}
+	return patterns, nil
+}
+
+// Selected reports whether the file or directory at relPath, relative to the
+// yaml dir and slash separated, is part of the release. A directory that is
+// not selected is skipped entirely.
+func (s *Selector) Selected(relPath string, isDir bool) bool {
+	parts := strings.Split(relPath, "/")
+	if s.ignore.Match(parts, isDir) {
+		return false
+	}
+	if isDir {
+		return true
+	}
+
+	name := path.Base(relPath)
+	if strings.HasPrefix(name, ".") {
+		return false
+	}
+	switch path.Ext(name) {
+	case ".yaml", ".yml", ".tgz", ".gz":
+	default:
+		return false
+	}
+
+	if len(s.include) == 0 {
+		return true
+	}
+	for _, p := range s.include {
+		if p.Match(parts, false) == gitignore.Exclude {
+			return true
+		}
+	}
+	return false
+}
+
+// Walk calls fn for each release file under the yaml dir, in lexical order,
+// with its path relative to the yaml dir
+func (s *Selector) Walk(fn func(filePath string, relPath string, info os.FileInfo) error) error {
+	return filepath.Walk(s.root, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.root, filePath)
+		if err != nil {
+			return errors.Wrapf(err, "get relative path for %s", filePath)
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if !s.Selected(rel, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+		return fn(filePath, rel, info)
+	})
+}
+
+// List returns the paths of the release files under root, relative to root
+func List(root string, opts Options) ([]string, error) {
+	selector, err := NewSelector(root, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	err = selector.Walk(func(_ string, relPath string, _ os.FileInfo) error {
+		paths = append(paths, relPath)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "walk %s", root)
+	}
+	return paths, nil
+}
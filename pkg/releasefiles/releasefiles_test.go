@@ -0,0 +1,101 @@
+package releasefiles
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		opts  Options
+		want  []string
+	}{
+		{
+			name: "defaults",
+			files: map[string]string{
+				"app.yaml":          "",
+				"chart-1.0.0.tgz":   "",
+				"notes.txt":         "",
+				".hidden.yaml":      "",
+				"sub/config.yml":    "",
+				"sub/app.yaml~":     "",
+				".github/ci.yaml":   "",
+				"values.yaml.swp":   "",
+				"sub/deeper/a.yaml": "",
+			},
+			want: []string{".github/ci.yaml", "app.yaml", "chart-1.0.0.tgz", "sub/config.yml", "sub/deeper/a.yaml"},
+		},
+		{
+			name: "ignore file",
+			files: map[string]string{
+				".replicatedignore":     "# local overrides\nvalues-local.yaml\ntests/\n*.bak.yaml\n",
+				"app.yaml":              "",
+				"values-local.yaml":     "",
+				"sub/values-local.yaml": "",
+				"tests/fixture.yaml":    "",
+				"old.bak.yaml":          "",
+			},
+			want: []string{"app.yaml"},
+		},
+		{
+			name: "nested ignore file overrides root",
+			files: map[string]string{
+				".replicatedignore":     "*.dev.yaml\n",
+				"a.dev.yaml":            "",
+				"sub/.replicatedignore": "!keep.dev.yaml\nlocal.yaml\n",
+				"sub/keep.dev.yaml":     "",
+				"sub/drop.dev.yaml":     "",
+				"sub/local.yaml":        "",
+				"local.yaml":            "",
+			},
+			want: []string{"local.yaml", "sub/keep.dev.yaml"},
+		},
+		{
+			name: "exclude",
+			files: map[string]string{
+				"app.yaml":          "",
+				"tests/a.yaml":      "",
+				"sub/tests/b.yaml":  "",
+				"values-local.yaml": "",
+			},
+			opts: Options{Exclude: []string{"tests/", "/values-*.yaml"}},
+			want: []string{"app.yaml"},
+		},
+		{
+			name: "include",
+			files: map[string]string{
+				"app.yaml":         "",
+				"kots/config.yaml": "",
+				"kots/notes.txt":   "",
+				"other.yaml":       "",
+			},
+			opts: Options{Include: []string{"kots/**", "app.yaml"}},
+			want: []string{"app.yaml", "kots/config.yaml"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := require.New(t)
+
+			dir, err := ioutil.TempDir("", "releasefiles")
+			req.NoError(err)
+			defer os.RemoveAll(dir)
+
+			for name, content := range test.files {
+				req.NoError(os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+				req.NoError(ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+			}
+
+			got, err := List(dir, test.opts)
+			req.NoError(err)
+			req.Equal(test.want, got)
+		})
+	}
+}
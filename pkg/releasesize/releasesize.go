@@ -0,0 +1,152 @@
+// Package releasesize measures how large a KOTS release will be on the wire
+// before it is uploaded.
+package releasesize
+
+import (
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// FileSize is the size of one file in a release
+type FileSize struct {
+	Path string `json:"path"`
+	// RawSize is the size of the file on disk
+	RawSize int64 `json:"rawSize"`
+	// EncodedSize is the size of the file in the release spec, which is
+	// larger than RawSize for files sent as base64
+	EncodedSize int64 `json:"encodedSize"`
+}
+
+// A Report breaks down the size of a release
+type Report struct {
+	// Files are sorted by EncodedSize, largest first
+	Files []FileSize `json:"files"`
+	// SpecSize is the size of the JSON release spec
+	SpecSize int64 `json:"specSize"`
+	// CompressedSize is the size of the gzipped spec
+	CompressedSize int64 `json:"compressedSize"`
+	// PayloadSize is the size of the request body, the gzipped spec
+	// base64 encoded in a JSON object
+	PayloadSize int64 `json:"payloadSize"`
+}
+
+// payloadOverhead is the size of the JSON object around the encoded spec
+var payloadOverhead = int64(len(`{"spec_gzip":""}` + "\n"))
+
+// Analyze measures a release spec, the JSON list of files sent to create or
+// update a KOTS release
+func Analyze(spec string) (*Report, error) {
+	var files []struct {
+		Path    string `json:"path"`
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal([]byte(spec), &files); err != nil {
+		return nil, errors.Wrap(err, "parse release spec")
+	}
+
+	report := Report{
+		SpecSize: int64(len(spec)),
+	}
+	for _, file := range files {
+		size := FileSize{
+			Path:        file.Path,
+			RawSize:     int64(len(file.Content)),
+			EncodedSize: int64(len(file.Content)),
+		}
+		switch path.Ext(file.Path) {
+		case ".tgz", ".gz":
+			size.RawSize = int64(base64.StdEncoding.DecodedLen(len(file.Content)))
+			if decoded, err := base64.StdEncoding.DecodeString(file.Content); err == nil {
+				size.RawSize = int64(len(decoded))
+			}
+		}
+		report.Files = append(report.Files, size)
+	}
+	sort.SliceStable(report.Files, func(i, j int) bool {
+		return report.Files[i].EncodedSize > report.Files[j].EncodedSize
+	})
+
+	// count the compressed bytes without keeping them
+	counter := &countingWriter{}
+	gzipWriter := gzip.NewWriter(counter)
+	if _, err := io.Copy(gzipWriter, strings.NewReader(spec)); err != nil {
+		return nil, errors.Wrap(err, "compress release spec")
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, errors.Wrap(err, "compress release spec")
+	}
+	report.CompressedSize = counter.n
+	report.PayloadSize = int64(base64.StdEncoding.EncodedLen(int(counter.n))) + payloadOverhead
+
+	return &report, nil
+}
+
+// Largest returns up to n of the largest files
+func (r *Report) Largest(n int) []FileSize {
+	if n > len(r.Files) {
+		n = len(r.Files)
+	}
+	return r.Files[:n]
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+var sizeRegexp = regexp.MustCompile(`(?i)^(\d+(?:\.\d+)?)\s*(b|[kmg]i?b?)?$`)
+
+// ParseSize parses a size such as 512KB, 10MiB or 1048576. Units are powers
+// of 1024 with or without the "i".
+func ParseSize(s string) (int64, error) {
+	match := sizeRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return 0, errors.Errorf("invalid size %q, use a number of bytes or a size like 512KB or 10MB", s)
+	}
+
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid size %q", s)
+	}
+
+	multiplier := int64(1)
+	switch strings.ToUpper(match[2] + " ")[0] {
+	case 'K':
+		multiplier = 1 << 10
+	case 'M':
+		multiplier = 1 << 20
+	case 'G':
+		multiplier = 1 << 30
+	}
+
+	return int64(value * float64(multiplier)), nil
+}
+
+// FormatSize formats a number of bytes for display, using the same units
+// ParseSize accepts
+func FormatSize(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1fGB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fKB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%dB", n)
+	}
+}
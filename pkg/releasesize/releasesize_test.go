@@ -0,0 +1,80 @@
+package releasesize
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze(t *testing.T) {
+	req := require.New(t)
+
+	chart := strings.Repeat("x", 3000)
+	spec, err := json.Marshal([]map[string]interface{}{
+		{"name": "app.yaml", "path": "app.yaml", "content": "kind: Application\n", "children": []string{}},
+		{"name": "chart-1.0.0.tgz", "path": "chart-1.0.0.tgz", "content": base64.StdEncoding.EncodeToString([]byte(chart)), "children": []string{}},
+		{"name": "config.yaml", "path": "kots/config.yaml", "content": strings.Repeat("a", 100), "children": []string{}},
+	})
+	req.NoError(err)
+
+	report, err := Analyze(string(spec))
+	req.NoError(err)
+
+	req.Equal([]FileSize{
+		{Path: "chart-1.0.0.tgz", RawSize: 3000, EncodedSize: 4000},
+		{Path: "kots/config.yaml", RawSize: 100, EncodedSize: 100},
+		{Path: "app.yaml", RawSize: 18, EncodedSize: 18},
+	}, report.Files)
+	req.Equal(int64(len(spec)), report.SpecSize)
+	req.True(report.CompressedSize > 0 && report.CompressedSize < report.SpecSize)
+	req.Equal(int64(base64.StdEncoding.EncodedLen(int(report.CompressedSize)))+payloadOverhead, report.PayloadSize)
+
+	req.Len(report.Largest(2), 2)
+	req.Len(report.Largest(10), 3)
+}
+
+func TestAnalyzeInvalid(t *testing.T) {
+	_, err := Analyze("not json")
+	require.Error(t, err)
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "1048576", want: 1048576},
+		{in: "100B", want: 100},
+		{in: "512KB", want: 512 << 10},
+		{in: "512k", want: 512 << 10},
+		{in: "10MiB", want: 10 << 20},
+		{in: "1.5MB", want: 3 << 19},
+		{in: "2 GB", want: 2 << 30},
+		{in: "10TB", wantErr: true},
+		{in: "MB", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			got, err := ParseSize(test.in)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	require.Equal(t, "512B", FormatSize(512))
+	require.Equal(t, "1.5KB", FormatSize(1536))
+	require.Equal(t, "10.0MB", FormatSize(10<<20))
+	require.Equal(t, "2.0GB", FormatSize(2<<30))
+}
@@ -0,0 +1,215 @@
+// Package releasesource turns Helm chart source directories and Kustomize
+// overlays into the files of a KOTS release.
+package releasesource
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// A File is a file in a release, keyed by its path relative to the release
+// root
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// ChartMetadata is the part of Chart.yaml needed to package a chart
+type ChartMetadata struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+}
+
+// PackageChart packages the chart source in dir into the .tgz archive KOTS
+// expects, the same layout "helm package" produces: every file under a top
+// level directory named after the chart. Files matching .helmignore are left
+// out.
+func PackageChart(dir string) (*File, *ChartMetadata, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, "Chart.yaml"))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "read Chart.yaml")
+	}
+	meta := ChartMetadata{}
+	if err := yaml.Unmarshal(b, &meta); err != nil {
+		return nil, nil, errors.Wrap(err, "parse Chart.yaml")
+	}
+	if meta.Name == "" || meta.Version == "" {
+		return nil, nil, errors.New("Chart.yaml must set name and version")
+	}
+
+	ignore, err := readHelmIgnore(filepath.Join(dir, ".helmignore"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	err = filepath.Walk(dir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			return nil
+		}
+		if ignore.matches(rel, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		content, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return errors.Wrapf(err, "read %s", filePath)
+		}
+		header := &tar.Header{
+			Name:    path.Join(meta.Name, rel),
+			Mode:    0644,
+			Size:    int64(len(content)),
+			ModTime: info.ModTime(),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err = tw.Write(content)
+		return err
+	})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "package chart %s", dir)
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, nil, errors.Wrap(err, "close tar")
+	}
+	if err := gz.Close(); err != nil {
+		return nil, nil, errors.Wrap(err, "close gzip")
+	}
+
+	return &File{
+		Path:    fmt.Sprintf("%s-%s.tgz", meta.Name, meta.Version),
+		Content: buf.Bytes(),
+	}, &meta, nil
+}
+
+// HelmChartKind returns a kots.io/v1beta1 HelmChart manifest for a chart,
+// with no values set
+func HelmChartKind(meta ChartMetadata) File {
+	content := fmt.Sprintf(`apiVersion: kots.io/v1beta1
+kind: HelmChart
+metadata:
+  name: %s
+spec:
+  chart:
+    name: %s
+    chartVersion: %s
+  values: {}
+`, meta.Name, meta.Name, meta.Version)
+
+	return File{
+		Path:    meta.Name + "-helmchart.yaml",
+		Content: []byte(content),
+	}
+}
+
+// HasHelmChartKind reports whether any of the yaml files defines a HelmChart
+// for the named chart
+func HasHelmChartKind(files []File, chartName string) bool {
+	type helmChart struct {
+		Kind string `yaml:"kind"`
+		Spec struct {
+			Chart struct {
+				Name string `yaml:"name"`
+			} `yaml:"chart"`
+		} `yaml:"spec"`
+	}
+
+	for _, file := range files {
+		switch path.Ext(file.Path) {
+		case ".yaml", ".yml":
+		default:
+			continue
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader(file.Content))
+		for {
+			doc := helmChart{}
+			if err := decoder.Decode(&doc); err != nil {
+				// stop at the end of the file, and at anything that is
+				// not valid yaml, such as unquoted templates
+				break
+			}
+			if doc.Kind == "HelmChart" && doc.Spec.Chart.Name == chartName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// helmIgnore holds the patterns of a .helmignore file. Patterns are matched
+// with path.Match against the relative path and the base name, a trailing /
+// only matches directories and a leading ! is not supported, like helm.
+type helmIgnore []string
+
+func readHelmIgnore(filename string) (helmIgnore, error) {
+	f, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "open .helmignore")
+	}
+	defer f.Close()
+
+	var patterns helmIgnore
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if err := scanner.Err(); err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "read .helmignore")
+	}
+	return patterns, nil
+}
+
+func (h helmIgnore) matches(rel string, isDir bool) bool {
+	for _, pattern := range h {
+		if strings.HasSuffix(pattern, "/") {
+			if !isDir {
+				continue
+			}
+			pattern = strings.TrimSuffix(pattern, "/")
+		}
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(rel)); ok {
+			return true
+		}
+	}
+	return false
+}
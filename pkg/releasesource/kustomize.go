@@ -0,0 +1,86 @@
+package releasesource
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// kustomizeCommands are tried in order to render an overlay
+var kustomizeCommands = [][]string{
+	{"kustomize", "build"},
+	{"kubectl", "kustomize"},
+}
+
+var invalidFilenameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// Kustomize renders the overlay in dir with the kustomize or kubectl binary
+// and returns one file per resource
+func Kustomize(ctx context.Context, dir string) ([]File, error) {
+	var lastErr error
+	for _, command := range kustomizeCommands {
+		binary, err := exec.LookPath(command[0])
+		if err != nil {
+			continue
+		}
+
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, binary, append(command[1:], dir)...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			lastErr = errors.Wrapf(err, "%s: %s", strings.Join(command, " "), strings.TrimSpace(stderr.String()))
+			continue
+		}
+
+		return SplitManifests(stdout.Bytes())
+	}
+
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, errors.New("kustomize or kubectl must be installed to render an overlay")
+}
+
+// SplitManifests splits multi-document yaml into one file per resource, named
+// after the resource's kind and name
+func SplitManifests(content []byte) ([]File, error) {
+	type resource struct {
+		Kind     string `yaml:"kind"`
+		Metadata struct {
+			Name string `yaml:"name"`
+		} `yaml:"metadata"`
+	}
+
+	var files []File
+	seen := map[string]int{}
+	for _, doc := range strings.Split(string(content), "\n---\n") {
+		doc = strings.TrimPrefix(doc, "---\n")
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+
+		r := resource{}
+		if err := yaml.Unmarshal([]byte(doc), &r); err != nil {
+			return nil, errors.Wrap(err, "parse rendered manifest")
+		}
+
+		name := invalidFilenameChars.ReplaceAllString(strings.ToLower(r.Kind+"-"+r.Metadata.Name), "-")
+		seen[name]++
+		if seen[name] > 1 {
+			name = fmt.Sprintf("%s-%d", name, seen[name])
+		}
+
+		if !strings.HasSuffix(doc, "\n") {
+			doc += "\n"
+		}
+		files = append(files, File{Path: name + ".yaml", Content: []byte(doc)})
+	}
+	return files, nil
+}
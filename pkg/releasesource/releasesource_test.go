@@ -0,0 +1,153 @@
+package releasesource
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageChart(t *testing.T) {
+	req := require.New(t)
+
+	dir, err := ioutil.TempDir("", "releasesource")
+	req.NoError(err)
+	defer os.RemoveAll(dir)
+
+	write := func(name string, content string) {
+		req.NoError(os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		req.NoError(ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	write("Chart.yaml", "apiVersion: v2\nname: my-chart\nversion: 1.2.3\n")
+	write("values.yaml", "replicas: 1\n")
+	write("templates/deployment.yaml", "kind: Deployment\n")
+	write("templates/notes.bak", "ignored")
+	write("ci/values.yaml", "ignored")
+	write(".helmignore", "# comment\n*.bak\nci/\n")
+
+	file, meta, err := PackageChart(dir)
+	req.NoError(err)
+	req.Equal("my-chart-1.2.3.tgz", file.Path)
+	req.Equal(ChartMetadata{Name: "my-chart", Version: "1.2.3"}, *meta)
+
+	gz, err := gzip.NewReader(bytes.NewReader(file.Content))
+	req.NoError(err)
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		req.NoError(err)
+		names = append(names, header.Name)
+	}
+	sort.Strings(names)
+	req.Equal([]string{
+		"my-chart/.helmignore",
+		"my-chart/Chart.yaml",
+		"my-chart/templates/deployment.yaml",
+		"my-chart/values.yaml",
+	}, names)
+}
+
+func TestPackageChartMissingVersion(t *testing.T) {
+	req := require.New(t)
+
+	dir, err := ioutil.TempDir("", "releasesource")
+	req.NoError(err)
+	defer os.RemoveAll(dir)
+
+	req.NoError(ioutil.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte("name: my-chart\n"), 0644))
+
+	_, _, err = PackageChart(dir)
+	req.EqualError(err, "Chart.yaml must set name and version")
+}
+
+func TestHasHelmChartKind(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []File
+		want  bool
+	}{
+		{
+			name:  "generated kind",
+			files: []File{HelmChartKind(ChartMetadata{Name: "my-chart", Version: "1.0.0"})},
+			want:  true,
+		},
+		{
+			name: "second document",
+			files: []File{{
+				Path:    "kinds.yaml",
+				Content: []byte("kind: Config\n---\nkind: HelmChart\nspec:\n  chart:\n    name: my-chart\n"),
+			}},
+			want: true,
+		},
+		{
+			name: "other chart",
+			files: []File{{
+				Path:    "other.yaml",
+				Content: []byte("kind: HelmChart\nspec:\n  chart:\n    name: other\n"),
+			}},
+			want: false,
+		},
+		{
+			name: "not yaml",
+			files: []File{{
+				Path:    "my-chart.txt",
+				Content: []byte("kind: HelmChart\nspec:\n  chart:\n    name: my-chart\n"),
+			}},
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.want, HasHelmChartKind(test.files, "my-chart"))
+		})
+	}
+}
+
+func TestSplitManifests(t *testing.T) {
+	req := require.New(t)
+
+	rendered := `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web
+  namespace: a
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web
+  namespace: b
+`
+
+	files, err := SplitManifests([]byte(rendered))
+	req.NoError(err)
+
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	req.Equal([]string{"service-web.yaml", "deployment-web.yaml", "configmap-web.yaml", "configmap-web-2.yaml"}, paths)
+	req.Equal("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n", string(files[1].Content))
+}
@@ -0,0 +1,30 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/replicatedhq/replicated/client"
+	"github.com/replicatedhq/replicated/pkg/types"
+)
+
+type apps struct {
+	api *client.Client
+}
+
+var _ Apps = (*apps)(nil)
+
+func (a *apps) List(ctx context.Context) ([]types.AppAndChannels, error) {
+	return a.api.ListAppsContext(ctx)
+}
+
+func (a *apps) Get(ctx context.Context, appIDOrSlug string) (*App, error) {
+	app, appType, err := a.api.GetAppTypeContext(ctx, appIDOrSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	if appType == "kots" {
+		return newKotsApp(a.api.KotsClient, app), nil
+	}
+	return newLegacyApp(a.api, app, appType), nil
+}
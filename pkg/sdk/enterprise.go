@@ -0,0 +1,90 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/replicatedhq/replicated/pkg/enterpriseclient"
+	"github.com/replicatedhq/replicated/pkg/enterprisetypes"
+)
+
+type enterprise struct {
+	client *enterpriseclient.HTTPClient
+}
+
+var _ Enterprise = (*enterprise)(nil)
+
+func (e *enterprise) ListChannels(ctx context.Context) ([]*enterprisetypes.Channel, error) {
+	return e.client.ListChannelsContext(ctx)
+}
+
+func (e *enterprise) CreateChannel(ctx context.Context, name string, description string) (*enterprisetypes.Channel, error) {
+	return e.client.CreateChannelContext(ctx, name, description)
+}
+
+func (e *enterprise) UpdateChannel(ctx context.Context, id string, name string, description string) (*enterprisetypes.Channel, error) {
+	return e.client.UpdateChannelContext(ctx, id, name, description)
+}
+
+func (e *enterprise) RemoveChannel(ctx context.Context, id string) error {
+	return e.client.RemoveChannelContext(ctx, id)
+}
+
+func (e *enterprise) AssignChannel(ctx context.Context, channelID string, teamID string) error {
+	return e.client.AssignChannelContext(ctx, channelID, teamID)
+}
+
+func (e *enterprise) ListPolicies(ctx context.Context) ([]*enterprisetypes.Policy, error) {
+	return e.client.ListPoliciesContext(ctx)
+}
+
+func (e *enterprise) CreatePolicy(ctx context.Context, name string, description string, policy string) (*enterprisetypes.Policy, error) {
+	return e.client.CreatePolicyContext(ctx, name, description, policy)
+}
+
+func (e *enterprise) UpdatePolicy(ctx context.Context, id string, name string, description string, policy string) (*enterprisetypes.Policy, error) {
+	return e.client.UpdatePolicyContext(ctx, id, name, description, policy)
+}
+
+func (e *enterprise) RemovePolicy(ctx context.Context, id string) error {
+	return e.client.RemovePolicyContext(ctx, id)
+}
+
+func (e *enterprise) AssignPolicy(ctx context.Context, policyID string, channelID string) error {
+	return e.client.AssignPolicyContext(ctx, policyID, channelID)
+}
+
+func (e *enterprise) UnassignPolicy(ctx context.Context, policyID string, channelID string) error {
+	return e.client.UnassignPolicyContext(ctx, policyID, channelID)
+}
+
+func (e *enterprise) ListInstallers(ctx context.Context) ([]*enterprisetypes.Installer, error) {
+	return e.client.ListInstallersContext(ctx)
+}
+
+func (e *enterprise) CreateInstaller(ctx context.Context, yaml string) (*enterprisetypes.Installer, error) {
+	return e.client.CreateInstallerContext(ctx, yaml)
+}
+
+func (e *enterprise) UpdateInstaller(ctx context.Context, id string, yaml string) (*enterprisetypes.Installer, error) {
+	return e.client.UpdateInstallerContext(ctx, id, yaml)
+}
+
+func (e *enterprise) RemoveInstaller(ctx context.Context, id string) error {
+	return e.client.RemoveInstallerContext(ctx, id)
+}
+
+func (e *enterprise) AssignInstaller(ctx context.Context, installerID string, channelID string) error {
+	return e.client.AssignInstallerContext(ctx, installerID, channelID)
+}
+
+func (e *enterprise) ListAuthKeys(ctx context.Context) ([]*enterprisetypes.AuthKey, error) {
+	return e.client.ListAuthKeysContext(ctx)
+}
+
+func (e *enterprise) RevokeAuthKey(ctx context.Context, fingerprint string) error {
+	return e.client.RevokeAuthKeyContext(ctx, fingerprint)
+}
+
+func (e *enterprise) Whoami(ctx context.Context) (*enterprisetypes.AuthIdentity, error) {
+	return e.client.AuthWhoamiContext(ctx)
+}
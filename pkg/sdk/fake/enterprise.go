@@ -0,0 +1,383 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/enterprisetypes"
+	"github.com/replicatedhq/replicated/pkg/sdk"
+)
+
+type enterpriseState struct {
+	channels   []*enterprisetypes.Channel
+	policies   []*enterprisetypes.Policy
+	installers []*enterprisetypes.Installer
+	authKeys   []*enterprisetypes.AuthKey
+
+	// channelTeams maps channel IDs to the teams they are assigned to
+	channelTeams map[string][]string
+	// channelPolicies maps channel IDs to their policies
+	channelPolicies map[string][]string
+	// channelInstallers maps channel IDs to their installer
+	channelInstallers map[string]string
+}
+
+// AddAuthKey adds a key to the enterprise organization. The first key added
+// is the one Whoami describes.
+func (f *Fake) AddAuthKey(fingerprint string, status string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.enterprise.authKeys = append(f.enterprise.authKeys, &enterprisetypes.AuthKey{
+		Fingerprint: fingerprint,
+		Status:      status,
+		CreatedAt:   f.now(),
+	})
+}
+
+// ChannelTeams returns the teams an enterprise channel is assigned to
+func (f *Fake) ChannelTeams(channelID string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.enterprise.channelTeams[channelID]...)
+}
+
+// ChannelPolicies returns the IDs of the policies assigned to an enterprise
+// channel
+func (f *Fake) ChannelPolicies(channelID string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.enterprise.channelPolicies[channelID]...)
+}
+
+// ChannelInstaller returns the ID of the installer assigned to an enterprise
+// channel
+func (f *Fake) ChannelInstaller(channelID string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.enterprise.channelInstallers[channelID]
+}
+
+type enterprise struct {
+	f *Fake
+}
+
+var _ sdk.Enterprise = (*enterprise)(nil)
+
+func (e *enterprise) channel(id string) (*enterprisetypes.Channel, error) {
+	for _, channel := range e.f.enterprise.channels {
+		if channel.ID == id {
+			return channel, nil
+		}
+	}
+	return nil, errors.Errorf("channel %s not found", id)
+}
+
+func (e *enterprise) policy(id string) (*enterprisetypes.Policy, error) {
+	for _, policy := range e.f.enterprise.policies {
+		if policy.ID == id {
+			return policy, nil
+		}
+	}
+	return nil, errors.Errorf("policy %s not found", id)
+}
+
+func (e *enterprise) installer(id string) (*enterprisetypes.Installer, error) {
+	for _, installer := range e.f.enterprise.installers {
+		if installer.ID == id {
+			return installer, nil
+		}
+	}
+	return nil, errors.Errorf("installer %s not found", id)
+}
+
+func (e *enterprise) ListChannels(ctx context.Context) ([]*enterprisetypes.Channel, error) {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	result := []*enterprisetypes.Channel{}
+	for _, channel := range e.f.enterprise.channels {
+		c := *channel
+		result = append(result, &c)
+	}
+	return result, nil
+}
+
+func (e *enterprise) CreateChannel(ctx context.Context, name string, description string) (*enterprisetypes.Channel, error) {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	channel := &enterprisetypes.Channel{
+		ID:          e.f.id("channel"),
+		Name:        name,
+		Description: description,
+	}
+	e.f.enterprise.channels = append(e.f.enterprise.channels, channel)
+	c := *channel
+	return &c, nil
+}
+
+func (e *enterprise) UpdateChannel(ctx context.Context, id string, name string, description string) (*enterprisetypes.Channel, error) {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	channel, err := e.channel(id)
+	if err != nil {
+		return nil, err
+	}
+	channel.Name = name
+	channel.Description = description
+	c := *channel
+	return &c, nil
+}
+
+func (e *enterprise) RemoveChannel(ctx context.Context, id string) error {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	for i, channel := range e.f.enterprise.channels {
+		if channel.ID == id {
+			e.f.enterprise.channels = append(e.f.enterprise.channels[:i], e.f.enterprise.channels[i+1:]...)
+			delete(e.f.enterprise.channelTeams, id)
+			delete(e.f.enterprise.channelPolicies, id)
+			delete(e.f.enterprise.channelInstallers, id)
+			return nil
+		}
+	}
+	return errors.Errorf("channel %s not found", id)
+}
+
+func (e *enterprise) AssignChannel(ctx context.Context, channelID string, teamID string) error {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	if _, err := e.channel(channelID); err != nil {
+		return err
+	}
+	if e.f.enterprise.channelTeams == nil {
+		e.f.enterprise.channelTeams = map[string][]string{}
+	}
+	e.f.enterprise.channelTeams[channelID] = appendUnique(e.f.enterprise.channelTeams[channelID], teamID)
+	return nil
+}
+
+func (e *enterprise) ListPolicies(ctx context.Context) ([]*enterprisetypes.Policy, error) {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	result := []*enterprisetypes.Policy{}
+	for _, policy := range e.f.enterprise.policies {
+		p := *policy
+		result = append(result, &p)
+	}
+	return result, nil
+}
+
+func (e *enterprise) CreatePolicy(ctx context.Context, name string, description string, policy string) (*enterprisetypes.Policy, error) {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	created := &enterprisetypes.Policy{
+		ID:          e.f.id("policy"),
+		Name:        name,
+		Description: description,
+		Policy:      policy,
+	}
+	e.f.enterprise.policies = append(e.f.enterprise.policies, created)
+	p := *created
+	return &p, nil
+}
+
+func (e *enterprise) UpdatePolicy(ctx context.Context, id string, name string, description string, policy string) (*enterprisetypes.Policy, error) {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	updated, err := e.policy(id)
+	if err != nil {
+		return nil, err
+	}
+	updated.Name = name
+	updated.Description = description
+	updated.Policy = policy
+	p := *updated
+	return &p, nil
+}
+
+func (e *enterprise) RemovePolicy(ctx context.Context, id string) error {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	for i, policy := range e.f.enterprise.policies {
+		if policy.ID == id {
+			e.f.enterprise.policies = append(e.f.enterprise.policies[:i], e.f.enterprise.policies[i+1:]...)
+			for channelID, policyIDs := range e.f.enterprise.channelPolicies {
+				e.f.enterprise.channelPolicies[channelID] = remove(policyIDs, id)
+			}
+			return nil
+		}
+	}
+	return errors.Errorf("policy %s not found", id)
+}
+
+func (e *enterprise) AssignPolicy(ctx context.Context, policyID string, channelID string) error {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	if _, err := e.policy(policyID); err != nil {
+		return err
+	}
+	if _, err := e.channel(channelID); err != nil {
+		return err
+	}
+	if e.f.enterprise.channelPolicies == nil {
+		e.f.enterprise.channelPolicies = map[string][]string{}
+	}
+	e.f.enterprise.channelPolicies[channelID] = appendUnique(e.f.enterprise.channelPolicies[channelID], policyID)
+	return nil
+}
+
+func (e *enterprise) UnassignPolicy(ctx context.Context, policyID string, channelID string) error {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	if _, err := e.policy(policyID); err != nil {
+		return err
+	}
+	if _, err := e.channel(channelID); err != nil {
+		return err
+	}
+	e.f.enterprise.channelPolicies[channelID] = remove(e.f.enterprise.channelPolicies[channelID], policyID)
+	return nil
+}
+
+func (e *enterprise) ListInstallers(ctx context.Context) ([]*enterprisetypes.Installer, error) {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	result := []*enterprisetypes.Installer{}
+	for _, installer := range e.f.enterprise.installers {
+		i := *installer
+		result = append(result, &i)
+	}
+	return result, nil
+}
+
+func (e *enterprise) CreateInstaller(ctx context.Context, yaml string) (*enterprisetypes.Installer, error) {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	installer := &enterprisetypes.Installer{
+		ID:   e.f.id("installer"),
+		Yaml: yaml,
+	}
+	e.f.enterprise.installers = append(e.f.enterprise.installers, installer)
+	i := *installer
+	return &i, nil
+}
+
+func (e *enterprise) UpdateInstaller(ctx context.Context, id string, yaml string) (*enterprisetypes.Installer, error) {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	installer, err := e.installer(id)
+	if err != nil {
+		return nil, err
+	}
+	installer.Yaml = yaml
+	i := *installer
+	return &i, nil
+}
+
+func (e *enterprise) RemoveInstaller(ctx context.Context, id string) error {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	for i, installer := range e.f.enterprise.installers {
+		if installer.ID == id {
+			e.f.enterprise.installers = append(e.f.enterprise.installers[:i], e.f.enterprise.installers[i+1:]...)
+			for channelID, installerID := range e.f.enterprise.channelInstallers {
+				if installerID == id {
+					delete(e.f.enterprise.channelInstallers, channelID)
+				}
+			}
+			return nil
+		}
+	}
+	return errors.Errorf("installer %s not found", id)
+}
+
+func (e *enterprise) AssignInstaller(ctx context.Context, installerID string, channelID string) error {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	if _, err := e.installer(installerID); err != nil {
+		return err
+	}
+	if _, err := e.channel(channelID); err != nil {
+		return err
+	}
+	if e.f.enterprise.channelInstallers == nil {
+		e.f.enterprise.channelInstallers = map[string]string{}
+	}
+	e.f.enterprise.channelInstallers[channelID] = installerID
+	return nil
+}
+
+func (e *enterprise) ListAuthKeys(ctx context.Context) ([]*enterprisetypes.AuthKey, error) {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	result := []*enterprisetypes.AuthKey{}
+	for _, authKey := range e.f.enterprise.authKeys {
+		k := *authKey
+		result = append(result, &k)
+	}
+	return result, nil
+}
+
+func (e *enterprise) RevokeAuthKey(ctx context.Context, fingerprint string) error {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	for _, authKey := range e.f.enterprise.authKeys {
+		if authKey.Fingerprint == fingerprint {
+			authKey.Status = enterprisetypes.AuthKeyStatusRevoked
+			return nil
+		}
+	}
+	return errors.Errorf("auth key %s not found", fingerprint)
+}
+
+func (e *enterprise) Whoami(ctx context.Context) (*enterprisetypes.AuthIdentity, error) {
+	e.f.mu.Lock()
+	defer e.f.mu.Unlock()
+
+	if len(e.f.enterprise.authKeys) == 0 {
+		return nil, errors.New("no private key is configured")
+	}
+	authKey := e.f.enterprise.authKeys[0]
+	return &enterprisetypes.AuthIdentity{
+		Fingerprint: authKey.Fingerprint,
+		KeyType:     "ecdsa-sha2-nistp521",
+		Status:      authKey.Status,
+	}, nil
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+func remove(values []string, value string) []string {
+	result := []string{}
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
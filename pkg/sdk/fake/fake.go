@@ -0,0 +1,622 @@
+// Package fake is an in-memory implementation of the sdk interfaces, for
+// testing code that uses the sdk without the Replicated API:
+//
+//	f := fake.New()
+//	app := f.AddApp("My App")
+//	err := codeUnderTest(ctx, f.Client(), app.Slug)
+//	spec, _ := f.ReleaseSpec(app.ID, 1)
+//
+// All apps are KOTS apps. IDs are generated from a counter, so they are the
+// same on every run. The values returned are copies, changing them does not
+// change the fake.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/sdk"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/replicatedhq/replicated/pkg/util"
+)
+
+// Fake holds the state of the fake APIs. It is safe for concurrent use.
+type Fake struct {
+	mu     sync.Mutex
+	nextID int
+	now    func() time.Time
+
+	apps       []*appState
+	enterprise enterpriseState
+}
+
+type appState struct {
+	app        types.App
+	channels   []*types.Channel
+	releases   []*releaseState
+	customers  []*types.Customer
+	installers []*types.InstallerSpec
+}
+
+type releaseState struct {
+	info types.ReleaseInfo
+	spec string
+}
+
+func New() *Fake {
+	return &Fake{
+		now: time.Now,
+	}
+}
+
+// SetNow sets the clock used for created timestamps
+func (f *Fake) SetNow(now func() time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+}
+
+// Client returns a client for the fake
+func (f *Fake) Client() *sdk.Client {
+	return &sdk.Client{
+		Apps:       &apps{f},
+		Enterprise: &enterprise{f},
+	}
+}
+
+// AddApp adds a KOTS app with a slug made from its name
+func (f *Fake) AddApp(name string) *types.App {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	state := &appState{
+		app: types.App{
+			ID:        f.id("app"),
+			Name:      name,
+			Slug:      slug(name),
+			Scheduler: "kots",
+		},
+	}
+	f.apps = append(f.apps, state)
+	app := state.app
+	return &app
+}
+
+// ReleaseSpec returns the spec a release was created or last updated with
+func (f *Fake) ReleaseSpec(appID string, sequence int64) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	state, err := f.app(appID)
+	if err != nil {
+		return "", false
+	}
+	release, err := state.release(sequence)
+	if err != nil {
+		return "", false
+	}
+	return release.spec, true
+}
+
+// id returns a new ID, f.mu must be held
+func (f *Fake) id(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s-%d", prefix, f.nextID)
+}
+
+// app finds an app by ID or slug, f.mu must be held
+func (f *Fake) app(appIDOrSlug string) (*appState, error) {
+	for _, state := range f.apps {
+		if state.app.ID == appIDOrSlug || state.app.Slug == appIDOrSlug {
+			return state, nil
+		}
+	}
+	return nil, errors.Errorf("app %s not found", appIDOrSlug)
+}
+
+func (a *appState) channel(channelID string) (*types.Channel, error) {
+	for _, channel := range a.channels {
+		if channel.ID == channelID {
+			return channel, nil
+		}
+	}
+	return nil, errors.Errorf("channel %s not found", channelID)
+}
+
+func (a *appState) release(sequence int64) (*releaseState, error) {
+	for _, release := range a.releases {
+		if release.info.Sequence == sequence {
+			return release, nil
+		}
+	}
+	return nil, errors.Errorf("release %d not found", sequence)
+}
+
+func (a *appState) customer(customerID string) (*types.Customer, error) {
+	for _, customer := range a.customers {
+		if customer.ID == customerID {
+			return customer, nil
+		}
+	}
+	return nil, errors.Errorf("customer %s not found", customerID)
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slug(name string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+type apps struct {
+	f *Fake
+}
+
+var _ sdk.Apps = (*apps)(nil)
+
+func (a *apps) List(ctx context.Context) ([]types.AppAndChannels, error) {
+	a.f.mu.Lock()
+	defer a.f.mu.Unlock()
+
+	result := []types.AppAndChannels{}
+	for _, state := range a.f.apps {
+		app := state.app
+		result = append(result, types.AppAndChannels{
+			App:      &app,
+			Channels: copyChannels(state.channels),
+		})
+	}
+	return result, nil
+}
+
+func (a *apps) Get(ctx context.Context, appIDOrSlug string) (*sdk.App, error) {
+	a.f.mu.Lock()
+	defer a.f.mu.Unlock()
+
+	state, err := a.f.app(appIDOrSlug)
+	if err != nil {
+		return nil, err
+	}
+	appID := state.app.ID
+	return &sdk.App{
+		App:        state.app,
+		Type:       "kots",
+		Channels:   &channels{f: a.f, appID: appID},
+		Releases:   &releases{f: a.f, appID: appID},
+		Customers:  &customers{f: a.f, appID: appID},
+		Installers: &installers{f: a.f, appID: appID},
+	}, nil
+}
+
+func copyChannels(channels []*types.Channel) []types.Channel {
+	result := []types.Channel{}
+	for _, channel := range channels {
+		result = append(result, *channel)
+	}
+	return result
+}
+
+type channels struct {
+	f     *Fake
+	appID string
+}
+
+var _ sdk.Channels = (*channels)(nil)
+
+func (c *channels) List(ctx context.Context) ([]types.Channel, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	state, err := c.f.app(c.appID)
+	if err != nil {
+		return nil, err
+	}
+	return copyChannels(state.channels), nil
+}
+
+func (c *channels) Find(ctx context.Context, nameOrID string) (*types.Channel, error) {
+	channels, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sdk.FindChannel(channels, nameOrID)
+}
+
+func (c *channels) Create(ctx context.Context, name string, description string) (*types.Channel, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	state, err := c.f.app(c.appID)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, errors.New("channel name is required")
+	}
+	channel := &types.Channel{
+		ID:          c.f.id("channel"),
+		Name:        name,
+		Description: description,
+		Slug:        slug(name),
+	}
+	state.channels = append(state.channels, channel)
+	created := *channel
+	return &created, nil
+}
+
+func (c *channels) Archive(ctx context.Context, channelID string) error {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	state, err := c.f.app(c.appID)
+	if err != nil {
+		return err
+	}
+	for i, channel := range state.channels {
+		if channel.ID == channelID {
+			state.channels = append(state.channels[:i], state.channels[i+1:]...)
+			return nil
+		}
+	}
+	return errors.Errorf("channel %s not found", channelID)
+}
+
+type releases struct {
+	f     *Fake
+	appID string
+}
+
+var _ sdk.Releases = (*releases)(nil)
+
+func (r *releases) List(ctx context.Context) ([]types.ReleaseInfo, error) {
+	r.f.mu.Lock()
+	defer r.f.mu.Unlock()
+
+	state, err := r.f.app(r.appID)
+	if err != nil {
+		return nil, err
+	}
+	result := []types.ReleaseInfo{}
+	// newest first, like the API
+	for i := len(state.releases) - 1; i >= 0; i-- {
+		info := state.releases[i].info
+		info.ActiveChannels = activeChannels(state.channels, info.Sequence)
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+func activeChannels(channels []*types.Channel, sequence int64) []types.Channel {
+	result := []types.Channel{}
+	for _, channel := range channels {
+		if channel.ReleaseSequence == sequence {
+			result = append(result, *channel)
+		}
+	}
+	return result
+}
+
+func (r *releases) Create(ctx context.Context, spec string) (*types.ReleaseInfo, error) {
+	r.f.mu.Lock()
+	defer r.f.mu.Unlock()
+
+	state, err := r.f.app(r.appID)
+	if err != nil {
+		return nil, err
+	}
+	now := r.f.now()
+	release := &releaseState{
+		info: types.ReleaseInfo{
+			AppID:     state.app.ID,
+			CreatedAt: now,
+			EditedAt:  now,
+			Editable:  true,
+			Sequence:  int64(len(state.releases) + 1),
+		},
+		spec: spec,
+	}
+	state.releases = append(state.releases, release)
+	info := release.info
+	return &info, nil
+}
+
+func (r *releases) Update(ctx context.Context, sequence int64, spec string) error {
+	r.f.mu.Lock()
+	defer r.f.mu.Unlock()
+
+	state, err := r.f.app(r.appID)
+	if err != nil {
+		return err
+	}
+	release, err := state.release(sequence)
+	if err != nil {
+		return err
+	}
+	if !release.info.Editable {
+		return errors.Errorf("release %d has been promoted and cannot be edited", sequence)
+	}
+	release.spec = spec
+	release.info.EditedAt = r.f.now()
+	return nil
+}
+
+func (r *releases) Promote(ctx context.Context, sequence int64, opts sdk.PromoteOptions) error {
+	r.f.mu.Lock()
+	defer r.f.mu.Unlock()
+
+	state, err := r.f.app(r.appID)
+	if err != nil {
+		return err
+	}
+	release, err := state.release(sequence)
+	if err != nil {
+		return err
+	}
+	if len(opts.ChannelIDs) == 0 {
+		return errors.New("at least one channel is required")
+	}
+	promoteTo := []*types.Channel{}
+	for _, channelID := range opts.ChannelIDs {
+		channel, err := state.channel(channelID)
+		if err != nil {
+			return err
+		}
+		promoteTo = append(promoteTo, channel)
+	}
+	for _, channel := range promoteTo {
+		channel.ReleaseSequence = sequence
+		channel.ReleaseLabel = opts.VersionLabel
+	}
+	release.info.Editable = false
+	release.info.Version = opts.VersionLabel
+	return nil
+}
+
+type customers struct {
+	f     *Fake
+	appID string
+}
+
+var _ sdk.Customers = (*customers)(nil)
+
+func (c *customers) List(ctx context.Context) ([]types.Customer, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	state, err := c.f.app(c.appID)
+	if err != nil {
+		return nil, err
+	}
+	result := []types.Customer{}
+	for _, customer := range state.customers {
+		result = append(result, *customer)
+	}
+	return result, nil
+}
+
+func (c *customers) Get(ctx context.Context, customerID string) (*types.Customer, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	state, err := c.f.app(c.appID)
+	if err != nil {
+		return nil, err
+	}
+	customer, err := state.customer(customerID)
+	if err != nil {
+		return nil, err
+	}
+	result := *customer
+	return &result, nil
+}
+
+func (c *customers) Create(ctx context.Context, opts types.CustomerOptions) (*types.Customer, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	state, err := c.f.app(c.appID)
+	if err != nil {
+		return nil, err
+	}
+	customer := &types.Customer{ID: c.f.id("customer")}
+	if err := applyCustomerOptions(state, customer, opts); err != nil {
+		return nil, err
+	}
+	state.customers = append(state.customers, customer)
+	result := *customer
+	return &result, nil
+}
+
+func (c *customers) Update(ctx context.Context, customerID string, opts types.CustomerOptions) (*types.Customer, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	state, err := c.f.app(c.appID)
+	if err != nil {
+		return nil, err
+	}
+	customer, err := state.customer(customerID)
+	if err != nil {
+		return nil, err
+	}
+	updated := *customer
+	if err := applyCustomerOptions(state, &updated, opts); err != nil {
+		return nil, err
+	}
+	*customer = updated
+	return &updated, nil
+}
+
+// applyCustomerOptions sets the fields of customer like the API does, all of
+// them are replaced
+func applyCustomerOptions(state *appState, customer *types.Customer, opts types.CustomerOptions) error {
+	if opts.Name == "" {
+		return errors.New("customer name is required")
+	}
+	if opts.ChannelID == "" {
+		return errors.New("customer channel is required")
+	}
+	channel, err := state.channel(opts.ChannelID)
+	if err != nil {
+		return err
+	}
+	customerType := opts.Type
+	if customerType == "" {
+		customerType = "dev"
+	}
+
+	customer.Name = opts.Name
+	customer.Type = customerType
+	customer.Channels = []types.Channel{*channel}
+	customer.Expires = nil
+	if opts.ExpiresAt != nil {
+		customer.Expires = &util.Time{Time: *opts.ExpiresAt}
+	}
+	customer.IsAirgapEnabled = opts.IsAirgapEnabled
+	customer.IsGitopsSupported = opts.IsGitopsSupported
+	customer.IsSnapshotSupported = opts.IsSnapshotSupported
+	return nil
+}
+
+func (c *customers) Archive(ctx context.Context, customerID string) error {
+	return c.setArchived(customerID, true)
+}
+
+func (c *customers) Unarchive(ctx context.Context, customerID string) error {
+	return c.setArchived(customerID, false)
+}
+
+func (c *customers) setArchived(customerID string, archived bool) error {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	state, err := c.f.app(c.appID)
+	if err != nil {
+		return err
+	}
+	customer, err := state.customer(customerID)
+	if err != nil {
+		return err
+	}
+	customer.IsArchived = archived
+	return nil
+}
+
+// DownloadLicense returns an unsigned license with the customer's fields
+func (c *customers) DownloadLicense(ctx context.Context, customerID string) ([]byte, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+
+	state, err := c.f.app(c.appID)
+	if err != nil {
+		return nil, err
+	}
+	customer, err := state.customer(customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	channel := types.Channel{}
+	if len(customer.Channels) > 0 {
+		channel = customer.Channels[0]
+	}
+	license := fmt.Sprintf(`apiVersion: kots.io/v1beta1
+kind: License
+metadata:
+  name: %s
+spec:
+  licenseID: %s
+  licenseType: %s
+  customerName: %q
+  appSlug: %s
+  channelID: %s
+  channelName: %q
+  isAirgapSupported: %t
+  isGitOpsSupported: %t
+  isSnapshotSupported: %t
+`, slug(customer.Name), customer.ID, customer.Type, customer.Name, state.app.Slug, channel.ID, channel.Name,
+		customer.IsAirgapEnabled, customer.IsGitopsSupported, customer.IsSnapshotSupported)
+	return []byte(license), nil
+}
+
+type installers struct {
+	f     *Fake
+	appID string
+}
+
+var _ sdk.Installers = (*installers)(nil)
+
+func (i *installers) List(ctx context.Context) ([]types.InstallerSpec, error) {
+	i.f.mu.Lock()
+	defer i.f.mu.Unlock()
+
+	state, err := i.f.app(i.appID)
+	if err != nil {
+		return nil, err
+	}
+	result := []types.InstallerSpec{}
+	for _, installer := range state.installers {
+		result = append(result, *installer)
+	}
+	return result, nil
+}
+
+func (i *installers) Create(ctx context.Context, yaml string) (*types.InstallerSpec, error) {
+	i.f.mu.Lock()
+	defer i.f.mu.Unlock()
+
+	state, err := i.f.app(i.appID)
+	if err != nil {
+		return nil, err
+	}
+	installer := &types.InstallerSpec{
+		AppID:     state.app.ID,
+		Sequence:  int64(len(state.installers) + 1),
+		YAML:      yaml,
+		CreatedAt: util.Time{Time: i.f.now()},
+	}
+	state.installers = append(state.installers, installer)
+	result := *installer
+	return &result, nil
+}
+
+func (i *installers) Promote(ctx context.Context, sequence int64, channelID string, versionLabel string) error {
+	i.f.mu.Lock()
+	defer i.f.mu.Unlock()
+
+	state, err := i.f.app(i.appID)
+	if err != nil {
+		return err
+	}
+	channel, err := state.channel(channelID)
+	if err != nil {
+		return err
+	}
+	var promoted *types.InstallerSpec
+	for _, installer := range state.installers {
+		if installer.Sequence == sequence {
+			promoted = installer
+		}
+	}
+	if promoted == nil {
+		return errors.Errorf("installer %d not found", sequence)
+	}
+
+	// a channel has one installer, which replaces the one it had
+	for _, installer := range state.installers {
+		active := []types.Channel{}
+		for _, activeChannel := range installer.ActiveChannels {
+			if activeChannel.ID != channelID {
+				active = append(active, activeChannel)
+			}
+		}
+		installer.ActiveChannels = active
+	}
+	promoted.ActiveChannels = append(promoted.ActiveChannels, *channel)
+	promoted.Immutable = true
+	return nil
+}
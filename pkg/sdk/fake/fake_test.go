@@ -0,0 +1,172 @@
+package fake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/replicatedhq/replicated/pkg/enterprisetypes"
+	"github.com/replicatedhq/replicated/pkg/sdk"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleases(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	f := New()
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	f.SetNow(func() time.Time { return now })
+	added := f.AddApp("My App")
+	req.Equal("my-app", added.Slug)
+
+	app, err := f.Client().Apps.Get(ctx, "my-app")
+	req.NoError(err)
+	req.Equal(added.ID, app.ID)
+
+	stable, err := app.Channels.Create(ctx, "Stable", "")
+	req.NoError(err)
+
+	release, err := app.Releases.Create(ctx, "spec 1")
+	req.NoError(err)
+	req.Equal(int64(1), release.Sequence)
+	req.Equal(now, release.CreatedAt)
+	req.NoError(app.Releases.Update(ctx, 1, "spec 1 edited"))
+
+	_, err = app.Releases.Create(ctx, "spec 2")
+	req.NoError(err)
+
+	err = app.Releases.Promote(ctx, 1, sdk.PromoteOptions{ChannelIDs: []string{"missing"}})
+	req.EqualError(err, "channel missing not found")
+	req.NoError(app.Releases.Promote(ctx, 1, sdk.PromoteOptions{ChannelIDs: []string{stable.ID}, VersionLabel: "1.0.0"}))
+	req.EqualError(app.Releases.Update(ctx, 1, "spec 1 again"), "release 1 has been promoted and cannot be edited")
+
+	spec, ok := f.ReleaseSpec(app.ID, 1)
+	req.True(ok)
+	req.Equal("spec 1 edited", spec)
+
+	releases, err := app.Releases.List(ctx)
+	req.NoError(err)
+	req.Len(releases, 2)
+	req.Equal(int64(2), releases[0].Sequence)
+	req.Empty(releases[0].ActiveChannels)
+	req.Equal("1.0.0", releases[1].Version)
+	req.Equal([]string{"Stable"}, channelNames(releases[1].ActiveChannels))
+
+	channel, err := app.Channels.Find(ctx, "Stable")
+	req.NoError(err)
+	req.Equal(int64(1), channel.ReleaseSequence)
+	req.Equal("1.0.0", channel.ReleaseLabel)
+
+	apps, err := f.Client().Apps.List(ctx)
+	req.NoError(err)
+	req.Len(apps, 1)
+	req.Equal([]string{"Stable"}, channelNames(apps[0].Channels))
+}
+
+func channelNames(channels []types.Channel) []string {
+	names := []string{}
+	for _, channel := range channels {
+		names = append(names, channel.Name)
+	}
+	return names
+}
+
+func TestCustomers(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	f := New()
+	added := f.AddApp("My App")
+	app, err := f.Client().Apps.Get(ctx, added.ID)
+	req.NoError(err)
+	stable, err := app.Channels.Create(ctx, "Stable", "")
+	req.NoError(err)
+
+	_, err = app.Customers.Create(ctx, types.CustomerOptions{Name: "Acme"})
+	req.EqualError(err, "customer channel is required")
+
+	customer, err := app.Customers.Create(ctx, types.CustomerOptions{Name: "Acme", ChannelID: stable.ID, IsAirgapEnabled: true})
+	req.NoError(err)
+	req.Equal("dev", customer.Type)
+	req.True(customer.IsAirgapEnabled)
+
+	customer, err = app.Customers.Update(ctx, customer.ID, types.CustomerOptions{Name: "Acme Inc", ChannelID: stable.ID, Type: "paid"})
+	req.NoError(err)
+	req.Equal("Acme Inc", customer.Name)
+	req.False(customer.IsAirgapEnabled)
+
+	req.NoError(app.Customers.Archive(ctx, customer.ID))
+	customer, err = app.Customers.Get(ctx, customer.ID)
+	req.NoError(err)
+	req.True(customer.IsArchived)
+
+	license, err := app.Customers.DownloadLicense(ctx, customer.ID)
+	req.NoError(err)
+	req.Contains(string(license), `customerName: "Acme Inc"`)
+	req.Contains(string(license), "licenseType: paid")
+}
+
+func TestInstallers(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	f := New()
+	added := f.AddApp("My App")
+	app, err := f.Client().Apps.Get(ctx, added.ID)
+	req.NoError(err)
+	stable, err := app.Channels.Create(ctx, "Stable", "")
+	req.NoError(err)
+
+	for _, yaml := range []string{"kind: Installer", "kind: Installer\n# v2"} {
+		_, err := app.Installers.Create(ctx, yaml)
+		req.NoError(err)
+	}
+	req.NoError(app.Installers.Promote(ctx, 1, stable.ID, ""))
+	req.NoError(app.Installers.Promote(ctx, 2, stable.ID, ""))
+
+	installers, err := app.Installers.List(ctx)
+	req.NoError(err)
+	req.Empty(installers[0].ActiveChannels)
+	req.Equal([]string{"Stable"}, channelNames(installers[1].ActiveChannels))
+}
+
+func TestEnterprise(t *testing.T) {
+	req := require.New(t)
+	ctx := context.Background()
+
+	f := New()
+	enterprise := f.Client().Enterprise
+
+	_, err := enterprise.Whoami(ctx)
+	req.EqualError(err, "no private key is configured")
+
+	f.AddAuthKey("SHA256:one", enterprisetypes.AuthKeyStatusApproved)
+	f.AddAuthKey("SHA256:two", enterprisetypes.AuthKeyStatusPending)
+	req.NoError(enterprise.RevokeAuthKey(ctx, "SHA256:two"))
+	authKeys, err := enterprise.ListAuthKeys(ctx)
+	req.NoError(err)
+	req.Equal(enterprisetypes.AuthKeyStatusRevoked, authKeys[1].Status)
+	identity, err := enterprise.Whoami(ctx)
+	req.NoError(err)
+	req.Equal("SHA256:one", identity.Fingerprint)
+
+	channel, err := enterprise.CreateChannel(ctx, "Enterprise", "")
+	req.NoError(err)
+	policy, err := enterprise.CreatePolicy(ctx, "Policy", "", "package replicated")
+	req.NoError(err)
+	installer, err := enterprise.CreateInstaller(ctx, "kind: Installer")
+	req.NoError(err)
+
+	req.NoError(enterprise.AssignChannel(ctx, channel.ID, "team-1"))
+	req.NoError(enterprise.AssignPolicy(ctx, policy.ID, channel.ID))
+	req.NoError(enterprise.AssignInstaller(ctx, installer.ID, channel.ID))
+	req.Equal([]string{"team-1"}, f.ChannelTeams(channel.ID))
+	req.Equal([]string{policy.ID}, f.ChannelPolicies(channel.ID))
+	req.Equal(installer.ID, f.ChannelInstaller(channel.ID))
+
+	req.NoError(enterprise.RemovePolicy(ctx, policy.ID))
+	req.Empty(f.ChannelPolicies(channel.ID))
+	req.EqualError(enterprise.AssignPolicy(ctx, policy.ID, channel.ID), "policy "+policy.ID+" not found")
+}
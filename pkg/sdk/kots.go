@@ -0,0 +1,125 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
+	"github.com/replicatedhq/replicated/pkg/types"
+)
+
+// the kots backend uses the Vendor API v3
+
+func newKotsApp(api *kotsclient.VendorV3Client, app *types.App) *App {
+	return &App{
+		App:        *app,
+		Type:       "kots",
+		Channels:   &kotsChannels{api: api, app: app},
+		Releases:   &kotsReleases{api: api, app: app},
+		Customers:  &kotsCustomers{api: api, app: app},
+		Installers: &kotsInstallers{api: api, app: app},
+	}
+}
+
+type kotsChannels struct {
+	api *kotsclient.VendorV3Client
+	app *types.App
+}
+
+var _ Channels = (*kotsChannels)(nil)
+
+func (c *kotsChannels) List(ctx context.Context) ([]types.Channel, error) {
+	return c.api.ListChannelsContext(ctx, c.app.ID, c.app.Slug, "")
+}
+
+func (c *kotsChannels) Find(ctx context.Context, nameOrID string) (*types.Channel, error) {
+	channels, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return FindChannel(channels, nameOrID)
+}
+
+func (c *kotsChannels) Create(ctx context.Context, name string, description string) (*types.Channel, error) {
+	return c.api.CreateChannelContext(ctx, c.app.ID, name, description)
+}
+
+func (c *kotsChannels) Archive(ctx context.Context, channelID string) error {
+	return c.api.ArchiveChannelContext(ctx, c.app.ID, channelID)
+}
+
+type kotsReleases struct {
+	api *kotsclient.VendorV3Client
+	app *types.App
+}
+
+var _ Releases = (*kotsReleases)(nil)
+
+func (r *kotsReleases) List(ctx context.Context) ([]types.ReleaseInfo, error) {
+	return r.api.ListReleasesContext(ctx, r.app.ID)
+}
+
+func (r *kotsReleases) Create(ctx context.Context, spec string) (*types.ReleaseInfo, error) {
+	return r.api.CreateReleaseContext(ctx, r.app.ID, spec)
+}
+
+func (r *kotsReleases) Update(ctx context.Context, sequence int64, spec string) error {
+	return r.api.UpdateReleaseContext(ctx, r.app.ID, sequence, spec)
+}
+
+func (r *kotsReleases) Promote(ctx context.Context, sequence int64, opts PromoteOptions) error {
+	return r.api.PromoteReleaseContext(ctx, r.app.ID, opts.VersionLabel, opts.ReleaseNotes, sequence, opts.ChannelIDs...)
+}
+
+type kotsCustomers struct {
+	api *kotsclient.VendorV3Client
+	app *types.App
+}
+
+var _ Customers = (*kotsCustomers)(nil)
+
+func (c *kotsCustomers) List(ctx context.Context) ([]types.Customer, error) {
+	return c.api.ListCustomersContext(ctx, c.app.ID)
+}
+
+func (c *kotsCustomers) Get(ctx context.Context, customerID string) (*types.Customer, error) {
+	return c.api.GetCustomerContext(ctx, c.app.ID, customerID)
+}
+
+func (c *kotsCustomers) Create(ctx context.Context, opts types.CustomerOptions) (*types.Customer, error) {
+	return c.api.CreateCustomerWithOptionsContext(ctx, c.app.ID, opts)
+}
+
+func (c *kotsCustomers) Update(ctx context.Context, customerID string, opts types.CustomerOptions) (*types.Customer, error) {
+	return c.api.UpdateCustomerContext(ctx, c.app.ID, customerID, opts)
+}
+
+func (c *kotsCustomers) Archive(ctx context.Context, customerID string) error {
+	return c.api.ArchiveCustomerContext(ctx, customerID)
+}
+
+func (c *kotsCustomers) Unarchive(ctx context.Context, customerID string) error {
+	return c.api.UnarchiveCustomerContext(ctx, customerID)
+}
+
+func (c *kotsCustomers) DownloadLicense(ctx context.Context, customerID string) ([]byte, error) {
+	return c.api.DownloadLicenseContext(ctx, c.app.ID, customerID)
+}
+
+type kotsInstallers struct {
+	api *kotsclient.VendorV3Client
+	app *types.App
+}
+
+var _ Installers = (*kotsInstallers)(nil)
+
+func (i *kotsInstallers) List(ctx context.Context) ([]types.InstallerSpec, error) {
+	return i.api.ListInstallersContext(ctx, i.app.ID)
+}
+
+func (i *kotsInstallers) Create(ctx context.Context, yaml string) (*types.InstallerSpec, error) {
+	return i.api.CreateInstallerContext(ctx, i.app.ID, yaml)
+}
+
+func (i *kotsInstallers) Promote(ctx context.Context, sequence int64, channelID string, versionLabel string) error {
+	return i.api.PromoteInstallerContext(ctx, i.app.ID, sequence, channelID, versionLabel)
+}
@@ -0,0 +1,139 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/replicatedhq/replicated/client"
+	"github.com/replicatedhq/replicated/pkg/types"
+)
+
+// the legacy backend serves platform and ship apps through client.Client,
+// which returns an error for anything those apps do not support
+
+func newLegacyApp(api *client.Client, app *types.App, appType string) *App {
+	legacy := &legacyApp{api: api, app: app, appType: appType}
+	return &App{
+		App:        *app,
+		Type:       appType,
+		Channels:   &legacyChannels{legacy},
+		Releases:   &legacyReleases{legacy},
+		Customers:  &legacyCustomers{legacy},
+		Installers: &legacyInstallers{legacy},
+	}
+}
+
+type legacyApp struct {
+	api     *client.Client
+	app     *types.App
+	appType string
+}
+
+type legacyChannels struct {
+	*legacyApp
+}
+
+var _ Channels = (*legacyChannels)(nil)
+
+func (c *legacyChannels) List(ctx context.Context) ([]types.Channel, error) {
+	return c.api.ListChannelsContext(ctx, c.app.ID, c.appType, c.app.Slug, "")
+}
+
+func (c *legacyChannels) Find(ctx context.Context, nameOrID string) (*types.Channel, error) {
+	channels, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return FindChannel(channels, nameOrID)
+}
+
+func (c *legacyChannels) Create(ctx context.Context, name string, description string) (*types.Channel, error) {
+	channels, err := c.api.CreateChannelContext(ctx, c.app.ID, c.appType, c.app.Slug, name, description)
+	if err != nil {
+		return nil, err
+	}
+	// the API returns every channel, the new one is the last with its name
+	for i := len(channels) - 1; i >= 0; i-- {
+		if channels[i].Name == name {
+			return channels[i].Copy(), nil
+		}
+	}
+	return FindChannel(channels, name)
+}
+
+func (c *legacyChannels) Archive(ctx context.Context, channelID string) error {
+	return c.api.ArchiveChannelContext(ctx, c.app.ID, c.appType, channelID)
+}
+
+type legacyReleases struct {
+	*legacyApp
+}
+
+var _ Releases = (*legacyReleases)(nil)
+
+func (r *legacyReleases) List(ctx context.Context) ([]types.ReleaseInfo, error) {
+	return r.api.ListReleasesContext(ctx, r.app.ID, r.appType)
+}
+
+func (r *legacyReleases) Create(ctx context.Context, spec string) (*types.ReleaseInfo, error) {
+	return r.api.CreateReleaseContext(ctx, r.app.ID, r.appType, spec)
+}
+
+func (r *legacyReleases) Update(ctx context.Context, sequence int64, spec string) error {
+	return r.api.UpdateReleaseContext(ctx, r.app.ID, r.appType, sequence, spec)
+}
+
+func (r *legacyReleases) Promote(ctx context.Context, sequence int64, opts PromoteOptions) error {
+	return r.api.PromoteReleaseContext(ctx, r.app.ID, r.appType, sequence, opts.VersionLabel, opts.ReleaseNotes, opts.Required, opts.ChannelIDs...)
+}
+
+type legacyCustomers struct {
+	*legacyApp
+}
+
+var _ Customers = (*legacyCustomers)(nil)
+
+func (c *legacyCustomers) List(ctx context.Context) ([]types.Customer, error) {
+	return c.api.ListCustomersContext(ctx, c.app.ID, c.appType)
+}
+
+func (c *legacyCustomers) Get(ctx context.Context, customerID string) (*types.Customer, error) {
+	return c.api.GetCustomerContext(ctx, c.appType, c.app.ID, customerID)
+}
+
+func (c *legacyCustomers) Create(ctx context.Context, opts types.CustomerOptions) (*types.Customer, error) {
+	return c.api.CreateCustomerWithOptionsContext(ctx, c.app.ID, c.appType, opts)
+}
+
+func (c *legacyCustomers) Update(ctx context.Context, customerID string, opts types.CustomerOptions) (*types.Customer, error) {
+	return c.api.UpdateCustomerContext(ctx, c.app.ID, c.appType, customerID, opts)
+}
+
+func (c *legacyCustomers) Archive(ctx context.Context, customerID string) error {
+	return c.api.ArchiveCustomerContext(ctx, c.appType, customerID)
+}
+
+func (c *legacyCustomers) Unarchive(ctx context.Context, customerID string) error {
+	return c.api.UnarchiveCustomerContext(ctx, c.appType, customerID)
+}
+
+func (c *legacyCustomers) DownloadLicense(ctx context.Context, customerID string) ([]byte, error) {
+	return c.api.DownloadLicenseContext(ctx, c.appType, c.app.ID, customerID)
+}
+
+type legacyInstallers struct {
+	*legacyApp
+}
+
+var _ Installers = (*legacyInstallers)(nil)
+
+func (i *legacyInstallers) List(ctx context.Context) ([]types.InstallerSpec, error) {
+	return i.api.ListInstallersContext(ctx, i.app.ID, i.appType)
+}
+
+func (i *legacyInstallers) Create(ctx context.Context, yaml string) (*types.InstallerSpec, error) {
+	return i.api.CreateInstallerContext(ctx, i.app.ID, i.appType, yaml)
+}
+
+func (i *legacyInstallers) Promote(ctx context.Context, sequence int64, channelID string, versionLabel string) error {
+	return i.api.PromoteInstallerContext(ctx, i.app.ID, i.appType, sequence, channelID, versionLabel)
+}
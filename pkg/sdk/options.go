@@ -0,0 +1,114 @@
+package sdk
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/client"
+	"github.com/replicatedhq/replicated/pkg/enterpriseclient"
+	"github.com/replicatedhq/replicated/pkg/transport"
+)
+
+const (
+	DefaultAPIOrigin        = "https://api.replicated.com/vendor"
+	DefaultGraphQLOrigin    = "https://g.replicated.com/graphql"
+	DefaultEnterpriseOrigin = "https://api.replicated.com/enterprise"
+)
+
+// An Option configures a Client created with New
+type Option func(*options)
+
+type options struct {
+	apiToken         string
+	apiOrigin        string
+	graphqlOrigin    string
+	enterpriseOrigin string
+	httpClient       *http.Client
+	signer           enterpriseclient.Signer
+}
+
+// WithAPIToken authenticates Vendor API requests with a user or service
+// account token
+func WithAPIToken(token string) Option {
+	return func(o *options) {
+		o.apiToken = token
+	}
+}
+
+// WithEnterpriseSigner signs Enterprise API requests, see
+// enterpriseclient.NewSigner
+func WithEnterpriseSigner(signer enterpriseclient.Signer) Option {
+	return func(o *options) {
+		o.signer = signer
+	}
+}
+
+// WithAPIOrigin sets the Vendor API origin, DefaultAPIOrigin by default
+func WithAPIOrigin(origin string) Option {
+	return func(o *options) {
+		o.apiOrigin = origin
+	}
+}
+
+// WithGraphQLOrigin sets the GraphQL API origin used by ship apps,
+// DefaultGraphQLOrigin by default
+func WithGraphQLOrigin(origin string) Option {
+	return func(o *options) {
+		o.graphqlOrigin = origin
+	}
+}
+
+// WithEnterpriseOrigin sets the Enterprise API origin,
+// DefaultEnterpriseOrigin by default
+func WithEnterpriseOrigin(origin string) Option {
+	return func(o *options) {
+		o.enterpriseOrigin = origin
+	}
+}
+
+// WithHTTPClient sends requests with httpClient, which replaces the retries
+// the sdk makes by default
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithRetries sets the per-attempt timeout and the maximum number of retries
+// of requests that are safe to repeat. A timeout of 0 disables the timeout.
+func WithRetries(timeout time.Duration, maxRetries int) Option {
+	return func(o *options) {
+		o.httpClient = transport.NewClient(timeout, maxRetries)
+	}
+}
+
+// New returns a client for the Replicated APIs. It needs an API token, an
+// enterprise signer, or both.
+func New(opts ...Option) (*Client, error) {
+	o := options{
+		apiOrigin:        DefaultAPIOrigin,
+		graphqlOrigin:    DefaultGraphQLOrigin,
+		enterpriseOrigin: DefaultEnterpriseOrigin,
+		httpClient:       transport.NewClient(transport.DefaultTimeout, transport.DefaultMaxRetries),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.apiToken == "" && o.signer == nil {
+		return nil, errors.New("an API token or an enterprise signer is required, use WithAPIToken or WithEnterpriseSigner")
+	}
+
+	api := client.NewClient(o.apiOrigin, o.graphqlOrigin, o.apiToken, "")
+	api.PlatformClient.SetHTTPClient(o.httpClient)
+	api.KotsClient.SetHTTPClient(o.httpClient)
+	api.ShipClient.GraphQLClient.HTTPClient = o.httpClient
+
+	enterpriseClient := enterpriseclient.NewHTTPClientWithSigner(o.enterpriseOrigin, o.signer)
+	enterpriseClient.SetHTTPClient(o.httpClient)
+
+	return &Client{
+		Apps:       &apps{api: &api},
+		Enterprise: &enterprise{client: enterpriseClient},
+	}, nil
+}
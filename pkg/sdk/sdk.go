@@ -0,0 +1,137 @@
+// Package sdk is a Go client for the Replicated Vendor and Enterprise APIs,
+// for programs that embed this module rather than run the CLI.
+//
+// Each resource is an interface, so code using the sdk can be tested against
+// the in-memory implementation in the fake package. Apps are looked up once
+// with Apps.Get, which returns clients bound to the app for the API it is
+// managed with:
+//
+//	client, err := sdk.New(sdk.WithAPIToken(os.Getenv("REPLICATED_API_TOKEN")))
+//	app, err := client.Apps.Get(ctx, "my-app")
+//	release, err := app.Releases.Create(ctx, spec)
+//
+// The package follows Version. Changes to the interfaces, including new
+// methods, which break other implementations, only come with a new major
+// version.
+package sdk
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/enterprisetypes"
+	"github.com/replicatedhq/replicated/pkg/types"
+)
+
+// Version is the version of the sdk interfaces
+const Version = "1.0.0"
+
+// Client is the entry point to the APIs
+type Client struct {
+	Apps Apps
+	// Enterprise requires a signer, see WithEnterpriseSigner
+	Enterprise Enterprise
+}
+
+type Apps interface {
+	List(ctx context.Context) ([]types.AppAndChannels, error)
+	// Get finds an app by ID or slug
+	Get(ctx context.Context, appIDOrSlug string) (*App, error)
+}
+
+// App is an app with clients bound to it
+type App struct {
+	types.App
+	// Type is the API the app is managed with: kots, platform or ship
+	Type string
+
+	Channels   Channels
+	Releases   Releases
+	Customers  Customers
+	Installers Installers
+}
+
+type Channels interface {
+	List(ctx context.Context) ([]types.Channel, error)
+	// Find returns the channel with the ID or name, which must be unique
+	Find(ctx context.Context, nameOrID string) (*types.Channel, error)
+	Create(ctx context.Context, name string, description string) (*types.Channel, error)
+	Archive(ctx context.Context, channelID string) error
+}
+
+type Releases interface {
+	List(ctx context.Context) ([]types.ReleaseInfo, error)
+	// Create creates a release from a spec in the format release create
+	// sends: a JSON list of files for KOTS apps, YAML for other apps
+	Create(ctx context.Context, spec string) (*types.ReleaseInfo, error)
+	Update(ctx context.Context, sequence int64, spec string) error
+	Promote(ctx context.Context, sequence int64, opts PromoteOptions) error
+}
+
+type PromoteOptions struct {
+	ChannelIDs   []string
+	VersionLabel string
+	ReleaseNotes string
+	// Required is only supported by platform apps
+	Required bool
+}
+
+type Customers interface {
+	List(ctx context.Context) ([]types.Customer, error)
+	Get(ctx context.Context, customerID string) (*types.Customer, error)
+	Create(ctx context.Context, opts types.CustomerOptions) (*types.Customer, error)
+	Update(ctx context.Context, customerID string, opts types.CustomerOptions) (*types.Customer, error)
+	Archive(ctx context.Context, customerID string) error
+	Unarchive(ctx context.Context, customerID string) error
+	DownloadLicense(ctx context.Context, customerID string) ([]byte, error)
+}
+
+type Installers interface {
+	List(ctx context.Context) ([]types.InstallerSpec, error)
+	Create(ctx context.Context, yaml string) (*types.InstallerSpec, error)
+	Promote(ctx context.Context, sequence int64, channelID string, versionLabel string) error
+}
+
+type Enterprise interface {
+	ListChannels(ctx context.Context) ([]*enterprisetypes.Channel, error)
+	CreateChannel(ctx context.Context, name string, description string) (*enterprisetypes.Channel, error)
+	UpdateChannel(ctx context.Context, id string, name string, description string) (*enterprisetypes.Channel, error)
+	RemoveChannel(ctx context.Context, id string) error
+	AssignChannel(ctx context.Context, channelID string, teamID string) error
+
+	ListPolicies(ctx context.Context) ([]*enterprisetypes.Policy, error)
+	CreatePolicy(ctx context.Context, name string, description string, policy string) (*enterprisetypes.Policy, error)
+	UpdatePolicy(ctx context.Context, id string, name string, description string, policy string) (*enterprisetypes.Policy, error)
+	RemovePolicy(ctx context.Context, id string) error
+	AssignPolicy(ctx context.Context, policyID string, channelID string) error
+	UnassignPolicy(ctx context.Context, policyID string, channelID string) error
+
+	ListInstallers(ctx context.Context) ([]*enterprisetypes.Installer, error)
+	CreateInstaller(ctx context.Context, yaml string) (*enterprisetypes.Installer, error)
+	UpdateInstaller(ctx context.Context, id string, yaml string) (*enterprisetypes.Installer, error)
+	RemoveInstaller(ctx context.Context, id string) error
+	AssignInstaller(ctx context.Context, installerID string, channelID string) error
+
+	ListAuthKeys(ctx context.Context) ([]*enterprisetypes.AuthKey, error)
+	RevokeAuthKey(ctx context.Context, fingerprint string) error
+	Whoami(ctx context.Context) (*enterprisetypes.AuthIdentity, error)
+}
+
+// FindChannel returns the channel in channels with the ID or name, for
+// implementations of Channels.Find
+func FindChannel(channels []types.Channel, nameOrID string) (*types.Channel, error) {
+	var found *types.Channel
+	for _, channel := range channels {
+		if channel.ID != nameOrID && channel.Name != nameOrID {
+			continue
+		}
+		if found != nil {
+			return nil, errors.Errorf("channel %q is ambiguous, please use channel ID", nameOrID)
+		}
+		found = channel.Copy()
+	}
+	if found == nil {
+		return nil, errors.Errorf("channel %q not found", nameOrID)
+	}
+	return found, nil
+}
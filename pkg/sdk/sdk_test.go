@@ -0,0 +1,119 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New()
+	require.EqualError(t, err, "an API token or an enterprise signer is required, use WithAPIToken or WithEnterpriseSigner")
+
+	client, err := New(WithAPIToken("token"))
+	require.NoError(t, err)
+	require.NotNil(t, client.Apps)
+	require.NotNil(t, client.Enterprise)
+}
+
+func TestKotsApp(t *testing.T) {
+	req := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("token", r.Header.Get("Authorization"))
+		req.Equal("sdk-test", r.Header.Get("X-Test"))
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/apps":
+			w.Write([]byte(`[]`))
+		case "GET /v3/apps":
+			w.Write([]byte(`{"apps":[{"id":"app-1","name":"My App","slug":"my-app"}]}`))
+		case "GET /v3/app/app-1/channels":
+			w.Write([]byte(`{"channels":[{"id":"channel-1","name":"Stable","channelSlug":"stable"},{"id":"channel-2","name":"Beta","channelSlug":"beta"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(
+		WithAPIToken("token"),
+		WithAPIOrigin(server.URL),
+		WithGraphQLOrigin(server.URL+"/graphql"),
+		WithHTTPClient(&http.Client{Transport: headerTransport{"X-Test", "sdk-test"}}),
+	)
+	req.NoError(err)
+
+	app, err := client.Apps.Get(context.Background(), "my-app")
+	req.NoError(err)
+	req.Equal("kots", app.Type)
+	req.Equal("app-1", app.ID)
+	req.IsType(&kotsChannels{}, app.Channels)
+
+	channel, err := app.Channels.Find(context.Background(), "Beta")
+	req.NoError(err)
+	req.Equal("channel-2", channel.ID)
+}
+
+type headerTransport struct {
+	name  string
+	value string
+}
+
+func (t headerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set(t.name, t.value)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestFindChannel(t *testing.T) {
+	channels := []types.Channel{
+		{ID: "1", Name: "Stable"},
+		{ID: "2", Name: "Beta"},
+		{ID: "3", Name: "Beta"},
+	}
+
+	tests := []struct {
+		name     string
+		nameOrID string
+		wantID   string
+		wantErr  string
+	}{
+		{
+			name:     "by id",
+			nameOrID: "2",
+			wantID:   "2",
+		},
+		{
+			name:     "by name",
+			nameOrID: "Stable",
+			wantID:   "1",
+		},
+		{
+			name:     "ambiguous",
+			nameOrID: "Beta",
+			wantErr:  `channel "Beta" is ambiguous, please use channel ID`,
+		},
+		{
+			name:     "missing",
+			nameOrID: "Unstable",
+			wantErr:  `channel "Unstable" not found`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			channel, err := FindChannel(channels, test.nameOrID)
+			if test.wantErr != "" {
+				require.EqualError(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.wantID, channel.ID)
+		})
+	}
+}
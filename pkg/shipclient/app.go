@@ -1,6 +1,8 @@
 package shipclient
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 	"github.com/replicatedhq/replicated/pkg/graphql"
 	"github.com/replicatedhq/replicated/pkg/types"
@@ -48,6 +50,10 @@ query {
 }`
 
 func (c *GraphQLClient) ListApps() ([]types.AppAndChannels, error) {
+	return c.ListAppsContext(context.Background())
+}
+
+func (c *GraphQLClient) ListAppsContext(ctx context.Context) ([]types.AppAndChannels, error) {
 	response := GraphQLResponseListApps{}
 
 	request := graphql.Request{
@@ -56,7 +62,7 @@ func (c *GraphQLClient) ListApps() ([]types.AppAndChannels, error) {
 		Variables: map[string]interface{}{},
 	}
 
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return nil, err
 	}
 
@@ -74,9 +80,9 @@ func (c *GraphQLClient) ListApps() ([]types.AppAndChannels, error) {
 
 		appAndChannels := types.AppAndChannels{
 			App: &types.App{
-				ID:   app.ID,
-				Name: app.Name,
-				Slug: app.Slug,
+				ID:        app.ID,
+				Name:      app.Name,
+				Slug:      app.Slug,
 				Scheduler: "ship",
 			},
 			Channels: channels,
@@ -89,7 +95,11 @@ func (c *GraphQLClient) ListApps() ([]types.AppAndChannels, error) {
 }
 
 func (c *GraphQLClient) GetApp(appID string) (*types.App, error) {
-	apps, err := c.ListApps()
+	return c.GetAppContext(context.Background(), appID)
+}
+
+func (c *GraphQLClient) GetAppContext(ctx context.Context, appID string) (*types.App, error) {
+	apps, err := c.ListAppsContext(ctx)
 	if err != nil {
 		return nil, err
 	}
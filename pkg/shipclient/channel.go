@@ -1,6 +1,7 @@
 package shipclient
 
 import (
+	"context"
 	channels "github.com/replicatedhq/replicated/gen/go/v1"
 	"github.com/replicatedhq/replicated/pkg/graphql"
 	"github.com/replicatedhq/replicated/pkg/types"
@@ -62,6 +63,10 @@ query getAppChannels($appId: ID!) {
 }`
 
 func (c *GraphQLClient) ListChannels(appID string) ([]types.Channel, error) {
+	return c.ListChannelsContext(context.Background(), appID)
+}
+
+func (c *GraphQLClient) ListChannelsContext(ctx context.Context, appID string) ([]types.Channel, error) {
 	response := GraphQLResponseListChannels{}
 
 	request := graphql.Request{
@@ -72,7 +77,7 @@ func (c *GraphQLClient) ListChannels(appID string) ([]types.Channel, error) {
 		},
 	}
 
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return nil, err
 	}
 
@@ -110,6 +115,10 @@ mutation createChannel($appId: String!, $channelName: String!, $description: Str
 }`
 
 func (c *GraphQLClient) CreateChannel(appID string, name string, description string) (*types.Channel, error) {
+	return c.CreateChannelContext(context.Background(), appID, name, description)
+}
+
+func (c *GraphQLClient) CreateChannelContext(ctx context.Context, appID string, name string, description string) (*types.Channel, error) {
 	response := GraphQLResponseCreateChannel{}
 
 	request := graphql.Request{
@@ -121,7 +130,7 @@ func (c *GraphQLClient) CreateChannel(appID string, name string, description str
 		},
 	}
 
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return nil, err
 	}
 	return &types.Channel{
@@ -156,6 +165,10 @@ var getShipChannel = `
 `
 
 func (c *GraphQLClient) GetChannel(appID string, channelID string) (*channels.AppChannel, []channels.ChannelRelease, error) {
+	return c.GetChannelContext(context.Background(), appID, channelID)
+}
+
+func (c *GraphQLClient) GetChannelContext(ctx context.Context, appID string, channelID string) (*channels.AppChannel, []channels.ChannelRelease, error) {
 	response := GraphQLResponseGetChannel{}
 
 	request := graphql.Request{
@@ -165,7 +178,7 @@ func (c *GraphQLClient) GetChannel(appID string, channelID string) (*channels.Ap
 			"channelId": channelID,
 		},
 	}
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return nil, nil, err
 	}
 
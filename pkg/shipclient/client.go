@@ -1,6 +1,8 @@
 package shipclient
 
 import (
+	"context"
+
 	"github.com/replicatedhq/replicated/pkg/graphql"
 )
 
@@ -25,5 +27,9 @@ func NewGraphQLClient(origin string, apiKey string) *GraphQLClient {
 }
 
 func (c *GraphQLClient) ExecuteRequest(requestObj graphql.Request, deserializeTarget interface{}) error {
-	return c.GraphQLClient.ExecuteRequest(requestObj, deserializeTarget)
+	return c.ExecuteRequestContext(context.Background(), requestObj, deserializeTarget)
+}
+
+func (c *GraphQLClient) ExecuteRequestContext(ctx context.Context, requestObj graphql.Request, deserializeTarget interface{}) error {
+	return c.GraphQLClient.ExecuteRequestContext(ctx, requestObj, deserializeTarget)
 }
@@ -1,6 +1,7 @@
 package shipclient
 
 import (
+	"context"
 	"time"
 
 	v1 "github.com/replicatedhq/replicated/gen/go/v1"
@@ -102,6 +103,10 @@ type PlatformChannel struct {
 }
 
 func (c *GraphQLClient) ListCollectors(appID string, appType string) ([]types.CollectorInfo, error) {
+	return c.ListCollectorsContext(context.Background(), appID, appType)
+}
+
+func (c *GraphQLClient) ListCollectorsContext(ctx context.Context, appID string, appType string) ([]types.CollectorInfo, error) {
 
 	if appType == "ship" {
 
@@ -139,7 +144,7 @@ func (c *GraphQLClient) ListCollectors(appID string, appType string) ([]types.Co
 			},
 		}
 
-		if err := c.ExecuteRequest(request, &response); err != nil {
+		if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 			return nil, err
 		}
 
@@ -203,7 +208,7 @@ query supportBundleSpecs($appId: String) {
 			},
 		}
 
-		if err := c.ExecuteRequest(request, &response); err != nil {
+		if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 			return nil, err
 		}
 
@@ -236,6 +241,10 @@ query supportBundleSpecs($appId: String) {
 
 // GetCollector returns a collector's properties.
 func (c *GraphQLClient) GetCollector(appID string, id string) (*v1.AppCollectorInfo, error) {
+	return c.GetCollectorContext(context.Background(), appID, id)
+}
+
+func (c *GraphQLClient) GetCollectorContext(ctx context.Context, appID string, id string) (*v1.AppCollectorInfo, error) {
 	response := GraphQLResponseGetCollector{}
 
 	request := graphql.Request{
@@ -264,7 +273,7 @@ query supportBundleSpec($id: String!) {
 		},
 	}
 
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return nil, err
 	}
 
@@ -280,6 +289,10 @@ query supportBundleSpec($id: String!) {
 
 // PromoteCollector assigns collector to a specified channel.
 func (c *GraphQLClient) PromoteCollector(appID string, specID string, channelIDs ...string) error {
+	return c.PromoteCollectorContext(context.Background(), appID, specID, channelIDs...)
+}
+
+func (c *GraphQLClient) PromoteCollectorContext(ctx context.Context, appID string, specID string, channelIDs ...string) error {
 	response := graphql.ResponseErrorOnly{}
 
 	request := graphql.Request{
@@ -295,7 +308,7 @@ mutation  promoteTroubleshootSpec($channelIds: [String], $specId: ID!) {
 		},
 	}
 
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return err
 	}
 
@@ -305,6 +318,10 @@ mutation  promoteTroubleshootSpec($channelIds: [String], $specId: ID!) {
 
 // CreateCollector creates a new collector based on given yaml and name
 func (c *GraphQLClient) CreateCollector(appID string, name string, yaml string) (*v1.AppCollectorInfo, error) {
+	return c.CreateCollectorContext(context.Background(), appID, name, yaml)
+}
+
+func (c *GraphQLClient) CreateCollectorContext(ctx context.Context, appID string, name string, yaml string) (*v1.AppCollectorInfo, error) {
 	response := GraphQLResponseCreateCollector{}
 
 	request := graphql.Request{
@@ -332,7 +349,7 @@ func (c *GraphQLClient) CreateCollector(appID string, name string, yaml string)
 		},
 	}
 
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return nil, err
 	}
 
@@ -361,7 +378,7 @@ func (c *GraphQLClient) CreateCollector(appID string, name string, yaml string)
 	}
 
 	finalizeSpecCreate := GraphQLResponseUpdateCollector{}
-	if err := c.ExecuteRequest(request, &finalizeSpecCreate); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &finalizeSpecCreate); err != nil {
 		return nil, err
 	}
 
@@ -377,6 +394,10 @@ func (c *GraphQLClient) CreateCollector(appID string, name string, yaml string)
 }
 
 func (c *GraphQLClient) UpdateCollector(appID string, specID, yaml string) (interface{}, error) {
+	return c.UpdateCollectorContext(context.Background(), appID, specID, yaml)
+}
+
+func (c *GraphQLClient) UpdateCollectorContext(ctx context.Context, appID string, specID, yaml string) (interface{}, error) {
 	response := GraphQLResponseUpdateCollector{}
 
 	request := graphql.Request{
@@ -398,7 +419,7 @@ mutation updateSupportBundleSpec($id: ID!, $spec: String!, $isArchived: Boolean)
 		},
 	}
 
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return nil, err
 	}
 
@@ -406,6 +427,10 @@ mutation updateSupportBundleSpec($id: ID!, $spec: String!, $isArchived: Boolean)
 }
 
 func (c *GraphQLClient) UpdateCollectorName(appID string, specID, name string) (interface{}, error) {
+	return c.UpdateCollectorNameContext(context.Background(), appID, specID, name)
+}
+
+func (c *GraphQLClient) UpdateCollectorNameContext(ctx context.Context, appID string, specID, name string) (interface{}, error) {
 	response := GraphQLResponseUpdateNameCollector{}
 
 	request := graphql.Request{
@@ -425,7 +450,7 @@ mutation updateSupportBundleSpecName($id: ID!, $name: String!) {
 		},
 	}
 
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,8 @@
 package shipclient
 
 import (
+	"context"
+
 	"github.com/replicatedhq/replicated/pkg/graphql"
 	"github.com/replicatedhq/replicated/pkg/types"
 )
@@ -39,6 +41,10 @@ mutation createEntitlementSpec($spec: String!, $name: String!, $appId: String!)
 }`
 
 func (c *GraphQLClient) CreateEntitlementSpec(appID string, name string, spec string) (*types.EntitlementSpec, error) {
+	return c.CreateEntitlementSpecContext(context.Background(), appID, name, spec)
+}
+
+func (c *GraphQLClient) CreateEntitlementSpecContext(ctx context.Context, appID string, name string, spec string) (*types.EntitlementSpec, error) {
 	response := GraphQLResponseCreateEntitlementSpec{}
 	request := graphql.Request{
 		Query: createEntitlementSpecQuery,
@@ -49,7 +55,7 @@ func (c *GraphQLClient) CreateEntitlementSpec(appID string, name string, spec st
 		},
 	}
 
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return nil, err
 	}
 
@@ -62,6 +68,10 @@ mutation setDefaultEntitlementSpec($specId: ID!) {
 }`
 
 func (c *GraphQLClient) SetDefaultEntitlementSpec(specID string) error {
+	return c.SetDefaultEntitlementSpecContext(context.Background(), specID)
+}
+
+func (c *GraphQLClient) SetDefaultEntitlementSpecContext(ctx context.Context, specID string) error {
 	response := GraphQLResponseSetDefault{}
 	request := graphql.Request{
 		Query: setDefaultEntitlementSpecQuery,
@@ -70,7 +80,7 @@ func (c *GraphQLClient) SetDefaultEntitlementSpec(specID string) error {
 		},
 	}
 
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return err
 	}
 
@@ -91,6 +101,10 @@ mutation($customerId: String!, $specId: String!, $key: String!, $value: String!,
 }`
 
 func (c *GraphQLClient) SetEntitlementValue(customerID string, specID string, key string, value string, datatype string, appId string) (*types.EntitlementValue, error) {
+	return c.SetEntitlementValueContext(context.Background(), customerID, specID, key, value, datatype, appId)
+}
+
+func (c *GraphQLClient) SetEntitlementValueContext(ctx context.Context, customerID string, specID string, key string, value string, datatype string, appId string) (*types.EntitlementValue, error) {
 	response := GraphQLResponseCreateEntitlementValue{}
 	request := graphql.Request{
 		Query: setEntitlementValueQuery,
@@ -103,7 +117,7 @@ func (c *GraphQLClient) SetEntitlementValue(customerID string, specID string, ke
 			"appId":      appId,
 		},
 	}
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,7 @@
 package shipclient
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -80,6 +81,10 @@ query allReleases($appId: ID!) {
 }`
 
 func (c *GraphQLClient) ListReleases(appID string) ([]types.ReleaseInfo, error) {
+	return c.ListReleasesContext(context.Background(), appID)
+}
+
+func (c *GraphQLClient) ListReleasesContext(ctx context.Context, appID string) ([]types.ReleaseInfo, error) {
 	response := GraphQLResponseListReleases{}
 
 	request := graphql.Request{
@@ -89,7 +94,7 @@ func (c *GraphQLClient) ListReleases(appID string) ([]types.ReleaseInfo, error)
 		},
 	}
 
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return nil, err
 	}
 
@@ -150,6 +155,10 @@ mutation finalizeUploadedRelease($appId: ID! $uploadId: String) {
 }`
 
 func (c *GraphQLClient) CreateRelease(appID string, yaml string) (*types.ReleaseInfo, error) {
+	return c.CreateReleaseContext(context.Background(), appID, yaml)
+}
+
+func (c *GraphQLClient) CreateReleaseContext(ctx context.Context, appID string, yaml string) (*types.ReleaseInfo, error) {
 	response := GraphQLResponseUploadRelease{}
 
 	request := graphql.Request{
@@ -159,7 +168,7 @@ func (c *GraphQLClient) CreateRelease(appID string, yaml string) (*types.Release
 		},
 	}
 
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return nil, err
 	}
 
@@ -174,7 +183,7 @@ func (c *GraphQLClient) CreateRelease(appID string, yaml string) (*types.Release
 	}
 	tmpFile.Close()
 
-	if err := util.UploadFile(tmpFile.Name(), response.Data.ShipPendingReleaseData.UploadURI); err != nil {
+	if err := util.UploadFileContext(ctx, tmpFile.Name(), response.Data.ShipPendingReleaseData.UploadURI); err != nil {
 		return nil, err
 	}
 
@@ -189,7 +198,7 @@ func (c *GraphQLClient) CreateRelease(appID string, yaml string) (*types.Release
 	// call finalize release
 	finalizeResponse := GraphQLResponseFinalizeRelease{}
 
-	if err := c.ExecuteRequest(request, &finalizeResponse); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &finalizeResponse); err != nil {
 		return nil, err
 	}
 
@@ -227,6 +236,10 @@ mutation updateRelease($appId: ID!, $spec: String!, $sequence: Int) {
   }`
 
 func (c *GraphQLClient) UpdateRelease(appID string, sequence int64, yaml string) error {
+	return c.UpdateReleaseContext(context.Background(), appID, sequence, yaml)
+}
+
+func (c *GraphQLClient) UpdateReleaseContext(ctx context.Context, appID string, sequence int64, yaml string) error {
 	response := graphql.ResponseErrorOnly{}
 
 	request := graphql.Request{
@@ -238,7 +251,7 @@ func (c *GraphQLClient) UpdateRelease(appID string, sequence int64, yaml string)
 		},
 	}
 
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return err
 	}
 
@@ -254,6 +267,10 @@ mutation promoteShipRelease($appId: ID!, $sequence: Int, $channelIds: [String],
 }`
 
 func (c *GraphQLClient) PromoteRelease(appID string, sequence int64, label string, notes string, channelIDs ...string) error {
+	return c.PromoteReleaseContext(context.Background(), appID, sequence, label, notes, channelIDs...)
+}
+
+func (c *GraphQLClient) PromoteReleaseContext(ctx context.Context, appID string, sequence int64, label string, notes string, channelIDs ...string) error {
 	response := graphql.ResponseErrorOnly{}
 
 	request := graphql.Request{
@@ -268,7 +285,7 @@ func (c *GraphQLClient) PromoteRelease(appID string, sequence int64, label strin
 		},
 	}
 
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return err
 	}
 
@@ -301,6 +318,10 @@ mutation lintRelease($appId: ID!, $spec: String!) {
 }`
 
 func (c *GraphQLClient) LintRelease(appID string, yaml string) ([]types.LintMessage, error) {
+	return c.LintReleaseContext(context.Background(), appID, yaml)
+}
+
+func (c *GraphQLClient) LintReleaseContext(ctx context.Context, appID string, yaml string) ([]types.LintMessage, error) {
 	response := GraphQLResponseLintRelease{}
 
 	request := graphql.Request{
@@ -311,7 +332,7 @@ func (c *GraphQLClient) LintRelease(appID string, yaml string) ([]types.LintMess
 		},
 	}
 
-	if err := c.ExecuteRequest(request, &response); err != nil {
+	if err := c.ExecuteRequestContext(ctx, request, &response); err != nil {
 		return nil, err
 	}
 
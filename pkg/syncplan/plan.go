@@ -0,0 +1,231 @@
+package syncplan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/releasediff"
+	"github.com/replicatedhq/replicated/pkg/types"
+)
+
+type ActionType string
+
+const (
+	ActionCreateChannel       ActionType = "create-channel"
+	ActionUpdateChannelSemver ActionType = "update-channel-semver"
+	ActionCreateRelease       ActionType = "create-release"
+	ActionPromoteRelease      ActionType = "promote-release"
+	ActionCreateCustomer      ActionType = "create-customer"
+)
+
+// An Action is a single change in a Plan. Only the spec matching its Type is set.
+type Action struct {
+	Type     ActionType
+	Channel  ChannelSpec
+	Release  ReleaseSpec
+	Customer CustomerSpec
+
+	// CurrentVersion is the version label the channel has before a release action
+	CurrentVersion string
+}
+
+// State is the current state of an app, as returned by the Vendor API
+type State struct {
+	// AppType is the type of the app, platform and ship channels don't
+	// support semantic versioning
+	AppType   string
+	Channels  []types.Channel
+	Releases  []types.ReleaseInfo
+	Customers []types.Customer
+
+	// ChannelFiles are the files of the current release of each channel that
+	// a release spec's version label matches, by channel name
+	ChannelFiles map[string][]releasediff.File
+	// YAMLDirFiles are the files in the yaml dir of those release specs, by
+	// yaml dir
+	YAMLDirFiles map[string][]releasediff.File
+}
+
+// A Plan is the ordered list of actions that brings an app in line with a Spec.
+// Channels are created before anything that is promoted or assigned to them.
+type Plan struct {
+	Actions  []Action
+	Warnings []string
+}
+
+func (p Plan) IsEmpty() bool {
+	return len(p.Actions) == 0
+}
+
+// Compute diffs spec against state and returns the actions needed to apply it
+func Compute(spec Spec, state State) (*Plan, error) {
+	plan := Plan{}
+
+	channelsByName := map[string]types.Channel{}
+	for _, channel := range state.Channels {
+		channelsByName[channel.Name] = channel
+	}
+	declaredChannels := map[string]bool{}
+
+	// channels of apps without semantic versioning always report it as
+	// disabled, so a semver setting would be planned on every run
+	var ignoredSemver []string
+	for _, channelSpec := range spec.Channels {
+		declaredChannels[channelSpec.Name] = true
+		if channelSpec.Semver != nil && !supportsSemver(state.AppType) {
+			ignoredSemver = append(ignoredSemver, fmt.Sprintf("%q", channelSpec.Name))
+			channelSpec.Semver = nil
+		}
+		existing, ok := channelsByName[channelSpec.Name]
+		if !ok {
+			plan.Actions = append(plan.Actions, Action{Type: ActionCreateChannel, Channel: channelSpec})
+			continue
+		}
+		if channelSpec.Semver != nil && *channelSpec.Semver != existing.SemverRequired {
+			plan.Actions = append(plan.Actions, Action{Type: ActionUpdateChannelSemver, Channel: channelSpec})
+		}
+	}
+
+	if len(ignoredSemver) > 0 {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("semantic versioning is not supported for %s apps, the semver setting of channel %s will be ignored", state.AppType, strings.Join(ignoredSemver, ", ")))
+	}
+
+	channelExists := func(name string) bool {
+		_, ok := channelsByName[name]
+		return ok || declaredChannels[name]
+	}
+
+	releaseSequences := map[int64]bool{}
+	for _, release := range state.Releases {
+		releaseSequences[release.Sequence] = true
+	}
+
+	for _, releaseSpec := range spec.Releases {
+		if !channelExists(releaseSpec.Channel) {
+			return nil, errors.Errorf("release channel %q is not declared in the spec and does not exist", releaseSpec.Channel)
+		}
+		if releaseSpec.Sequence != 0 && !releaseSequences[releaseSpec.Sequence] {
+			return nil, errors.Errorf("release sequence %d for channel %q does not exist", releaseSpec.Sequence, releaseSpec.Channel)
+		}
+
+		current := channelsByName[releaseSpec.Channel]
+		if current.ReleaseLabel == releaseSpec.Version &&
+			(releaseSpec.Sequence == 0 || current.ReleaseSequence == releaseSpec.Sequence) {
+			changed, err := filesChanged(releaseSpec, state)
+			if err != nil {
+				return nil, err
+			}
+			if !changed {
+				continue
+			}
+		}
+
+		actionType := ActionCreateRelease
+		if releaseSpec.Sequence != 0 {
+			actionType = ActionPromoteRelease
+		}
+		plan.Actions = append(plan.Actions, Action{
+			Type:           actionType,
+			Release:        releaseSpec,
+			CurrentVersion: current.ReleaseLabel,
+		})
+	}
+
+	customersByName := map[string]types.Customer{}
+	for _, customer := range state.Customers {
+		customersByName[customer.Name] = customer
+	}
+
+	for _, customerSpec := range spec.Customers {
+		if !channelExists(customerSpec.Channel) {
+			return nil, errors.Errorf("customer channel %q is not declared in the spec and does not exist", customerSpec.Channel)
+		}
+
+		existing, ok := customersByName[customerSpec.Name]
+		if !ok {
+			plan.Actions = append(plan.Actions, Action{Type: ActionCreateCustomer, Customer: customerSpec})
+			continue
+		}
+
+		onChannel := false
+		for _, channel := range existing.Channels {
+			if channel.Name == customerSpec.Channel || channel.ID == customerSpec.Channel {
+				onChannel = true
+			}
+		}
+		if !onChannel {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("customer %q exists but is not assigned to channel %q, it will not be changed", customerSpec.Name, customerSpec.Channel))
+		}
+	}
+
+	return &plan, nil
+}
+
+// filesChanged reports whether the files in a release spec's yaml dir differ
+// from those of the channel's current release. The version label alone does
+// not say, since manifests can be edited without changing it.
+func filesChanged(releaseSpec ReleaseSpec, state State) (bool, error) {
+	if releaseSpec.YAMLDir == "" {
+		return false, nil
+	}
+	current, ok := state.ChannelFiles[releaseSpec.Channel]
+	if !ok {
+		return false, nil
+	}
+	result, err := releasediff.Compare(releaseSpec.Channel, current, releaseSpec.YAMLDir, state.YAMLDirFiles[releaseSpec.YAMLDir])
+	if err != nil {
+		return false, errors.Wrapf(err, "compare %s to the release on channel %q", releaseSpec.YAMLDir, releaseSpec.Channel)
+	}
+	return !result.IsEmpty(), nil
+}
+
+func supportsSemver(appType string) bool {
+	return appType != "platform" && appType != "ship"
+}
+
+func (a Action) String() string {
+	switch a.Type {
+	case ActionCreateChannel:
+		if a.Channel.Semver != nil && *a.Channel.Semver {
+			return fmt.Sprintf("+ channel %q (semantic versioning enabled)", a.Channel.Name)
+		}
+		return fmt.Sprintf("+ channel %q", a.Channel.Name)
+	case ActionUpdateChannelSemver:
+		if *a.Channel.Semver {
+			return fmt.Sprintf("~ channel %q: enable semantic versioning", a.Channel.Name)
+		}
+		return fmt.Sprintf("~ channel %q: disable semantic versioning", a.Channel.Name)
+	case ActionCreateRelease:
+		return fmt.Sprintf("+ release from %s, promote to channel %q as %q%s", a.Release.YAMLDir, a.Release.Channel, a.Release.Version, a.currentVersionSuffix())
+	case ActionPromoteRelease:
+		return fmt.Sprintf("~ promote release %d to channel %q as %q%s", a.Release.Sequence, a.Release.Channel, a.Release.Version, a.currentVersionSuffix())
+	case ActionCreateCustomer:
+		return fmt.Sprintf("+ customer %q on channel %q", a.Customer.Name, a.Customer.Channel)
+	}
+	return string(a.Type)
+}
+
+func (a Action) currentVersionSuffix() string {
+	if a.CurrentVersion == "" {
+		return ""
+	}
+	if a.CurrentVersion == a.Release.Version {
+		return " (files changed)"
+	}
+	return fmt.Sprintf(" (currently %q)", a.CurrentVersion)
+}
+
+// Summary counts the actions in the plan the way terraform does
+func (p Plan) Summary() string {
+	add, change := 0, 0
+	for _, action := range p.Actions {
+		switch action.Type {
+		case ActionCreateChannel, ActionCreateRelease, ActionCreateCustomer:
+			add++
+		default:
+			change++
+		}
+	}
+	return fmt.Sprintf("Plan: %d to add, %d to change.", add, change)
+}
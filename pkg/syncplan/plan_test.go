@@ -0,0 +1,208 @@
+package syncplan
+
+import (
+	"testing"
+
+	"github.com/replicatedhq/replicated/pkg/releasediff"
+	"github.com/replicatedhq/replicated/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			spec: `channels:
+  - name: Beta
+    semver: true
+releases:
+  - yamlDir: ./manifests
+    channel: Beta
+    version: 1.0.0
+customers:
+  - name: dev
+    channel: Beta
+    expiresIn: 24h
+`,
+		},
+		{
+			name:    "unknown field",
+			spec:    "channel:\n  - name: Beta\n",
+			wantErr: "field channel not found",
+		},
+		{
+			name:    "release without version",
+			spec:    "releases:\n  - yamlDir: ./manifests\n    channel: Beta\n",
+			wantErr: `release for channel "Beta" requires a version`,
+		},
+		{
+			name:    "release with dir and sequence",
+			spec:    "releases:\n  - yamlDir: ./manifests\n    sequence: 3\n    channel: Beta\n    version: 1.0.0\n",
+			wantErr: "cannot set both yamlDir and sequence",
+		},
+		{
+			name:    "bad expiry",
+			spec:    "customers:\n  - name: dev\n    channel: Beta\n    expiresIn: soon\n",
+			wantErr: `parse expiresIn for customer "dev"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			_, err := Parse([]byte(tt.spec))
+			if tt.wantErr == "" {
+				req.NoError(err)
+				return
+			}
+			req.Error(err)
+			req.Contains(err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCompute(t *testing.T) {
+	enabled := true
+
+	tests := []struct {
+		name     string
+		spec     Spec
+		state    State
+		want     []string
+		warnings int
+		wantErr  string
+	}{
+		{
+			name: "everything new",
+			spec: Spec{
+				Channels:  []ChannelSpec{{Name: "Beta", Semver: &enabled}},
+				Releases:  []ReleaseSpec{{YAMLDir: "./manifests", Channel: "Beta", Version: "1.0.0"}},
+				Customers: []CustomerSpec{{Name: "dev", Channel: "Beta"}},
+			},
+			want: []string{
+				`+ channel "Beta" (semantic versioning enabled)`,
+				`+ release from ./manifests, promote to channel "Beta" as "1.0.0"`,
+				`+ customer "dev" on channel "Beta"`,
+			},
+		},
+		{
+			name: "up to date",
+			spec: Spec{
+				Channels:  []ChannelSpec{{Name: "Beta", Semver: &enabled}},
+				Releases:  []ReleaseSpec{{YAMLDir: "./manifests", Channel: "Beta", Version: "1.0.0"}},
+				Customers: []CustomerSpec{{Name: "dev", Channel: "Beta"}},
+			},
+			state: State{
+				Channels:  []types.Channel{{ID: "1", Name: "Beta", SemverRequired: true, ReleaseLabel: "1.0.0"}},
+				Customers: []types.Customer{{Name: "dev", Channels: []types.Channel{{ID: "1", Name: "Beta"}}}},
+			},
+			want: nil,
+		},
+		{
+			name: "same label, changed files",
+			spec: Spec{
+				Releases: []ReleaseSpec{{YAMLDir: "./manifests", Channel: "Beta", Version: "1.0.0"}},
+			},
+			state: State{
+				Channels:     []types.Channel{{ID: "1", Name: "Beta", ReleaseSequence: 3, ReleaseLabel: "1.0.0"}},
+				ChannelFiles: map[string][]releasediff.File{"Beta": {{Path: "config.yaml", Content: "replicas: 1\n"}}},
+				YAMLDirFiles: map[string][]releasediff.File{"./manifests": {{Path: "config.yaml", Content: "replicas: 2\n"}}},
+			},
+			want: []string{
+				`+ release from ./manifests, promote to channel "Beta" as "1.0.0" (files changed)`,
+			},
+		},
+		{
+			name: "same label, same files",
+			spec: Spec{
+				Releases: []ReleaseSpec{{YAMLDir: "./manifests", Channel: "Beta", Version: "1.0.0"}},
+			},
+			state: State{
+				Channels:     []types.Channel{{ID: "1", Name: "Beta", ReleaseSequence: 3, ReleaseLabel: "1.0.0"}},
+				ChannelFiles: map[string][]releasediff.File{"Beta": {{Path: "config.yaml", Content: "replicas: 1\n"}}},
+				YAMLDirFiles: map[string][]releasediff.File{"./manifests": {{Path: "config.yaml", Content: "replicas: 1\n"}}},
+			},
+			want: nil,
+		},
+		{
+			name: "changes to existing channel",
+			spec: Spec{
+				Channels: []ChannelSpec{{Name: "Stable", Semver: &enabled}},
+				Releases: []ReleaseSpec{{Sequence: 4, Channel: "Stable", Version: "1.1.0"}},
+			},
+			state: State{
+				Channels: []types.Channel{{ID: "1", Name: "Stable", ReleaseSequence: 3, ReleaseLabel: "1.0.0"}},
+				Releases: []types.ReleaseInfo{{Sequence: 3}, {Sequence: 4}},
+			},
+			want: []string{
+				`~ channel "Stable": enable semantic versioning`,
+				`~ promote release 4 to channel "Stable" as "1.1.0" (currently "1.0.0")`,
+			},
+		},
+		{
+			name: "semver on platform app",
+			spec: Spec{
+				Channels: []ChannelSpec{{Name: "Beta", Semver: &enabled}, {Name: "Stable", Semver: &enabled}},
+			},
+			state: State{
+				AppType:  "platform",
+				Channels: []types.Channel{{ID: "1", Name: "Stable"}},
+			},
+			want: []string{
+				`+ channel "Beta"`,
+			},
+			warnings: 1,
+		},
+		{
+			name: "customer on another channel",
+			spec: Spec{
+				Customers: []CustomerSpec{{Name: "dev", Channel: "Beta"}},
+			},
+			state: State{
+				Channels:  []types.Channel{{ID: "1", Name: "Beta"}, {ID: "2", Name: "Stable"}},
+				Customers: []types.Customer{{Name: "dev", Channels: []types.Channel{{ID: "2", Name: "Stable"}}}},
+			},
+			want:     nil,
+			warnings: 1,
+		},
+		{
+			name: "undeclared channel",
+			spec: Spec{
+				Releases: []ReleaseSpec{{YAMLDir: "./manifests", Channel: "Nope", Version: "1.0.0"}},
+			},
+			wantErr: `release channel "Nope" is not declared`,
+		},
+		{
+			name: "missing sequence",
+			spec: Spec{
+				Releases: []ReleaseSpec{{Sequence: 9, Channel: "Stable", Version: "1.0.0"}},
+			},
+			state: State{
+				Channels: []types.Channel{{ID: "1", Name: "Stable"}},
+			},
+			wantErr: "release sequence 9",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			plan, err := Compute(tt.spec, tt.state)
+			if tt.wantErr != "" {
+				req.Error(err)
+				req.Contains(err.Error(), tt.wantErr)
+				return
+			}
+			req.NoError(err)
+
+			var got []string
+			for _, action := range plan.Actions {
+				got = append(got, action.String())
+			}
+			req.Equal(tt.want, got)
+			req.Len(plan.Warnings, tt.warnings)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Package syncplan reads a replicated.yaml spec describing the desired
+// channels, releases and customers of an app, and computes the changes needed
+// to bring the app in line with it.
+package syncplan
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Spec is the desired state of an app, as declared in replicated.yaml
+type Spec struct {
+	Channels  []ChannelSpec  `yaml:"channels"`
+	Releases  []ReleaseSpec  `yaml:"releases"`
+	Customers []CustomerSpec `yaml:"customers"`
+}
+
+type ChannelSpec struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// Semver is left unchanged on the channel when not set
+	Semver *bool `yaml:"semver"`
+}
+
+// ReleaseSpec promotes either a new release built from YAMLDir or an existing
+// release Sequence to Channel with the Version label
+type ReleaseSpec struct {
+	YAMLDir      string `yaml:"yamlDir"`
+	Sequence     int64  `yaml:"sequence"`
+	Channel      string `yaml:"channel"`
+	Version      string `yaml:"version"`
+	ReleaseNotes string `yaml:"releaseNotes"`
+}
+
+type CustomerSpec struct {
+	Name      string `yaml:"name"`
+	Channel   string `yaml:"channel"`
+	ExpiresIn string `yaml:"expiresIn"`
+}
+
+// ParseFile reads and validates the spec at path. Relative yamlDir entries are
+// resolved against the directory containing the spec.
+func ParseFile(path string) (*Spec, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read %s", path)
+	}
+
+	spec, err := Parse(b)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse %s", path)
+	}
+
+	baseDir := filepath.Dir(path)
+	for i, release := range spec.Releases {
+		if release.YAMLDir != "" && !filepath.IsAbs(release.YAMLDir) {
+			spec.Releases[i].YAMLDir = filepath.Join(baseDir, release.YAMLDir)
+		}
+	}
+
+	return spec, nil
+}
+
+func Parse(b []byte) (*Spec, error) {
+	spec := Spec{}
+	if err := yaml.UnmarshalStrict(b, &spec); err != nil {
+		return nil, errors.Wrap(err, "unmarshal spec")
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+func (s Spec) Validate() error {
+	channelNames := map[string]bool{}
+	for _, channel := range s.Channels {
+		if channel.Name == "" {
+			return errors.New("every channel requires a name")
+		}
+		if channelNames[channel.Name] {
+			return errors.Errorf("channel %q is declared more than once", channel.Name)
+		}
+		channelNames[channel.Name] = true
+	}
+
+	promotedChannels := map[string]bool{}
+	for i, release := range s.Releases {
+		if release.Channel == "" {
+			return errors.Errorf("release %d requires a channel", i+1)
+		}
+		if promotedChannels[release.Channel] {
+			return errors.Errorf("channel %q has more than one release promoted to it", release.Channel)
+		}
+		promotedChannels[release.Channel] = true
+		if release.YAMLDir == "" && release.Sequence == 0 {
+			return errors.Errorf("release for channel %q requires one of yamlDir or sequence", release.Channel)
+		}
+		if release.YAMLDir != "" && release.Sequence != 0 {
+			return errors.Errorf("release for channel %q cannot set both yamlDir and sequence", release.Channel)
+		}
+		if release.Version == "" {
+			return errors.Errorf("release for channel %q requires a version", release.Channel)
+		}
+	}
+
+	customerNames := map[string]bool{}
+	for _, customer := range s.Customers {
+		if customer.Name == "" {
+			return errors.New("every customer requires a name")
+		}
+		if customer.Channel == "" {
+			return errors.Errorf("customer %q requires a channel", customer.Name)
+		}
+		if customerNames[customer.Name] {
+			return errors.Errorf("customer %q is declared more than once", customer.Name)
+		}
+		customerNames[customer.Name] = true
+		if customer.ExpiresIn != "" {
+			if _, err := time.ParseDuration(customer.ExpiresIn); err != nil {
+				return errors.Wrapf(err, "parse expiresIn for customer %q", customer.Name)
+			}
+		}
+	}
+
+	return nil
+}
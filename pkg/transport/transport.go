@@ -0,0 +1,257 @@
+// Package transport is the HTTP transport shared by the API clients. It adds
+// per-attempt timeouts and retries with exponential backoff to every request.
+package transport
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultTimeout    = 2 * time.Minute
+	DefaultMaxRetries = 3
+
+	defaultMinBackoff = 500 * time.Millisecond
+	defaultMaxBackoff = 30 * time.Second
+
+	// maxRetryAfter caps how long a Retry-After header can make a request wait
+	maxRetryAfter = 5 * time.Minute
+)
+
+// DefaultClient is used by all of the API clients. Configure changes its
+// timeout and retry settings.
+var DefaultClient = &http.Client{
+	Transport: &RetryTransport{
+		Base:       http.DefaultTransport,
+		Timeout:    DefaultTimeout,
+		MaxRetries: DefaultMaxRetries,
+		MinBackoff: defaultMinBackoff,
+		MaxBackoff: defaultMaxBackoff,
+	},
+}
+
+// Configure sets the per-attempt timeout and the maximum number of retries of
+// DefaultClient. A timeout of 0 disables the timeout.
+func Configure(timeout time.Duration, maxRetries int) {
+	DefaultClient.Transport = NewClient(timeout, maxRetries).Transport
+}
+
+// NewClient returns a client with the same retry behaviour as DefaultClient,
+// for API clients that are not configured globally
+func NewClient(timeout time.Duration, maxRetries int) *http.Client {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	return &http.Client{
+		Transport: &RetryTransport{
+			Base:       http.DefaultTransport,
+			Timeout:    timeout,
+			MaxRetries: maxRetries,
+			MinBackoff: defaultMinBackoff,
+			MaxBackoff: defaultMaxBackoff,
+		},
+	}
+}
+
+type idempotentKey struct{}
+
+// WithIdempotent marks requests made with ctx as safe to retry, for POST
+// requests that do not change anything, such as GraphQL queries
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+type retryHookKey struct{}
+
+// WithRetryHook makes requests made with ctx call hook on every retry, before
+// it is sent, for headers that must not be resent as they were, such as a
+// signature's timestamp and nonce
+func WithRetryHook(ctx context.Context, hook func(*http.Request) error) context.Context {
+	return context.WithValue(ctx, retryHookKey{}, hook)
+}
+
+// RetryTransport retries requests that failed with a network error or a
+// retryable status. Only requests that are safe to repeat are retried: GET,
+// HEAD, OPTIONS and TRACE requests, requests with an Idempotency-Key header,
+// and requests whose context was marked with WithIdempotent. A 429 response
+// is retried for any method, as the request was not processed.
+type RetryTransport struct {
+	Base http.RoundTripper
+
+	// Timeout bounds each attempt, including reading the response body
+	Timeout    time.Duration
+	MaxRetries int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	randMu sync.Mutex
+	rand   *rand.Rand
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	// a body that cannot be rewound can only be sent once
+	rewindable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		attemptReq, cancel, err := t.attemptRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := base.RoundTrip(attemptReq)
+
+		if attempt >= t.MaxRetries || !rewindable || !t.shouldRetry(req, resp, err) {
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+
+		wait := t.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				wait = retryAfter
+			}
+			// drain so the connection can be reused
+			_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64*1024))
+			resp.Body.Close()
+		}
+		cancel()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// attemptRequest clones req with a fresh body and the per-attempt timeout
+func (t *RetryTransport) attemptRequest(req *http.Request, attempt int) (*http.Request, context.CancelFunc, error) {
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+	if t.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.Timeout)
+	}
+
+	attemptReq := req.Clone(ctx)
+	if attempt > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		attemptReq.Body = body
+	}
+	if hook, ok := req.Context().Value(retryHookKey{}).(func(*http.Request) error); ok && attempt > 0 {
+		if err := hook(attemptReq); err != nil {
+			cancel()
+			return nil, nil, err
+		}
+	}
+	return attemptReq, cancel, nil
+}
+
+func (t *RetryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if !isIdempotent(req) {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	if req.Header.Get("Idempotency-Key") != "" {
+		return true
+	}
+	idempotent, _ := req.Context().Value(idempotentKey{}).(bool)
+	return idempotent
+}
+
+// backoff returns an exponential delay with full jitter for the given attempt
+func (t *RetryTransport) backoff(attempt int) time.Duration {
+	ceiling := t.MinBackoff
+	for i := 0; i < attempt && ceiling < t.MaxBackoff; i++ {
+		ceiling *= 2
+	}
+	if ceiling > t.MaxBackoff {
+		ceiling = t.MaxBackoff
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+
+	t.randMu.Lock()
+	defer t.randMu.Unlock()
+	if t.rand == nil {
+		t.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return time.Duration(t.rand.Int63n(int64(ceiling)) + 1)
+}
+
+// parseRetryAfter reads a Retry-After header in either delay-seconds or
+// HTTP-date form
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		wait = date.Sub(now)
+	} else {
+		return 0, false
+	}
+
+	if wait < 0 {
+		wait = 0
+	}
+	if wait > maxRetryAfter {
+		wait = maxRetryAfter
+	}
+	return wait, true
+}
+
+// cancelOnClose releases the attempt's timeout once the body has been read
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
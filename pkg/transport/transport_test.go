@@ -0,0 +1,189 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		ctx          context.Context
+		statuses     []int
+		retryAfter   string
+		wantStatus   int
+		wantAttempts int32
+	}{
+		{
+			name:         "get retried until success",
+			method:       http.MethodGet,
+			statuses:     []int{503, 502, 200},
+			wantStatus:   200,
+			wantAttempts: 3,
+		},
+		{
+			name:         "get gives up after max retries",
+			method:       http.MethodGet,
+			statuses:     []int{500, 500, 500, 500, 500},
+			wantStatus:   500,
+			wantAttempts: 3,
+		},
+		{
+			name:         "post not retried on 5xx",
+			method:       http.MethodPost,
+			statuses:     []int{503, 200},
+			wantStatus:   503,
+			wantAttempts: 1,
+		},
+		{
+			name:         "post retried on 429",
+			method:       http.MethodPost,
+			statuses:     []int{429, 201},
+			retryAfter:   "0",
+			wantStatus:   201,
+			wantAttempts: 2,
+		},
+		{
+			name:         "post marked idempotent retried on 5xx",
+			method:       http.MethodPost,
+			ctx:          WithIdempotent(context.Background()),
+			statuses:     []int{503, 200},
+			wantStatus:   200,
+			wantAttempts: 2,
+		},
+		{
+			name:         "client errors not retried",
+			method:       http.MethodGet,
+			statuses:     []int{400, 200},
+			wantStatus:   400,
+			wantAttempts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&attempts, 1)
+				body, _ := ioutil.ReadAll(r.Body)
+				if r.Method == http.MethodPost {
+					req.Equal("payload", string(body))
+				}
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: &RetryTransport{
+				Timeout:    time.Second,
+				MaxRetries: 2,
+				MinBackoff: time.Millisecond,
+				MaxBackoff: 5 * time.Millisecond,
+			}}
+
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			httpReq, err := http.NewRequestWithContext(ctx, tt.method, server.URL, bytes.NewBufferString("payload"))
+			req.NoError(err)
+
+			resp, err := client.Do(httpReq)
+			req.NoError(err)
+			resp.Body.Close()
+
+			req.Equal(tt.wantStatus, resp.StatusCode)
+			req.Equal(tt.wantAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+}
+
+func TestRetryHook(t *testing.T) {
+	req := require.New(t)
+
+	var headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get("Attempt"))
+		if len(headers) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{
+		Timeout:    time.Second,
+		MaxRetries: 2,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 5 * time.Millisecond,
+	}}
+
+	retries := 0
+	ctx := WithRetryHook(context.Background(), func(r *http.Request) error {
+		retries++
+		r.Header.Set("Attempt", strconv.Itoa(retries+1))
+		return nil
+	})
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	req.NoError(err)
+	httpReq.Header.Set("Attempt", "1")
+
+	resp, err := client.Do(httpReq)
+	req.NoError(err)
+	resp.Body.Close()
+
+	req.Equal(http.StatusOK, resp.StatusCode)
+	req.Equal([]string{"1", "2", "3"}, headers)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "empty", value: ""},
+		{name: "seconds", value: "7", want: 7 * time.Second, wantOK: true},
+		{name: "date", value: "Tue, 01 Jun 2021 12:00:30 GMT", want: 30 * time.Second, wantOK: true},
+		{name: "date in the past", value: "Tue, 01 Jun 2021 11:00:00 GMT", want: 0, wantOK: true},
+		{name: "capped", value: "86400", want: maxRetryAfter, wantOK: true},
+		{name: "invalid", value: "soon"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+			got, ok := parseRetryAfter(tt.value, now)
+			req.Equal(tt.wantOK, ok)
+			req.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	req := require.New(t)
+	transport := &RetryTransport{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		ceiling := 100 * time.Millisecond << uint(attempt)
+		if ceiling > time.Second {
+			ceiling = time.Second
+		}
+		wait := transport.backoff(attempt)
+		req.True(wait > 0 && wait <= ceiling, "attempt %d waited %s", attempt, wait)
+	}
+}
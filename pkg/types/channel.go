@@ -23,6 +23,7 @@ type KotsChannel struct {
 	// TODO: set these (see kotsChannelToSchema function)
 	ReleaseSequence int32            `json:"releaseSequence,omitempty"`
 	Releases        []ChannelRelease `json:"releases,omitempty"`
+	SemverRequired  bool             `json:"semverRequired,omitempty"`
 	Updated         time.Time        `json:"updated,omitempty"`
 }
 
@@ -66,17 +67,18 @@ type UpdateChannelRequest struct {
 }
 
 type Channel struct {
-	ID          string
-	Name        string
-	Description string
-	Slug        string
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Slug        string `json:"slug"`
 
-	ReleaseSequence int64
-	ReleaseLabel    string
+	ReleaseSequence int64  `json:"releaseSequence"`
+	ReleaseLabel    string `json:"releaseLabel"`
+	SemverRequired  bool   `json:"semverRequired"`
 
 	IsArchived bool `json:"isArchived"`
 
-	InstallCommands *InstallCommands
+	InstallCommands *InstallCommands `json:"installCommands,omitempty"`
 }
 
 func (c *Channel) Copy() *Channel {
@@ -87,12 +89,13 @@ func (c *Channel) Copy() *Channel {
 		Slug:            c.Slug,
 		ReleaseSequence: c.ReleaseSequence,
 		ReleaseLabel:    c.ReleaseLabel,
+		SemverRequired:  c.SemverRequired,
 		InstallCommands: c.InstallCommands,
 	}
 }
 
 type InstallCommands struct {
-	Existing string
-	Embedded string
-	Airgap   string
+	Existing string `json:"existing"`
+	Embedded string `json:"embedded"`
+	Airgap   string `json:"airgap"`
 }
@@ -3,11 +3,11 @@ package types
 import "time"
 
 type CollectorInfo struct {
-	ActiveChannels []Channel
-	AppID          string
-	CreatedAt      time.Time
-	EditedAt       time.Time
-	Name           string
-	SpecID         string
-	Config         string
+	ActiveChannels []Channel `json:"activeChannels"`
+	AppID          string    `json:"appId"`
+	CreatedAt      time.Time `json:"createdAt"`
+	EditedAt       time.Time `json:"editedAt"`
+	Name           string    `json:"name"`
+	SpecID         string    `json:"specId"`
+	Config         string    `json:"config"`
 }
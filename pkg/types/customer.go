@@ -1,16 +1,22 @@
 package types
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/replicatedhq/replicated/pkg/util"
 )
 
 type Customer struct {
-	ID       string     `json:"id"`
-	Name     string     `json:"name"`
-	Channels []Channel  `json:"channels"`
-	Type     string     `json:"type"`
-	Expires  *util.Time `json:"expiresAt"`
+	ID                  string     `json:"id"`
+	Name                string     `json:"name"`
+	Channels            []Channel  `json:"channels"`
+	Type                string     `json:"type"`
+	Expires             *util.Time `json:"expiresAt"`
+	IsAirgapEnabled     bool       `json:"airgap"`
+	IsGitopsSupported   bool       `json:"isGitopsSupported"`
+	IsSnapshotSupported bool       `json:"isSnapshotSupported"`
+	IsArchived          bool       `json:"isArchived"`
 }
 
 func (c Customer) WithExpiryTime(expiryTime string) (Customer, error) {
@@ -24,6 +30,23 @@ func (c Customer) WithExpiryTime(expiryTime string) (Customer, error) {
 	return c, nil
 }
 
+// CustomerOptions are the fields of a customer that can be set when it is
+// created or updated
+type CustomerOptions struct {
+	Name      string
+	ChannelID string
+	// Type is one of dev, trial, paid or community
+	Type              string
+	ExpiresAt         *time.Time
+	EntitlementValues []EntitlementValue
+
+	IsAirgapEnabled     bool
+	IsGitopsSupported   bool
+	IsSnapshotSupported bool
+}
+
+var CustomerTypes = []string{"dev", "trial", "paid", "community"}
+
 type TotalActiveInactiveCustomers struct {
 	ActiveCustomers   int64 `json:"activeCustomers,omitempty"`
 	InactiveCustomers int64 `json:"inactiveCustomers,omitempty"`
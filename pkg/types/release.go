@@ -3,13 +3,13 @@ package types
 import "time"
 
 type ReleaseInfo struct {
-	ActiveChannels []Channel
-	AppID          string
-	CreatedAt      time.Time
-	EditedAt       time.Time
-	Editable       bool
-	Sequence       int64
-	Version        string
+	ActiveChannels []Channel `json:"activeChannels"`
+	AppID          string    `json:"appId"`
+	CreatedAt      time.Time `json:"createdAt"`
+	EditedAt       time.Time `json:"editedAt"`
+	Editable       bool      `json:"editable"`
+	Sequence       int64     `json:"sequence"`
+	Version        string    `json:"version"`
 }
 
 type LintMessage struct {
@@ -56,6 +56,15 @@ type KotsPromoteReleaseRequest struct {
 	IgnoreWarnings bool     `json:"ignoreWarnings"`
 }
 
+// ChannelPromotion is the outcome of promoting a release to one channel
+type ChannelPromotion struct {
+	Channel   string `json:"channel"`
+	ChannelID string `json:"channelId,omitempty"`
+	Sequence  int64  `json:"sequence"`
+	Promoted  bool   `json:"promoted"`
+	Error     string `json:"error,omitempty"`
+}
+
 type KotsAppRelease struct {
 	AppID                string     `json:"appId"`
 	Sequence             int64      `json:"sequence"`
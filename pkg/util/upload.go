@@ -1,18 +1,25 @@
 package util
 
 import (
+	"context"
 	"net/http"
 	"os"
+
+	"github.com/replicatedhq/replicated/pkg/transport"
 )
 
 func UploadFile(fullpath string, url string) error {
+	return UploadFileContext(context.Background(), fullpath, url)
+}
+
+func UploadFileContext(ctx context.Context, fullpath string, url string) error {
 	file, err := os.Open(fullpath)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	req, err := http.NewRequest("PUT", url, file)
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, file)
 	if err != nil {
 		return err
 	}
@@ -25,10 +32,11 @@ func UploadFile(fullpath string, url string) error {
 
 	req.Header.Set("Content-Type", "application/x-yaml")
 
-	res, err := http.DefaultClient.Do(req)
+	res, err := transport.DefaultClient.Do(req)
 	if err != nil {
 		return err
 	}
+	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
 		return err